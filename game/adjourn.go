@@ -0,0 +1,78 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// AdjournmentVersion is the version of the adjournment written by WriteAdjournment.
+// ReadAdjournment reads adjournments of this and earlier versions.
+const AdjournmentVersion = 1
+
+// adjournmentFormat is the name of the format stored in the adjournment
+const adjournmentFormat = "gomaster adjournment"
+
+// adjournment is the JSON object written by WriteAdjournment
+type adjournment struct {
+	Format  string
+	Version int
+	Game    *Snapshot
+}
+
+// WriteAdjournment writes snap to w in the adjournment format,
+// so the suspended game can be resumed by RestoreGame on another server.
+//
+// The adjournment is the JSON object
+//
+//	{"Format": "gomaster adjournment", "Version": 1, "Game": {...}}
+//
+// Game holds fields of Snapshot by their names: players with their clocks,
+// moves of the history, the ruleset and the variant of the field, komi,
+// time control and the state of the game.
+// Durations are integer nanoseconds, times are RFC 3339 strings,
+// colours, kinds of moves, rulesets and result methods are names,
+// e.g. "black", "pass", "chinese", "resign".
+//
+// Version is increased when the meaning of existing fields changes.
+// New fields may be added without it, fields missing in the adjournment
+// written before take zero values, which keep the former behaviour.
+// ReadAdjournment rejects adjournments of newer versions.
+func WriteAdjournment(w io.Writer, snap *Snapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(&adjournment{Format: adjournmentFormat, Version: AdjournmentVersion, Game: snap})
+}
+
+// ReadAdjournment reads the snapshot of the game written by WriteAdjournment from r.
+func ReadAdjournment(r io.Reader) (*Snapshot, error) {
+	var adj adjournment
+	if err := json.NewDecoder(r).Decode(&adj); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAdjournment, err)
+	}
+	switch {
+	case adj.Format != adjournmentFormat:
+		return nil, fmt.Errorf("%w: format %q", ErrAdjournment, adj.Format)
+	case adj.Version < 1 || adj.Version > AdjournmentVersion:
+		return nil, fmt.Errorf("%w: version %d", ErrAdjournment, adj.Version)
+	case adj.Game == nil:
+		return nil, fmt.Errorf("%w: no game", ErrAdjournment)
+	}
+	return adj.Game, nil
+}
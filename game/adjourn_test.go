@@ -0,0 +1,138 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestAdjournment checks that the game adjourned on one server is resumed on another one.
+func TestAdjournment(t *testing.T) {
+	game, black, white := pieGame(t, WithFischerTime(time.Minute, 10*time.Second),
+		WithFieldOptions(field.WithRuleset(igame.ChineseRules)))
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	snap, err := game.Snapshot()
+	if err != nil {
+		t.Fatalf("Unexpected Snapshot err: %v", err)
+	}
+	game.End()
+
+	var buf bytes.Buffer
+	if err := WriteAdjournment(&buf, snap); err != nil {
+		t.Fatalf("Unexpected WriteAdjournment err: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"Ruleset": "chinese"`) {
+		t.Errorf("Unexpected adjournment without the ruleset:\n%s", buf.String())
+	}
+	loaded, err := ReadAdjournment(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected ReadAdjournment err: %v", err)
+	}
+
+	restored, err := RestoreGame(loaded)
+	if err != nil {
+		t.Fatalf("Unexpected RestoreGame err: %v", err)
+	}
+	defer restored.End()
+
+	conf, err := restored.Settings(black)
+	if err != nil {
+		t.Fatalf("Unexpected Settings err: %v", err)
+	}
+	if conf.Ruleset != igame.ChineseRules {
+		t.Errorf("Unexpected ruleset:\nwant: %v,\ngot: %v", igame.ChineseRules, conf.Ruleset)
+	}
+	if moves, err := restored.History(black); err != nil || len(moves) != 2 {
+		t.Errorf("Unexpected History: %v, err: %v", moves, err)
+	}
+	if err := restored.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Errorf("Unexpected MakeTurn err after restoring: %v", err)
+	}
+}
+
+// TestReadAdjournmentFailures checks rejection of data which is not the supported adjournment.
+func TestReadAdjournmentFailures(t *testing.T) {
+	tests := []struct {
+		caseName string
+		data     string
+	}{
+		{caseName: "not JSON", data: "(;GM[1])"},
+		{caseName: "other format", data: `{"Format": "other", "Version": 1, "Game": {}}`},
+		{caseName: "newer version", data: `{"Format": "gomaster adjournment", "Version": 2, "Game": {}}`},
+		{caseName: "no game", data: `{"Format": "gomaster adjournment", "Version": 1}`},
+	}
+	for _, test := range tests {
+		t.Run(test.caseName, func(t *testing.T) {
+			if _, err := ReadAdjournment(strings.NewReader(test.data)); !errors.Is(err, ErrAdjournment) {
+				t.Errorf("Unexpected ReadAdjournment err:\nwant: %v,\ngot: %v", ErrAdjournment, err)
+			}
+		})
+	}
+}
+
+// TestAdjournmentVariant checks that options of the field are kept by the adjournment.
+func TestAdjournmentVariant(t *testing.T) {
+	game, _, _ := pieGame(t, WithBlindGo(true), WithFieldOptions(field.WithCaptureGo(),
+		field.WithHalfPointKomi(), field.WithJigoWinner(igame.White)))
+	snap, err := game.Snapshot()
+	if err != nil {
+		t.Fatalf("Unexpected Snapshot err: %v", err)
+	}
+	game.End()
+
+	var buf bytes.Buffer
+	if err := WriteAdjournment(&buf, snap); err != nil {
+		t.Fatalf("Unexpected WriteAdjournment err: %v", err)
+	}
+	loaded, err := ReadAdjournment(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected ReadAdjournment err: %v", err)
+	}
+	restored, err := RestoreGame(loaded)
+	if err != nil {
+		t.Fatalf("Unexpected RestoreGame err: %v", err)
+	}
+	defer restored.End()
+
+	// the restored game is snapshotted again with the same variant and one filter of blind go.
+	again, err := restored.Snapshot()
+	if err != nil {
+		t.Fatalf("Unexpected Snapshot err of the restored game: %v", err)
+	}
+	if !again.CaptureGo || !again.HalfPointKomi || again.JigoWinner != igame.White || !again.Blind || !again.BlindAll {
+		t.Errorf("Unexpected variant of the restored game: %+v", again)
+	}
+}
+
+// TestSnapshotStateFilter checks that the game with filters of the state can't be snapshotted.
+func TestSnapshotStateFilter(t *testing.T) {
+	game, _, _ := pieGame(t, WithFieldOptions(field.WithOneColour()))
+	defer game.End()
+
+	if _, err := game.Snapshot(); !errors.Is(err, ErrSnapshot) {
+		t.Errorf("Unexpected Snapshot err:\nwant: %v,\ngot: %v", ErrSnapshot, err)
+	}
+}
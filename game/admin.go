@@ -0,0 +1,73 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Admin is the handle of the administrator of the Game bound to it by WithAdmin.
+// It's meant for moderation and maintenance, not for gamers,
+// so it's kept by the server and isn't given out with the Game.
+type Admin struct {
+	game *Game
+}
+
+// ForceEnd finishes the game in progress for the reason.
+// The game is void if winner is NoColour, else it's won by winner by forfeit.
+// Awaiting gamers are released with ForceEndedReason.
+func (a *Admin) ForceEnd(reason string, winner igame.ChipColour) error {
+	return a.ForceEndContext(context.Background(), reason, winner)
+}
+
+// ForceEndContext is like ForceEnd, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (a *Admin) ForceEndContext(ctx context.Context, reason string, winner igame.ChipColour) error {
+	if a.game == nil {
+		return ErrNoAdmin
+	}
+	if winner != igame.NoColour && winner != igame.Black && winner != igame.White {
+		return fmt.Errorf("%w: %v", ErrWrongColour, winner)
+	}
+	return errorOf(a.game.request(ctx, &gameCommand{act: forceEndCMD, text: reason, colour: winner}))
+}
+
+// forceEnd implements concurrently safe processing of querry of
+// ForceEnd function of the Admin
+func forceEnd(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if gd.gameOver {
+		cmd.rez <- ErrGameOver
+		return
+	}
+
+	gd.result = &igame.Result{Winner: cmd.colour, Method: igame.ForfeitMethod}
+	if cmd.colour == igame.NoColour {
+		gd.result.Method = igame.AbortMethod
+	}
+	gd.counting = false
+	gd.gameOver = true
+	publish(gd.subscribers, GameEvent{Kind: ForceEndedEvent, Reason: cmd.text})
+	for id, gs := range gamerStates {
+		ended := waitResult(gamerStates, id, gd, ForceEndedReason)
+		reportOnChan(&gs.beMSGChan, ended)
+		reportOnChan(&gs.turnMSGChan, ended)
+	}
+}
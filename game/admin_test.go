@@ -0,0 +1,90 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestForceEnd checks that the game ended by the administrator releases awaiting gamers.
+func TestForceEnd(t *testing.T) {
+	admin := &Admin{}
+	game, black, white := pieGame(t, WithAdmin(admin))
+	defer game.End()
+
+	events, cancel := game.Subscribe(white)
+	defer cancel()
+	ch := make(chan error)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), fastDurationThreshold)
+		defer cancel()
+		ch <- game.WaitTurn(ctx, black)
+	}()
+
+	time.Sleep(rtDurationThreshold / 2)
+	if err := admin.ForceEnd("maintenance", igame.NoColour); err != nil {
+		t.Fatalf("Unexpected ForceEnd err: %v", err)
+	}
+	if err := <-ch; !errors.Is(err, ErrForceEnded) {
+		t.Errorf("Unexpected WaitTurn err:\nwant: %v,\ngot: %v", ErrForceEnded, err)
+	}
+	if ev := nextEvent(t, events); ev.Kind != ForceEndedEvent || ev.Reason != "maintenance" {
+		t.Errorf("Unexpected event of ForceEnd: %+v", ev)
+	}
+	result, err := game.Result(white)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if result.Winner != igame.NoColour || result.Method != igame.AbortMethod {
+		t.Errorf("Unexpected Result:\nwant: void game,\ngot: %v", result)
+	}
+	if err := admin.ForceEnd("again", igame.Black); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Unexpected ForceEnd err of the finished game:\nwant: %v,\ngot: %v", ErrGameOver, err)
+	}
+}
+
+// TestForceEndForfeit checks that the administrator can award the win by forfeit.
+func TestForceEndForfeit(t *testing.T) {
+	admin := &Admin{}
+	game, black, _ := pieGame(t, WithAdmin(admin))
+	defer game.End()
+
+	if err := admin.ForceEnd("cheating of white", igame.ChipColour(3)); !errors.Is(err, ErrWrongColour) {
+		t.Errorf("Unexpected ForceEnd err with unknown colour:\nwant: %v,\ngot: %v", ErrWrongColour, err)
+	}
+	if err := admin.ForceEnd("cheating of white", igame.Black); err != nil {
+		t.Fatalf("Unexpected ForceEnd err: %v", err)
+	}
+	if result, err := game.Result(black); err != nil || result.Winner != igame.Black || result.Method != igame.ForfeitMethod {
+		t.Errorf("Unexpected Result:\nwant: win of black by forfeit,\ngot: %v, err: %v", result, err)
+	}
+}
+
+// TestForceEndNoAdmin checks that the Admin not bound to a game can't end one.
+func TestForceEndNoAdmin(t *testing.T) {
+	game, _, _ := pieGame(t)
+	defer game.End()
+
+	if err := (&Admin{}).ForceEnd("maintenance", igame.NoColour); !errors.Is(err, ErrNoAdmin) {
+		t.Errorf("Unexpected ForceEnd err:\nwant: %v,\ngot: %v", ErrNoAdmin, err)
+	}
+}
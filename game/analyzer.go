@@ -0,0 +1,53 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "time"
+
+// MoveTiming describes the timing of a move for analysis of consistency of gamers
+type MoveTiming struct {
+	GameID string        // id of the game set by WithLogger
+	Move   HistoryMove   // the move with the time it's made and metadata of the client
+	Think  time.Duration // time since the previous move or the beginning of the game
+}
+
+// MoveAnalyzer analyzes timings of moves, e.g. to detect use of engines by gamers
+type MoveAnalyzer interface {
+	AnalyzeMove(timing MoveTiming)
+}
+
+// lastTiming returns the timing of the last move of the game, nil if no moves made yet
+func lastTiming(gd *gmaeDescriptor) *MoveTiming {
+	if len(gd.history) == 0 {
+		return nil
+	}
+	move := gd.history[len(gd.history)-1]
+	previous := gd.begun
+	if len(gd.history) > 1 {
+		previous = gd.history[len(gd.history)-2].Time
+	}
+	return &MoveTiming{GameID: gd.cfg.gameID, Move: move, Think: move.Time.Sub(previous)}
+}
+
+// analyzeMoves feeds the analyzer of cfg with timings of moves until the game is destroyed
+func analyzeMoves(cfg *config, events <-chan GameEvent) {
+	for ev := range events {
+		if ev.Timing != nil {
+			cfg.analyzer.AnalyzeMove(*ev.Timing)
+		}
+	}
+}
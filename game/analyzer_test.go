@@ -0,0 +1,81 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// chanAnalyzer is a MoveAnalyzer passing timings to the channel
+type chanAnalyzer chan MoveTiming
+
+func (a chanAnalyzer) AnalyzeMove(timing MoveTiming) {
+	a <- timing
+}
+
+// TestMoveAnalyzer checks timings and metadata of moves passed to the analyzer.
+func TestMoveAnalyzer(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	analyzer := make(chanAnalyzer, 10)
+	game, black, white := pieGame(t, WithTimeSource(ft), WithLogger(make(chanLogger, 100), "g1"), WithMoveAnalyzer(analyzer))
+	defer game.End()
+
+	events, cancel := game.Subscribe(black)
+	defer cancel()
+
+	ft.advance(10 * time.Second)
+	meta := map[string]string{"sent": "2020-01-01T00:00:09Z"}
+	if err := game.MakeTurnWithMeta(white, &igame.TurnData{X: 3, Y: 3}, meta); err != nil {
+		t.Fatalf("Unexpected MakeTurnWithMeta err: %v", err)
+	}
+	meta["sent"] = "changed"
+	ft.advance(5 * time.Second)
+	if err := game.Pass(black); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+
+	want := []struct {
+		id    int
+		kind  igame.MoveKind
+		think time.Duration
+		sent  string
+	}{
+		{black, igame.PlaceMove, 0, ""},
+		{white, igame.PlaceMove, 10 * time.Second, "2020-01-01T00:00:09Z"},
+		{black, igame.PassMove, 5 * time.Second, ""},
+	}
+	for _, w := range want {
+		select {
+		case timing := <-analyzer:
+			if timing.GameID != "g1" || timing.Move.ID != w.id || timing.Move.Kind != w.kind ||
+				timing.Think != w.think || timing.Move.Meta["sent"] != w.sent {
+				t.Errorf("Unexpected timing:\nwant: %+v,\ngot: %+v", w, timing)
+			}
+		case <-time.After(rtDurationThreshold):
+			t.Fatalf("No timing of the move of %d", w.id)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if ev := nextEvent(t, events); ev.Timing != nil {
+			t.Errorf("Unexpected timing for the gamer: %+v", ev.Timing)
+		}
+	}
+}
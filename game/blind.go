@@ -0,0 +1,29 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// hidden checks that moves of colour are hidden from the viewer playing by viewer in blind go,
+// NoColour checks that any moves are hidden from the viewer.
+// Moves are shown when the game is counted or over
+func hidden(gd *gmaeDescriptor, viewer, colour igame.ChipColour) bool {
+	if !gd.cfg.blind || viewer == igame.NoColour || gd.gameOver || gd.counting {
+		return false
+	}
+	return gd.cfg.blindAll || colour != viewer
+}
@@ -0,0 +1,79 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestBlindGo checks that chips of the opponent are hidden from gamers but not from spectators.
+func TestBlindGo(t *testing.T) {
+	game, black, white := pieGame(t, WithBlindGo(false))
+	defer game.End()
+	watcher := &Gamer{Name: "Watcher", ID: 4}
+	if err := game.Watch(watcher); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+
+	events, cancel := game.Subscribe(black)
+	defer cancel()
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if ev := nextEvent(t, events); ev.Kind != MoveMadeEvent || ev.Move != nil || len(ev.State.ChipsOnBoard[igame.White]) != 0 {
+		t.Errorf("Unexpected event of the hidden move: %+v", ev)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); !errors.Is(err, ErrWrongTurn) {
+		t.Errorf("Unexpected MakeTurn err on the hidden chip:\nwant: %v,\ngot: %v", ErrWrongTurn, err)
+	}
+
+	state, err := game.GameState(black)
+	if err != nil {
+		t.Fatalf("Unexpected GameState err: %v", err)
+	}
+	if len(state.ChipsOnBoard[igame.Black]) != 1 || len(state.ChipsOnBoard[igame.White]) != 0 {
+		t.Errorf("Unexpected chips shown to black: %v", state.ChipsOnBoard)
+	}
+	state, err = game.GameState(watcher.ID)
+	if err != nil {
+		t.Fatalf("Unexpected GameState err: %v", err)
+	}
+	if len(state.ChipsOnBoard[igame.Black]) != 1 || len(state.ChipsOnBoard[igame.White]) != 1 {
+		t.Errorf("Unexpected chips shown to the spectator: %v", state.ChipsOnBoard)
+	}
+
+	history, err := game.History(black)
+	if err != nil {
+		t.Fatalf("Unexpected History err: %v", err)
+	}
+	if len(history) != 2 || history[0].Position != (igame.TurnData{X: 5, Y: 5}) || history[1].Position != (igame.TurnData{}) {
+		t.Errorf("Unexpected history shown to black: %+v", history)
+	}
+	if _, err := game.SGF(black); !errors.Is(err, ErrBlind) {
+		t.Errorf("Unexpected SGF err of black:\nwant: %v,\ngot: %v", ErrBlind, err)
+	}
+
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	if state, err := game.GameState(black); err != nil || len(state.ChipsOnBoard[igame.White]) != 1 {
+		t.Errorf("Unexpected chips shown to black after the game: %v, err: %v", state, err)
+	}
+}
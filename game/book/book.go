@@ -0,0 +1,63 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package book provides in-memory opening book.
+package book
+
+import (
+	"fmt"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Memory is an opening book held in memory.
+// It implements igame.OpeningBook. It's not safe for concurrent use while filled.
+type Memory struct {
+	moves map[uint64][]igame.BookMove
+}
+
+// NewMemory creates an empty Memory book
+func NewMemory() *Memory {
+	return &Memory{moves: make(map[uint64][]igame.BookMove)}
+}
+
+// Add adds move to the position identified by hash
+func (m *Memory) Add(hash uint64, move igame.BookMove) {
+	m.moves[hash] = append(m.moves[hash], move)
+}
+
+// AddLine replays moves of line on a copy of f and adds each of them
+// to the position it's made in, with weight and name given
+func (m *Memory) AddLine(f *field.Field, line []igame.Placement, weight int, name string) error {
+	f = f.Clone()
+	for i, p := range line {
+		hash := f.State().Hash
+		td := p.Position
+		if err := f.Move(p.Colour, &td); err != nil {
+			return fmt.Errorf("failed to add move %d of line %q: %w", i+1, name, err)
+		}
+		m.Add(hash, igame.BookMove{Colour: p.Colour, Position: p.Position, Weight: weight, Name: name})
+	}
+	return nil
+}
+
+// Lookup returns copy of moves known for the position identified by hash
+func (m *Memory) Lookup(hash uint64) []igame.BookMove {
+	moves := make([]igame.BookMove, len(m.moves[hash]))
+	copy(moves, m.moves[hash])
+	return moves
+}
@@ -0,0 +1,83 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package book_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/book"
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+var line = []igame.Placement{
+	{Colour: igame.Black, Position: igame.TurnData{X: 3, Y: 7}},
+	{Colour: igame.White, Position: igame.TurnData{X: 7, Y: 3}},
+}
+
+func TestAddLine(t *testing.T) {
+	f, err := field.New(9, 0)
+	if err != nil {
+		t.Fatalf("Unexpected New() err: %v", err)
+	}
+	book := NewMemory()
+	if err := book.AddLine(f, line, 10, "diagonal"); err != nil {
+		t.Fatalf("Unexpected AddLine() err: %v", err)
+	}
+	book.Add(f.State().Hash, igame.BookMove{Colour: igame.Black, Position: igame.TurnData{X: 5, Y: 5}, Weight: 1})
+
+	want := []igame.BookMove{
+		{Colour: igame.Black, Position: line[0].Position, Weight: 10, Name: "diagonal"},
+		{Colour: igame.Black, Position: igame.TurnData{X: 5, Y: 5}, Weight: 1},
+	}
+	if got := f.BookMoves(book); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected BookMoves() on empty field:\nwant: %v,\ngot: %v.", want, got)
+	}
+
+	td := line[0].Position
+	if err := f.Move(igame.Black, &td); err != nil {
+		t.Fatalf("Unexpected Move() err: %v", err)
+	}
+	want = []igame.BookMove{{Colour: igame.White, Position: line[1].Position, Weight: 10, Name: "diagonal"}}
+	if got := f.BookMoves(book); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected BookMoves() after the first move:\nwant: %v,\ngot: %v.", want, got)
+	}
+
+	td = line[1].Position
+	if err := f.Move(igame.Black, &td); err != nil {
+		t.Fatalf("Unexpected Move() err: %v", err)
+	}
+	if got := f.BookMoves(book); len(got) != 0 {
+		t.Errorf("Unexpected BookMoves() out of book:\nwant: [],\ngot: %v.", got)
+	}
+}
+
+func TestAddLineIllegal(t *testing.T) {
+	f, err := field.New(9, 0)
+	if err != nil {
+		t.Fatalf("Unexpected New() err: %v", err)
+	}
+	illegal := append(line, line[0])
+	if err := NewMemory().AddLine(f, illegal, 1, ""); !errors.Is(err, field.ErrOccupied) {
+		t.Errorf("Unexpected AddLine() err:\nwant: %v,\ngot: %v.", field.ErrOccupied, err)
+	}
+	if len(f.History()) != 0 {
+		t.Errorf("Unexpected change of the field by AddLine()")
+	}
+}
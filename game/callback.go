@@ -0,0 +1,111 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// MoveEvent describes a move passed to callbacks registered by OnMove
+type MoveEvent struct {
+	Move  HistoryMove       // the move with the time it's made and metadata of the client
+	State *igame.FieldState // state of the game after the move shown to spectators
+}
+
+// OnMove registers fn called after each move, pass or resign made in the game.
+// fn is called outside of the goroutine of the game in order of moves,
+// so a slow callback delays following calls, but not the game.
+func (g *Game) OnMove(fn func(MoveEvent)) error {
+	return g.OnMoveContext(context.Background(), fn)
+}
+
+// OnMoveContext is like OnMove, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) OnMoveContext(ctx context.Context, fn func(MoveEvent)) error {
+	sub := newSubscription()
+	sub.timings = true
+	return g.callback(ctx, sub, func(ev GameEvent) {
+		if ev.Timing != nil {
+			fn(MoveEvent{Move: ev.Timing.Move, State: ev.State})
+		}
+	}, nil)
+}
+
+// OnEnd registers fn called with the result when the game is over,
+// immediately if it's over already. fn isn't called for the game left undecided.
+// fn is called outside of the goroutine of the game.
+func (g *Game) OnEnd(fn func(igame.Result)) error {
+	return g.OnEndContext(context.Background(), fn)
+}
+
+// OnEndContext is like OnEnd, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) OnEndContext(ctx context.Context, fn func(igame.Result)) error {
+	return g.callback(ctx, newSubscription(), func(ev GameEvent) {
+		if ev.Kind == GameOverEvent && ev.Result != nil {
+			fn(*ev.Result)
+		}
+	}, nil)
+}
+
+// OnDestroy registers fn called when the game is destroyed by End, by leaving of all gamers
+// or by inactivity, immediately if it's destroyed already.
+// fn is called outside of the goroutine of the game.
+func (g *Game) OnDestroy(fn func()) error {
+	return g.OnDestroyContext(context.Background(), fn)
+}
+
+// OnDestroyContext is like OnDestroy, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) OnDestroyContext(ctx context.Context, fn func()) error {
+	err := g.callback(ctx, newSubscription(), func(GameEvent) {}, fn)
+	if errors.Is(err, ErrGameDestroyed) {
+		go fn()
+		return nil
+	}
+	return err
+}
+
+// callback subscribes sub to events of the game and passes them to fn until the game is destroyed,
+// then calls destroyed if it's not nil
+func (g *Game) callback(ctx context.Context, sub *subscription, fn func(GameEvent), destroyed func()) error {
+	if err := errorOf(g.request(ctx, &gameCommand{act: callbackCMD, sub: sub})); err != nil {
+		// the subscription could be passed to the game before cancellation.
+		g.unsubscribe(sub, sub.close)
+		return err
+	}
+	go func() {
+		for ev := range sub.out {
+			fn(ev)
+		}
+		if destroyed != nil {
+			destroyed()
+		}
+	}()
+	return nil
+}
+
+// addCallback implements concurrently safe processing of
+// registration of callbacks by OnMove, OnEnd and OnDestroy
+func addCallback(cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gd.subscribers[cmd.sub] = true
+	if gd.gameOver {
+		cmd.sub.events <- GameEvent{Kind: GameOverEvent, Result: finalResult(gd)}
+	}
+}
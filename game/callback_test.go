@@ -0,0 +1,117 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestOnMoveOnEnd checks callbacks of moves and of the end of the game.
+func TestOnMoveOnEnd(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	moves := make(chan MoveEvent, 10)
+	if err := game.OnMove(func(ev MoveEvent) { moves <- ev }); err != nil {
+		t.Fatalf("Unexpected OnMove err: %v", err)
+	}
+	results := make(chan igame.Result, 10)
+	if err := game.OnEnd(func(result igame.Result) { results <- result }); err != nil {
+		t.Fatalf("Unexpected OnEnd err: %v", err)
+	}
+
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if err := game.Resign(black); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+
+	want := []struct {
+		id   int
+		kind igame.MoveKind
+	}{
+		{white, igame.PlaceMove},
+		{black, igame.ResignMove},
+	}
+	for _, w := range want {
+		select {
+		case ev := <-moves:
+			if ev.Move.ID != w.id || ev.Move.Kind != w.kind || ev.State == nil {
+				t.Errorf("Unexpected MoveEvent:\nwant: %+v,\ngot: %+v", w, ev)
+			}
+		case <-time.After(rtDurationThreshold):
+			t.Fatalf("No callback of the move of %d", w.id)
+		}
+	}
+	select {
+	case result := <-results:
+		if result.Winner != igame.White || result.Method != igame.ResignMethod {
+			t.Errorf("Unexpected result:\nwant: win of white by resign,\ngot: %v", result)
+		}
+	case <-time.After(rtDurationThreshold):
+		t.Fatalf("No callback of the end of the game")
+	}
+
+	// the callback registered after the end is called immediately.
+	if err := game.OnEnd(func(result igame.Result) { results <- result }); err != nil {
+		t.Fatalf("Unexpected OnEnd err: %v", err)
+	}
+	select {
+	case result := <-results:
+		if result.Winner != igame.White {
+			t.Errorf("Unexpected result of the finished game: %v", result)
+		}
+	case <-time.After(rtDurationThreshold):
+		t.Fatalf("No callback of the finished game")
+	}
+}
+
+// TestOnDestroy checks callbacks of the destruction of the game.
+func TestOnDestroy(t *testing.T) {
+	game, _, _ := pieGame(t)
+
+	destroyed := make(chan bool, 2)
+	if err := game.OnDestroy(func() { destroyed <- true }); err != nil {
+		t.Fatalf("Unexpected OnDestroy err: %v", err)
+	}
+	select {
+	case <-destroyed:
+		t.Fatalf("Unexpected callback of the destruction before End")
+	case <-time.After(rtDurationThreshold):
+	}
+
+	game.End()
+	select {
+	case <-destroyed:
+	case <-time.After(rtDurationThreshold):
+		t.Fatalf("No callback of the destruction of the game")
+	}
+
+	// the callback registered after the destruction is called immediately.
+	if err := game.OnDestroy(func() { destroyed <- true }); err != nil {
+		t.Fatalf("Unexpected OnDestroy err: %v", err)
+	}
+	select {
+	case <-destroyed:
+	case <-time.After(rtDurationThreshold):
+		t.Fatalf("No callback of the destroyed game")
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Clock describes the state of the clock of a gamer
+type Clock struct {
+	Remaining  time.Duration // remaining time budget, 0 if the game has no time control
+	TurnLeft   time.Duration // time left for the current turn, 0 if the clock is stopped
+	Running    bool          // the clock is running
+	Vacation   time.Duration // remaining vacation time
+	OnVacation bool          // the clock is paused by the vacation
+}
+
+// clock tracks the time of the gamer to move
+type clock struct {
+	running bool      // the clock of owner is running, clocks of all gamers are stopped otherwise
+	owner   int       // id of the gamer whose clock is running
+	turn    int       // the turn the running clock started on
+	started time.Time // time the running clock started
+	timer   Timer     // timer to report the running out of time
+}
+
+// timeout reports to the game that the gamer with id could run out of time
+func (g *Game) timeout(id int) error {
+	return errorOf(g.request(context.Background(), &gameCommand{act: timeoutCMD, id: id}))
+}
+
+// vacationOver reports to the game that the gamer with id could run out of vacation time
+func (g *Game) vacationOver(id int) error {
+	return errorOf(g.request(context.Background(), &gameCommand{act: vacationOverCMD, id: id}))
+}
+
+// timeControl checks that the game has time control
+func (cfg *config) timeControl() bool {
+	return cfg.mainTime != 0 || cfg.perMove != 0
+}
+
+// turnLimit returns the time the gamer can spend on the turn
+func turnLimit(gs *GamerState, cfg *config) time.Duration {
+	limit := cfg.deadline
+	if budget := gs.Remaining + cfg.perMove; cfg.timeControl() && (limit == 0 || budget < limit) {
+		limit = budget
+	}
+	return limit
+}
+
+// clockOwner returns the id of the gamer whose clock should run, ok is false if none
+func clockOwner(gamerStates map[int]*GamerState, gd *gmaeDescriptor) (id int, ok bool) {
+	if (!gd.cfg.timeControl() && gd.cfg.deadline == 0) || gd.gameOver || gd.counting || gd.paused || len(gamerStates) < gd.cfg.seats() {
+		return 0, false
+	}
+	for id, gs := range gamerStates {
+		if isGamersTurn(gd.currentTurn, gs, gd.cfg) && !gs.OnVacation && !gs.Disconnected {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// spent returns time of the budget of the gamer with id spent on the running clock.
+// The time within the per move limit is not taken from the budget.
+func (gd *gmaeDescriptor) spent(id int) time.Duration {
+	if !gd.clock.running || gd.clock.owner != id || !gd.cfg.timeControl() {
+		return 0
+	}
+	if spent := gd.cfg.since(gd.clock.started) - gd.cfg.perMove; spent > 0 {
+		return spent
+	}
+	return 0
+}
+
+// updateClocks stops the clock of the gamer who finished the turn
+// and starts the clock of the gamer to move
+func updateClocks(g *Game, gamerStates map[int]*GamerState, gd *gmaeDescriptor) {
+	owner, running := clockOwner(gamerStates, gd)
+	if running == gd.clock.running && owner == gd.clock.owner && gd.clock.turn == gd.currentTurn {
+		return
+	}
+
+	if gd.clock.running {
+		gd.clock.timer.Stop()
+		if gs, ok := gamerStates[gd.clock.owner]; ok == true {
+			gs.Remaining -= gd.spent(gd.clock.owner)
+			if gd.clock.turn != gd.currentTurn && gd.gameOver == false {
+				gs.Remaining += gd.cfg.increment
+			}
+		}
+	}
+
+	gd.clock.running, gd.clock.owner = running, owner
+	gd.clock.turn = gd.currentTurn
+	if !running {
+		return
+	}
+	gd.clock.started = gd.cfg.timeSource().Now()
+	gd.clock.timer = gd.cfg.timeSource().AfterFunc(turnLimit(gamerStates[owner], gd.cfg), func() {
+		g.timeout(owner)
+	})
+}
+
+// clocks implements concurrently safe processing of querry of
+// Clocks function
+func clocks(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("clocks", cmd.id, ErrUnknownID)
+		return
+	}
+	cmd.rez <- clocksOf(gamerStates, gd)
+}
+
+// clocksOf returns states of clocks of gamers by colour
+func clocksOf(gamerStates map[int]*GamerState, gd *gmaeDescriptor) map[igame.ChipColour]Clock {
+	rez := make(map[igame.ChipColour]Clock, len(gamerStates))
+	for id, gs := range gamerStates {
+		if gd.cfg.rengo && !isGamersTurn(nextTurnOf(gd.currentTurn, gs.Colour), gs, gd.cfg) {
+			// the clock of the team mate moving next is shown for the colour.
+			continue
+		}
+		c := Clock{
+			Remaining:  gs.Remaining - gd.spent(id),
+			Running:    gd.clock.running && gd.clock.owner == id,
+			Vacation:   gs.Vacation - vacationSpent(gs, gd.cfg),
+			OnVacation: gs.OnVacation,
+		}
+		if c.Running {
+			c.TurnLeft = turnLimit(gs, gd.cfg) - gd.cfg.since(gd.clock.started)
+		}
+		rez[gs.Colour] = c
+	}
+	return rez
+}
+
+// timeout implements concurrently safe processing of
+// running out of time by the gamer with id
+// return 1 on auto pass, else - 0
+func timeout(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) int {
+	defer close(cmd.rez)
+
+	gs, ok := gamerStates[cmd.id]
+	if ok == false || !gd.clock.running || gd.clock.owner != cmd.id {
+		// the gamer finished the turn in time.
+		return 0
+	}
+
+	switch {
+	case gd.cfg.timeControl() && gd.spent(cmd.id) >= gs.Remaining:
+		gs.Remaining = 0
+		gd.clock.running = false
+		gd.result = &igame.Result{Winner: igame.ChipColour(3 - int(movingColour(gs, gd))), Method: igame.TimeoutMethod}
+	case gd.cfg.deadline != 0 && gd.cfg.since(gd.clock.started) >= gd.cfg.deadline:
+		gs.Timeouts++
+		if (gd.cfg.autoPass || gs.Timeouts <= gd.cfg.autoPasses) && passTurn(gamerStates, cmd.id, gs, gd) == nil {
+			return 1
+		}
+		gd.result = &igame.Result{Winner: igame.ChipColour(3 - int(movingColour(gs, gd))), Method: igame.ForfeitMethod}
+	default:
+		// the gamer finished the turn in time.
+		return 0
+	}
+
+	gd.gameOver = true
+	reportOnGameOver(gamerStates, gd)
+	return 0
+}
+
+// vacationSpent returns vacation time spent by the gamer on the current vacation
+func vacationSpent(gs *GamerState, cfg *config) time.Duration {
+	if gs.OnVacation == false {
+		return 0
+	}
+	return cfg.since(gs.vacationStarted)
+}
+
+// startVacation implements concurrently safe processing of querry of
+// StartVacation function
+func startVacation(g *Game, gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "startVacation", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	if gs.Vacation <= 0 {
+		cmd.rez <- opError("startVacation", cmd.id, ErrNoVacation)
+		return
+	}
+	if gs.OnVacation {
+		return
+	}
+
+	gs.OnVacation = true
+	gs.vacationStarted = gd.cfg.timeSource().Now()
+	gs.vacationTimer = gd.cfg.timeSource().AfterFunc(gs.Vacation, func() {
+		g.vacationOver(cmd.id)
+	})
+}
+
+// endVacation finishes the vacation of the gamer
+func endVacation(gs *GamerState, cfg *config) {
+	if gs.OnVacation == false {
+		return
+	}
+	gs.vacationTimer.Stop()
+	gs.Vacation -= vacationSpent(gs, cfg)
+	if gs.Vacation < 0 {
+		gs.Vacation = 0
+	}
+	gs.OnVacation = false
+}
+
+// stopVacation implements concurrently safe processing of querry of
+// EndVacation function
+func stopVacation(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "stopVacation", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	endVacation(gs, gd.cfg)
+}
+
+// vacationOver implements concurrently safe processing of
+// running out of vacation time by the gamer with id
+func vacationOver(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, ok := gamerStates[cmd.id]
+	if ok == false || vacationSpent(gs, gd.cfg) < gs.Vacation {
+		// the vacation is finished by the gamer.
+		return
+	}
+	endVacation(gs, gd.cfg)
+}
@@ -0,0 +1,212 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestAbsoluteTime(t *testing.T) {
+	mainTime := rtDurationThreshold / 2
+	game, black, white := pieGame(t, WithAbsoluteTime(mainTime))
+	defer game.End()
+
+	gs, err := game.GamerState(black)
+	if err != nil {
+		t.Fatalf("Unexpected GamerState err: %v", err)
+	}
+	if gs.Remaining <= 0 || gs.Remaining >= mainTime {
+		t.Errorf("Unexpected remaining time of black after the move:\nwant: in (0, %v),\ngot: %v", mainTime, gs.Remaining)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*rtDurationThreshold)
+	defer cancel()
+	if err := game.WaitTurn(ctx, black); !errors.Is(err, ErrGameOver) {
+		t.Fatalf("Unexpected WaitTurn err while white runs out of time:\nwant: %v,\ngot: %v", ErrGameOver, err)
+	}
+
+	result, err := game.Result(white)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if result.Winner != igame.Black || result.Method != igame.TimeoutMethod {
+		t.Errorf("Unexpected Result:\nwant: win of black on time,\ngot: %v", result)
+	}
+	if gs, _ := game.GamerState(white); gs.Remaining != 0 {
+		t.Errorf("Unexpected remaining time of white:\nwant: 0,\ngot: %v", gs.Remaining)
+	}
+}
+
+func TestFischerTime(t *testing.T) {
+	mainTime := rtDurationThreshold / 2
+	game, black, white := pieGame(t, WithFischerTime(mainTime, mainTime))
+	defer game.End()
+
+	gs, err := game.GamerState(black)
+	if err != nil {
+		t.Fatalf("Unexpected GamerState err: %v", err)
+	}
+	if gs.Remaining <= mainTime || gs.Remaining >= 2*mainTime {
+		t.Errorf("Unexpected remaining time of black after the move:\nwant: in (%v, %v),\ngot: %v", mainTime, 2*mainTime, gs.Remaining)
+	}
+	if gs, _ := game.GamerState(white); gs.Remaining <= 0 || gs.Remaining > mainTime {
+		t.Errorf("Unexpected remaining time of white on the turn:\nwant: in (0, %v],\ngot: %v", mainTime, gs.Remaining)
+	}
+}
+
+func TestCorrespondenceTime(t *testing.T) {
+	perMove, vacation := rtDurationThreshold/4, rtDurationThreshold
+	game, black, white := pieGame(t, WithCorrespondenceTime(perMove, perMove, vacation))
+	defer game.End()
+
+	if gs, _ := game.GamerState(black); gs.Remaining != perMove {
+		t.Errorf("Unexpected time bank of black after the move in the limit:\nwant: %v,\ngot: %v", perMove, gs.Remaining)
+	}
+
+	if err := game.StartVacation(white); err != nil {
+		t.Fatalf("Unexpected StartVacation err: %v", err)
+	}
+	time.Sleep(3 * perMove)
+	if _, err := game.IsMyTurn(white); err != nil {
+		t.Fatalf("Unexpected IsMyTurn err of white on vacation: %v", err)
+	}
+	if err := game.EndVacation(white); err != nil {
+		t.Fatalf("Unexpected EndVacation err: %v", err)
+	}
+	if gs, _ := game.GamerState(white); gs.OnVacation || gs.Vacation <= 0 || gs.Vacation >= vacation {
+		t.Errorf("Unexpected vacation of white after EndVacation:\nwant: in (0, %v),\ngot: %v, on vacation: %v", vacation, gs.Vacation, gs.OnVacation)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*rtDurationThreshold)
+	defer cancel()
+	if err := game.WaitTurn(ctx, black); !errors.Is(err, ErrGameOver) {
+		t.Fatalf("Unexpected WaitTurn err while white runs out of time:\nwant: %v,\ngot: %v", ErrGameOver, err)
+	}
+}
+
+func TestNoVacation(t *testing.T) {
+	game, black, _ := pieGame(t, WithAbsoluteTime(rtDurationThreshold))
+	defer game.End()
+
+	if err := game.StartVacation(black); !errors.Is(err, ErrNoVacation) {
+		t.Errorf("Unexpected StartVacation err without vacation time:\nwant: %v,\ngot: %v", ErrNoVacation, err)
+	}
+}
+
+func TestMoveDeadline(t *testing.T) {
+	deadline := rtDurationThreshold / 4
+	game, black, white := pieGame(t, WithMoveDeadline(deadline, false))
+	defer game.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*rtDurationThreshold)
+	defer cancel()
+	if err := game.WaitTurn(ctx, black); !errors.Is(err, ErrGameOver) {
+		t.Fatalf("Unexpected WaitTurn err while white misses the deadline:\nwant: %v,\ngot: %v", ErrGameOver, err)
+	}
+	if result, _ := game.Result(white); result == nil || result.Winner != igame.Black || result.Method != igame.ForfeitMethod {
+		t.Errorf("Unexpected Result:\nwant: win of black by forfeit,\ngot: %v", result)
+	}
+}
+
+func TestMoveDeadlineAutoPass(t *testing.T) {
+	deadline := rtDurationThreshold / 4
+	game, black, _ := pieGame(t, WithMoveDeadline(deadline, true))
+	defer game.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*rtDurationThreshold)
+	defer cancel()
+	if err := game.WaitTurn(ctx, black); err != nil {
+		t.Fatalf("Unexpected WaitTurn err while white misses the deadline: %v", err)
+	}
+	state, err := game.GameState(black)
+	if err != nil {
+		t.Fatalf("Unexpected GameState err: %v", err)
+	}
+	if state.LastMove == nil || state.LastMove.Kind != igame.PassMove || state.LastMove.Colour != igame.White {
+		t.Errorf("Unexpected last move:\nwant: pass of white,\ngot: %v", state.LastMove)
+	}
+}
+
+func TestAutoPassLimit(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, black, white := pieGame(t, WithMoveDeadline(time.Minute, false), WithAutoPassLimit(1), WithTimeSource(ft))
+	defer game.End()
+
+	ft.advance(time.Minute)
+	gs, err := game.GamerState(white)
+	if err != nil {
+		t.Fatalf("Unexpected GamerState err: %v", err)
+	}
+	if gs.Timeouts != 1 {
+		t.Errorf("Unexpected Timeouts of white: want 1, got %d", gs.Timeouts)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err after the pass of white: %v", err)
+	}
+
+	ft.advance(time.Minute)
+	if result, _ := game.Result(black); result == nil || result.Winner != igame.Black || result.Method != igame.ForfeitMethod {
+		t.Errorf("Unexpected Result:\nwant: win of black by forfeit,\ngot: %v", result)
+	}
+}
+
+func TestClocks(t *testing.T) {
+	mainTime := rtDurationThreshold
+	game, black, _ := pieGame(t, WithAbsoluteTime(mainTime))
+	defer game.End()
+
+	clocks, err := game.Clocks(black)
+	if err != nil {
+		t.Fatalf("Unexpected Clocks err: %v", err)
+	}
+	if c := clocks[igame.Black]; c.Running || c.TurnLeft != 0 || c.Remaining <= 0 || c.Remaining >= mainTime {
+		t.Errorf("Unexpected clock of black:\nwant: stopped with time in (0, %v),\ngot: %+v", mainTime, c)
+	}
+	if c := clocks[igame.White]; !c.Running || c.TurnLeft <= 0 || c.TurnLeft > mainTime || c.Remaining > mainTime {
+		t.Errorf("Unexpected clock of white:\nwant: running with time in (0, %v],\ngot: %+v", mainTime, c)
+	}
+	if _, err := game.Clocks(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Clocks err of foreign gamer:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
+
+// TestZeroIDTime checks that the clock of the gamer with id 0 runs.
+func TestZeroIDTime(t *testing.T) {
+	game, zero, other := zeroGame(t, WithAbsoluteTime(rtDurationThreshold/2))
+	defer game.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*rtDurationThreshold)
+	defer cancel()
+	if err := game.WaitTurn(ctx, other); !errors.Is(err, ErrGameOver) {
+		t.Fatalf("Unexpected WaitTurn err while the gamer with id 0 runs out of time:\nwant: %v,\ngot: %v", ErrGameOver, err)
+	}
+	result, err := game.Result(other)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if gs, _ := game.GamerState(other); result.Winner != gs.Colour || result.Method != igame.TimeoutMethod {
+		t.Errorf("Unexpected Result:\nwant: win of %v on time,\ngot: %v", gs.Colour, result)
+	}
+	if gs, _ := game.GamerState(zero); gs.Remaining != 0 {
+		t.Errorf("Unexpected remaining time of the gamer with id 0:\nwant: 0,\ngot: %v", gs.Remaining)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// StateDelta describes changes of the state of the game shown to the subscriber
+// since the previous event delivered to it
+type StateDelta struct {
+	Added    []igame.Placement          // chips put on the field
+	Removed  []igame.TurnData           // chips removed from the field
+	KoPoint  *igame.TurnData            // position forbidden by ko rule, nil if there is no ko
+	LastMove *igame.Move                // the most recent move, nil if no moves made yet
+	Clocks   map[igame.ChipColour]Clock // states of clocks of gamers by colour
+}
+
+// delta returns changes of state since the state shown to the subscriber
+// and remembers state as shown
+func (sub *subscription) delta(state *igame.FieldState, clocks map[igame.ChipColour]Clock) *StateDelta {
+	// the state is shared by subscribers of the same colour.
+	delta := &StateDelta{Clocks: make(map[igame.ChipColour]Clock, len(clocks))}
+	if state.KoPoint != nil {
+		ko := *state.KoPoint
+		delta.KoPoint = &ko
+	}
+	if state.LastMove != nil {
+		move := *state.LastMove
+		delta.LastMove = &move
+	}
+	for colour, c := range clocks {
+		delta.Clocks[colour] = c
+	}
+
+	chips := chipsOf(state)
+	for td, colour := range chips {
+		if shown, ok := sub.shown[td]; !ok || shown != colour {
+			delta.Added = append(delta.Added, igame.Placement{Colour: colour, Position: td})
+		}
+	}
+	for td := range sub.shown {
+		if _, ok := chips[td]; !ok {
+			delta.Removed = append(delta.Removed, td)
+		}
+	}
+	sub.shown = chips
+	return delta
+}
+
+// resync sends the whole state to the subscriber,
+// following deltas are counted from it
+func (sub *subscription) resync(gamerStates map[int]*GamerState, gd *gmaeDescriptor) {
+	colour, _ := viewerColour(gamerStates, sub.id, gd)
+	state := stateFor(gd, colour)
+	sub.shown = chipsOf(state)
+	sub.events <- GameEvent{Kind: ResyncEvent, State: state}
+}
+
+// chipsOf returns colours of chips on the field of state by position
+func chipsOf(state *igame.FieldState) map[igame.TurnData]igame.ChipColour {
+	chips := make(map[igame.TurnData]igame.ChipColour)
+	for colour, positions := range state.ChipsOnBoard {
+		for _, td := range positions {
+			chips[*td] = colour
+		}
+	}
+	return chips
+}
+
+// resync implements concurrently safe processing of querry of
+// Resync function
+func resync(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("resync", cmd.id, ErrUnknownID)
+		return
+	}
+	for sub := range gd.subscribers {
+		if sub.id == cmd.id && sub.deltas {
+			sub.resync(gamerStates, gd)
+		}
+	}
+}
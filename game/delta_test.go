@@ -0,0 +1,73 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestSubscribeDeltas checks that subscribers of deltas get changes of the field instead of the whole state.
+func TestSubscribeDeltas(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+	watcher := &Gamer{Name: "Watcher", ID: 4}
+	if err := game.Watch(watcher); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+
+	events, cancel := game.SubscribeDeltas(watcher.ID)
+	defer cancel()
+	if ev := nextEvent(t, events); ev.Kind != ResyncEvent || ev.State == nil || len(ev.State.ChipsOnBoard[igame.Black]) != 1 {
+		t.Fatalf("Unexpected first event of deltas: %+v", ev)
+	}
+
+	moves := []struct {
+		id int
+		td igame.TurnData
+	}{{white, igame.TurnData{X: 1, Y: 2}}, {black, igame.TurnData{X: 1, Y: 1}}, {white, igame.TurnData{X: 2, Y: 1}}}
+	var ev GameEvent
+	for _, move := range moves {
+		td := move.td
+		if err := game.MakeTurn(move.id, &td); err != nil {
+			t.Fatalf("Unexpected MakeTurn err: %v", err)
+		}
+		ev = nextEvent(t, events)
+	}
+	if ev.Kind != MoveMadeEvent || ev.State != nil || ev.Delta == nil {
+		t.Fatalf("Unexpected event of the capture: %+v", ev)
+	}
+	added := []igame.Placement{{Colour: igame.White, Position: igame.TurnData{X: 2, Y: 1}}}
+	removed := []igame.TurnData{{X: 1, Y: 1}}
+	if !reflect.DeepEqual(ev.Delta.Added, added) || !reflect.DeepEqual(ev.Delta.Removed, removed) {
+		t.Errorf("Unexpected delta of the capture:\nwant: added %v, removed %v,\ngot: added %v, removed %v",
+			added, removed, ev.Delta.Added, ev.Delta.Removed)
+	}
+
+	if err := game.Resync(watcher.ID); err != nil {
+		t.Fatalf("Unexpected Resync err: %v", err)
+	}
+	if ev := nextEvent(t, events); ev.Kind != ResyncEvent || len(ev.State.ChipsOnBoard[igame.White]) != 2 {
+		t.Errorf("Unexpected event of Resync: %+v", ev)
+	}
+	if err := game.Resync(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Resync err of foreign gamer:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"sync"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// EventKind provides datatype of kinds of game events
+type EventKind int
+
+// Set of kinds of game events
+const (
+	JoinedEvent           EventKind = iota // a gamer joined the game
+	BegunEvent                             // both gamers joined, the game begun
+	MoveMadeEvent                          // a gamer put a chip on the field
+	PassEvent                              // a gamer passed
+	ResignEvent                            // a gamer resigned
+	LeftEvent                              // a gamer left the game
+	GameOverEvent                          // the game is over
+	ChatEvent                              // a gamer or a spectator said something
+	DisconnectedEvent                      // a gamer disconnected, the game waits for the gamer to rejoin
+	RejoinedEvent                          // a disconnected gamer rejoined the game
+	PausedEvent                            // gamers agreed to pause the game
+	ResumedEvent                           // gamers agreed to resume the game
+	StoreFailedEvent                       // the store of the game failed to persist it
+	AbandonedEvent                         // gamers issued no commands for the inactivity timeout, the game is destroyed
+	SettingsProposedEvent                  // a gamer proposed changed settings of the game
+	SettingsChangedEvent                   // a gamer accepted the proposed settings, the game is reconfigured
+	ReviewEvent                            // a reviewer moved the cursor of the review of the finished game
+	ScoreRejectedEvent                     // a gamer rejected the score in counting phase
+	ForceEndedEvent                        // the administrator ended the game
+	ResyncEvent                            // the whole state for subscriptions made by SubscribeDeltas
+	SpectatorJoinedEvent                   // a spectator started watching the game
+	SpectatorLeftEvent                     // a spectator stopped watching the game
+)
+
+// ChatMessage is a message said in the game chat
+type ChatMessage struct {
+	ID   int    // id of the author
+	Name string // name of the author
+	Text string
+}
+
+// GameEvent describes a change of the game delivered to subscribers
+type GameEvent struct {
+	Kind     EventKind
+	ID       int               // id of the gamer caused the event, 0 for BegunEvent and GameOverEvent
+	Colour   igame.ChipColour  // colour of the gamer caused the event
+	Move     *igame.TurnData   // position of the chip for MoveMadeEvent
+	Result   *igame.Result     // outcome of the game for GameOverEvent, nil if the game is left undecided
+	Message  *ChatMessage      // message for ChatEvent
+	Nigiri   *Nigiri           // the nigiri determined colours for BegunEvent, nil if there was no nigiri
+	State    *igame.FieldState // state of the game after the move for MoveMadeEvent, PassEvent and ResignEvent, the whole state for ResyncEvent
+	Delta    *StateDelta       // changes of the state instead of State for subscriptions made by SubscribeDeltas
+	Timing   *MoveTiming       // timing of the move for the analyzer set by WithMoveAnalyzer, nil for other subscribers
+	Err      error             // failure of the store for StoreFailedEvent
+	Settings *Settings         // settings for SettingsProposedEvent and SettingsChangedEvent
+	Review   *ReviewPosition   // position of the cursor for ReviewEvent
+	Reason   string            // reason of the administrator for ForceEndedEvent
+	Watchers int               // number of spectators for SpectatorJoinedEvent and SpectatorLeftEvent
+}
+
+// WaitReason provides datatype of reasons of finishing of awaiting
+// by WaitBeginResult and WaitTurnResult
+type WaitReason int
+
+// Set of reasons of finishing of awaiting
+const (
+	GameBegunReason     WaitReason = iota // both gamers joined, the game begun
+	TurnBegunReason                       // it's the gamer's turn
+	OpponentLeftReason                    // the opponent left the game
+	GameDestroyedReason                   // the game was ended before the awaited event
+	GameOverReason                        // the game is over
+	CountingReason                        // both gamers passed, the game is in counting phase
+	PausedReason                          // gamers agreed to pause the game, the turn begins on resumption
+	AbandonedReason                       // gamers issued no commands for the inactivity timeout, the game is destroyed
+	ForceEndedReason                      // the administrator ended the game
+)
+
+// WaitResult describes the finish of awaiting by WaitBeginResult and WaitTurnResult
+type WaitResult struct {
+	Reason         WaitReason
+	OpponentID     int              // id of the opponent, 0 if the opponent isn't joined
+	OpponentName   string           // name of the opponent
+	OpponentColour igame.ChipColour // colour of the opponent
+	Move           *igame.Move      // the last move made in the game, nil if no moves made yet
+	Nigiri         *Nigiri          // the nigiri determined colours for GameBegunReason, nil if there was no nigiri
+}
+
+// Err returns the error reported by WaitBegin and WaitTurn for the result,
+// nil if the awaited event happened
+func (r *WaitResult) Err() error {
+	switch r.Reason {
+	case OpponentLeftReason:
+		return ErrOtherGamerLeft
+	case GameDestroyedReason:
+		return ErrGameDestroyed
+	case GameOverReason:
+		return ErrGameOver
+	case CountingReason:
+		return ErrCounting
+	case PausedReason:
+		return ErrPaused
+	case AbandonedReason:
+		return ErrAbandoned
+	case ForceEndedReason:
+		return ErrForceEnded
+	}
+	return nil
+}
+
+// subscription delivers events to a subscriber without blocking of the Game.
+// Events are queued until the subscriber reads them.
+type subscription struct {
+	events  chan GameEvent                      // events published by the Game
+	out     chan GameEvent                      // events delivered to the subscriber
+	done    chan struct{}                       // closed on cancellation by the subscriber
+	closed  sync.Once                           // protects events from repeated closing
+	id      int                                 // id of the subscriber
+	deltas  bool                                // the subscriber gets changes of the state instead of the whole state
+	shown   map[igame.TurnData]igame.ChipColour // chips on the field shown to the subscriber by deltas
+	timings bool                                // the subscriber gets timings of moves
+}
+
+func newSubscription() *subscription {
+	sub := &subscription{
+		events: make(chan GameEvent),
+		out:    make(chan GameEvent),
+		done:   make(chan struct{}),
+	}
+	go sub.run()
+	return sub
+}
+
+// run queues published events and delivers them to the subscriber.
+// Queued events are delivered after the Game stops publishing,
+// unless the subscription is cancelled.
+func (sub *subscription) run() {
+	defer close(sub.out)
+
+	queue := make([]GameEvent, 0)
+	events := sub.events
+	for events != nil || len(queue) > 0 {
+		var out chan GameEvent
+		var next GameEvent
+		if len(queue) > 0 {
+			out, next = sub.out, queue[0]
+		}
+
+		select {
+		case ev, ok := <-events:
+			if ok == false {
+				events = nil
+				continue
+			}
+			queue = append(queue, ev)
+		case out <- next:
+			queue = queue[1:]
+		case <-sub.done:
+			return
+		}
+	}
+}
+
+// close stops publishing of events to the subscription
+func (sub *subscription) close() {
+	sub.closed.Do(func() {
+		close(sub.events)
+	})
+}
+
+// publish delivers ev to all subscribers
+func publish(subscribers map[*subscription]bool, ev GameEvent) {
+	for sub := range subscribers {
+		sub.events <- ev
+	}
+}
+
+// publishMove delivers the event of a move to all subscribers
+// with the state of the game shown to each of them
+func publishMove(gamerStates map[int]*GamerState, gd *gmaeDescriptor, ev GameEvent) {
+	move := ev.Move
+	states := make(map[igame.ChipColour]*igame.FieldState, 3)
+	var clocks map[igame.ChipColour]Clock
+	timing := lastTiming(gd)
+	for sub := range gd.subscribers {
+		ev.Timing = nil
+		if sub.timings {
+			ev.Timing = timing
+		}
+		colour, _ := viewerColour(gamerStates, sub.id, gd)
+		if sub.deltas {
+			// the state is calculated once for all subscribers of the colour.
+			if states[colour] == nil {
+				states[colour] = stateFor(gd, colour)
+			}
+			if clocks == nil {
+				clocks = clocksOf(gamerStates, gd)
+			}
+			ev.State, ev.Delta = nil, sub.delta(states[colour], clocks)
+		} else {
+			ev.State, ev.Delta = stateFor(gd, colour), nil
+		}
+		ev.Move = move
+		if hidden(gd, colour, ev.Colour) {
+			ev.Move = nil
+		}
+		sub.events <- ev
+	}
+}
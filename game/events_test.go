@@ -0,0 +1,183 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// nextEvent returns the next event of the subscription or fails on timeout
+func nextEvent(t *testing.T, events <-chan GameEvent) GameEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if ok == false {
+			t.Fatalf("Unexpected close of events channel")
+		}
+		return ev
+	case <-time.After(rtDurationThreshold):
+		t.Fatalf("Unexpected timeout of awaiting an event")
+	}
+	return GameEvent{}
+}
+
+func TestSubscribe(t *testing.T) {
+	gamers := copyGamers(validGamers)
+	game, err := NewGame(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+
+	if err := game.Join(gamers[0]); err != nil {
+		t.Fatalf("Unexpected Join err: %v", err)
+	}
+	events, cancel := game.Subscribe(gamers[0].ID)
+	if err := game.Join(gamers[1]); err != nil {
+		t.Fatalf("Unexpected Join err: %v", err)
+	}
+
+	black, white := gamers[0].ID, gamers[1].ID
+	if igt, _ := game.IsMyTurn(black); igt != true {
+		black, white = white, black
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 5, Y: 5}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	if err := game.Resign(black); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+
+	wants := []GameEvent{
+		{Kind: JoinedEvent, ID: gamers[1].ID},
+		{Kind: BegunEvent},
+		{Kind: MoveMadeEvent, ID: black, Colour: igame.Black},
+		{Kind: PassEvent, ID: white, Colour: igame.White},
+		{Kind: ResignEvent, ID: black, Colour: igame.Black},
+		{Kind: GameOverEvent},
+	}
+	for _, want := range wants {
+		got := nextEvent(t, events)
+		if got.Kind != want.Kind || got.ID != want.ID || (want.Colour != igame.NoColour && got.Colour != want.Colour) {
+			t.Errorf("Unexpected event:\nwant: %+v,\ngot: %+v", want, got)
+		}
+		if got.Kind == GameOverEvent && (got.Result == nil || got.Result.Winner != igame.White) {
+			t.Errorf("Unexpected result of GameOverEvent:\nwant: win of white,\ngot: %v", got.Result)
+		}
+	}
+
+	cancel()
+	if _, ok := <-events; ok != false {
+		t.Errorf("Unexpected open events channel after cancel")
+	}
+}
+
+func TestSubscribeForeign(t *testing.T) {
+	game, err := NewGame(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+
+	events, cancel := game.Subscribe(invalidGamer.ID)
+	defer cancel()
+	if _, ok := <-events; ok != false {
+		t.Errorf("Unexpected open events channel of not joined gamer")
+	}
+}
+
+func TestSubscribeState(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	events, cancel := game.Subscribe(white)
+	defer cancel()
+
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	ev := nextEvent(t, events)
+	if ev.Kind != MoveMadeEvent || ev.State == nil {
+		t.Fatalf("Unexpected event:\nwant: MoveMadeEvent with state,\ngot: %+v", ev)
+	}
+	if n := len(ev.State.ChipsOnBoard[igame.Black]) + len(ev.State.ChipsOnBoard[igame.White]); n != 2 {
+		t.Errorf("Unexpected number of chips in the state of the event:\nwant: 2,\ngot: %v.", n)
+	}
+	if ev.State.LastMove == nil || ev.State.LastMove.Position != (igame.TurnData{X: 3, Y: 3}) {
+		t.Errorf("Unexpected last move in the state of the event:\nwant: 3,3,\ngot: %+v.", ev.State.LastMove)
+	}
+
+	if err := game.Pass(black); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	if ev := nextEvent(t, events); ev.Kind != PassEvent || ev.State == nil {
+		t.Errorf("Unexpected event:\nwant: PassEvent with state,\ngot: %+v", ev)
+	}
+}
+
+func TestWaitState(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	type stateRez struct {
+		state *igame.FieldState
+		err   error
+	}
+	rez := make(chan stateRez, 1)
+	go func() {
+		state, err := game.WaitState(black)
+		rez <- stateRez{state, err}
+	}()
+	time.Sleep(rtDurationThreshold / 10)
+
+	if err := game.Say(white, "hi"); err != nil {
+		t.Fatalf("Unexpected Say err: %v", err)
+	}
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+
+	select {
+	case got := <-rez:
+		if got.err != nil {
+			t.Fatalf("Unexpected WaitState err: %v", got.err)
+		}
+		if got.state.LastMove == nil || got.state.LastMove.Position != (igame.TurnData{X: 3, Y: 3}) {
+			t.Errorf("Unexpected last move after WaitState:\nwant: 3,3,\ngot: %+v.", got.state.LastMove)
+		}
+	case <-time.After(rtDurationThreshold):
+		t.Fatalf("Unexpected timeout of WaitState")
+	}
+
+	if _, err := game.WaitState(invalidGamer.ID); err == nil {
+		t.Errorf("Unexpected nil err on WaitState of not joined gamer")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold/10)
+	defer cancel()
+	if _, err := game.WaitStateContext(ctx, black); !errors.Is(err, ErrCancellation) {
+		t.Errorf("Unexpected err on WaitStateContext:\nwant: %v,\ngot: %v", ErrCancellation, err)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+var chipSymbols = map[igame.ChipColour]byte{
+	igame.NoColour: '.',
+	igame.Black:    'X',
+	igame.White:    'O',
+}
+
+const starSymbol = '+'
+
+// ErrDiagram error occurs when a text diagram of the field can't be parsed
+var ErrDiagram = errors.New("wrong field diagram")
+
+// String provides compatibility with Stringer interface.
+// It renders the field as a text diagram with coordinates,
+// star points and the last move put in parentheses.
+func (field *Field) String() string {
+	var b strings.Builder
+
+	stars := make(map[igame.TurnData]bool)
+	for _, td := range igame.StarPoints(field.size) {
+		stars[td] = true
+	}
+	var last *igame.TurnData
+	if n := len(field.history); n > 0 && field.history[n-1].move.Kind == igame.PlaceMove {
+		last = &field.history[n-1].move.Position
+	}
+
+	header := field.columnsHeader()
+	b.WriteString(header)
+	for y := 1; y <= field.size; y++ {
+		row := field.size - y + 1
+		fmt.Fprintf(&b, "%2d", row)
+		for x := 1; x <= field.size; x++ {
+			td := igame.TurnData{X: x, Y: y}
+			b.WriteByte(cellSeparator(last, td))
+
+			symbol := chipSymbols[field.at(td)]
+			if symbol == chipSymbols[igame.NoColour] && stars[td] {
+				symbol = starSymbol
+			}
+			b.WriteByte(symbol)
+		}
+		b.WriteByte(cellSeparator(last, igame.TurnData{X: field.size + 1, Y: y}))
+		fmt.Fprintf(&b, "%d\n", row)
+	}
+	b.WriteString(header)
+
+	return b.String()
+}
+
+// ParseDiagram creates the Field with chips put by Setup according to the text diagram.
+// The diagram is a square grid of "." (or "+") for empty points, "X" for black chips
+// and "O" for white ones. Coordinates and parentheses produced by String are ignored,
+// but the last move is not restored since the field has no history.
+func ParseDiagram(diagram string, komi float64, opts ...Option) (*Field, error) {
+	rows := make([]string, 0)
+	for _, line := range strings.Split(diagram, "\n") {
+		cells := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(" \t\r()0123456789", r) {
+				return -1
+			}
+			return r
+		}, line)
+		// columns header always starts with "A" which is not a cell symbol.
+		if cells == "" || strings.HasPrefix(cells, "A") {
+			continue
+		}
+		rows = append(rows, cells)
+	}
+
+	field, err := New(len(rows), komi, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create field from diagram: %w", err)
+	}
+
+	placements := make([]igame.Placement, 0)
+	for y, row := range rows {
+		if len(row) != field.size {
+			return nil, fmt.Errorf("%w: row %d has %d cells instead of %d", ErrDiagram, y+1, len(row), field.size)
+		}
+		for x := range row {
+			td := igame.TurnData{X: x + 1, Y: y + 1}
+			switch row[x] {
+			case chipSymbols[igame.Black]:
+				placements = append(placements, igame.Placement{Colour: igame.Black, Position: td})
+			case chipSymbols[igame.White]:
+				placements = append(placements, igame.Placement{Colour: igame.White, Position: td})
+			case chipSymbols[igame.NoColour], starSymbol:
+			default:
+				return nil, fmt.Errorf("%w: unknown symbol %q at %v", ErrDiagram, row[x], td)
+			}
+		}
+	}
+
+	if err := field.Setup(placements); err != nil {
+		return nil, fmt.Errorf("failed to setup field from diagram: %w", err)
+	}
+	return field, nil
+}
+
+// columnsHeader returns the line with letters of columns
+func (field *Field) columnsHeader() string {
+	var b strings.Builder
+	b.WriteString("  ")
+	for x := 1; x <= field.size; x++ {
+		b.WriteByte(' ')
+		b.WriteString(igame.ColumnLetter(x))
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// cellSeparator returns the symbol to put before the cell td
+func cellSeparator(last *igame.TurnData, td igame.TurnData) byte {
+	switch {
+	case last == nil || last.Y != td.Y:
+		return ' '
+	case last.X == td.X:
+		return '('
+	case last.X == td.X-1:
+		return ')'
+	}
+	return ' '
+}
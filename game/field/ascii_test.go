@@ -0,0 +1,95 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestString(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape)
+
+	want := `   A B C D E F G H J
+ 9 . X O . . . . . . 9
+ 8 X(O). O . . . . . 8
+ 7 . X O . . . + . . 7
+ 6 . . . . . . . . . 6
+ 5 . . . . + . . . . 5
+ 4 . . . . . . . . . 4
+ 3 . . + . . . + . . 3
+ 2 . . . . . . . . . 2
+ 1 . . . . . . . . . 1
+   A B C D E F G H J
+`
+	if got := field.String(); got != want {
+		t.Errorf("Unexpected String():\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestParseDiagram(t *testing.T) {
+	diagram := `   A B C D E F G H J
+ 9 . X O . . . . . . 9
+ 8 X . X O . . . . . 8
+ 7 . X O . . . + . . 7
+ 6 . . . . . . . . . 6
+ 5 . . . . + . . . . 5
+ 4 . . . . . . . . . 4
+ 3 . . + . . . + . . 3
+ 2 . . . . . . . . . 2
+ 1 . . . . . . . . . 1
+   A B C D E F G H J
+`
+	field, err := ParseDiagram(diagram, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected ParseDiagram() err: %v", err)
+	}
+	if got := field.String(); got != diagram {
+		t.Errorf("Unexpected String() of parsed diagram:\nwant:\n%s\ngot:\n%s", diagram, got)
+	}
+
+	if err := field.CheckMove(igame.White, &igame.TurnData{X: 1, Y: 1}); !errors.Is(err, ErrSuicide) {
+		t.Errorf("Unexpected CheckMove() err on parsed diagram:\nwant: %v,\ngot: %v.", ErrSuicide, err)
+	}
+}
+
+var diagramErrorTests = []struct {
+	name    string
+	diagram string
+	want    error
+}{
+	{name: "empty", diagram: "", want: ErrFieldSize},
+	{name: "short row", diagram: "XO.\n...\n..\n", want: ErrDiagram},
+	{name: "unknown symbol", diagram: "XO\n.#\n", want: ErrDiagram},
+}
+
+func TestParseDiagramErrors(t *testing.T) {
+	for _, test := range diagramErrorTests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseDiagram(test.diagram, defaultKomi); !errors.Is(err, test.want) {
+				t.Errorf("Unexpected ParseDiagram() err:\nwant: %v,\ngot: %v.", test.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// benchField returns 19x19 field filled with chips in a checkered pattern
+// without captures
+func benchField(b *testing.B) *Field {
+	field, err := New(maxSize, defaultKomi)
+	if err != nil {
+		b.Fatalf("Unexpected New() error: %v", err)
+	}
+	for y := 1; y <= maxSize; y += 2 {
+		for x := 1; x <= maxSize; x++ {
+			colour := igame.ChipColour(igame.Black)
+			if x%2 == 0 {
+				colour = igame.White
+			}
+			if err := field.Move(colour, &igame.TurnData{X: x, Y: y}); err != nil {
+				b.Fatalf("Unexpected Move() error: %v", err)
+			}
+		}
+	}
+	return field
+}
+
+func BenchmarkState(b *testing.B) {
+	field := benchField(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = field.State()
+	}
+}
+
+func BenchmarkMoveUndo(b *testing.B) {
+	field := benchField(b)
+	td := &igame.TurnData{X: 1, Y: 2}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := field.Move(igame.Black, td); err != nil {
+			b.Fatalf("Unexpected Move() error: %v", err)
+		}
+		if err := field.Undo(); err != nil {
+			b.Fatalf("Unexpected Undo() error: %v", err)
+		}
+	}
+}
+
+func BenchmarkStateGameOver(b *testing.B) {
+	field := benchField(b)
+	for _, colour := range []igame.ChipColour{igame.Black, igame.White} {
+		if err := field.Pass(colour); err != nil {
+			b.Fatalf("Unexpected Pass() error: %v", err)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = field.State()
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import (
+	"sort"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// at returns the colour of the chip at position td
+func (field *Field) at(td igame.TurnData) igame.ChipColour {
+	return field.field[td.Y-1][td.X-1]
+}
+
+// set puts the chip of colour to position td.
+// All changes of the board should be done by this function.
+func (field *Field) set(td igame.TurnData, colour igame.ChipColour) {
+	old := field.at(td)
+	field.hash = field.hash ^ zobristKey(td, old) ^ zobristKey(td, colour)
+	if old != igame.NoColour {
+		field.chips[old] = removePosition(field.chips[old], td)
+	}
+	field.field[td.Y-1][td.X-1] = colour
+	if colour != igame.NoColour {
+		field.chips[colour] = insertPosition(field.chips[colour], td)
+	}
+}
+
+// index returns the index of position td in flat per-point tables of the field
+func (field *Field) index(td igame.TurnData) int {
+	return (td.Y-1)*field.size + td.X - 1
+}
+
+// positionLess defines the order of positions in lists of chips
+func positionLess(a, b igame.TurnData) bool {
+	return a.X < b.X || (a.X == b.X && a.Y < b.Y)
+}
+
+// insertPosition inserts td into sorted positions
+func insertPosition(positions []igame.TurnData, td igame.TurnData) []igame.TurnData {
+	i := sort.Search(len(positions), func(i int) bool { return !positionLess(positions[i], td) })
+	positions = append(positions, igame.TurnData{})
+	copy(positions[i+1:], positions[i:])
+	positions[i] = td
+	return positions
+}
+
+// removePosition removes td from sorted positions
+func removePosition(positions []igame.TurnData, td igame.TurnData) []igame.TurnData {
+	i := sort.Search(len(positions), func(i int) bool { return !positionLess(positions[i], td) })
+	if i < len(positions) && positions[i] == td {
+		positions = append(positions[:i], positions[i+1:]...)
+	}
+	return positions
+}
+
+// opponent returns the colour of the opponent of the gamer playing by colour
+func opponent(colour igame.ChipColour) igame.ChipColour {
+	return igame.ChipColour(3 - int(colour))
+}
+
+// neighbours returns positions adjacent to td inside the field
+func (field *Field) neighbours(td igame.TurnData) []igame.TurnData {
+	positions := make([]igame.TurnData, 0, 4)
+	candidates := []igame.TurnData{
+		{X: td.X - 1, Y: td.Y},
+		{X: td.X + 1, Y: td.Y},
+		{X: td.X, Y: td.Y - 1},
+		{X: td.X, Y: td.Y + 1},
+	}
+	for _, c := range candidates {
+		if c.X >= 1 && c.Y >= 1 && c.X <= field.size && c.Y <= field.size {
+			positions = append(positions, c)
+		}
+	}
+	return positions
+}
+
+// group returns all chips of the chain containing td
+// and the number of its liberties
+func (field *Field) group(td igame.TurnData) (stones []igame.TurnData, liberties int) {
+	colour := field.at(td)
+	visited := map[igame.TurnData]bool{td: true}
+	libs := make(map[igame.TurnData]bool)
+	stack := []igame.TurnData{td}
+
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		stones = append(stones, cur)
+
+		for _, n := range field.neighbours(cur) {
+			switch field.at(n) {
+			case igame.NoColour:
+				libs[n] = true
+			case colour:
+				if !visited[n] {
+					visited[n] = true
+					stack = append(stack, n)
+				}
+			}
+		}
+	}
+	return stones, len(libs)
+}
+
+// capture removes chains of opposite to colour chips adjacent to td
+// which have no liberties left. It returns positions of removed chips.
+func (field *Field) capture(td igame.TurnData, colour igame.ChipColour) []igame.TurnData {
+	captured := make([]igame.TurnData, 0)
+	for _, n := range field.neighbours(td) {
+		if c := field.at(n); c == igame.NoColour || c == colour {
+			continue
+		}
+		stones, liberties := field.group(n)
+		if liberties > 0 {
+			continue
+		}
+		for _, s := range stones {
+			field.set(s, igame.NoColour)
+		}
+		captured = append(captured, stones...)
+	}
+	return captured
+}
+
+// koAfter returns the position forbidden by ko rule after the move to td
+// which captured chips at positions captured, or nil if there is no such position.
+func (field *Field) koAfter(td igame.TurnData, captured []igame.TurnData) *igame.TurnData {
+	if len(captured) != 1 {
+		return nil
+	}
+	if stones, liberties := field.group(td); len(stones) != 1 || liberties != 1 {
+		return nil
+	}
+	ko := captured[0]
+	return &ko
+}
+
+// AtariGroups returns chains of chips with the only liberty left for each colour
+func (field *Field) AtariGroups() map[igame.ChipColour][][]*igame.TurnData {
+	groups := map[igame.ChipColour][][]*igame.TurnData{
+		igame.Black: make([][]*igame.TurnData, 0),
+		igame.White: make([][]*igame.TurnData, 0),
+	}
+	visited := make(map[igame.TurnData]bool)
+
+	for x := 1; x <= field.size; x++ {
+		for y := 1; y <= field.size; y++ {
+			td := igame.TurnData{X: x, Y: y}
+			colour := field.at(td)
+			if colour == igame.NoColour || visited[td] {
+				continue
+			}
+
+			stones, liberties := field.group(td)
+			positions := make([]*igame.TurnData, len(stones))
+			for i := range stones {
+				visited[stones[i]] = true
+				positions[i] = &stones[i]
+			}
+			if liberties == 1 {
+				groups[colour] = append(groups[colour], positions)
+			}
+		}
+	}
+	return groups
+}
+
+// isLegal returns true if the chip of colour can be put to empty position td
+// without forbidden suicide and violation of ko rule
+func (field *Field) isLegal(colour igame.ChipColour, td igame.TurnData) bool {
+	if field.at(td) != igame.NoColour || (field.koPoint != nil && *field.koPoint == td) {
+		return false
+	}
+	return field.rules().suicide || !field.isSuicide(colour, td)
+}
+
+// isSuicide returns true if the chip of colour put to empty position td
+// leaves own chain without liberties
+func (field *Field) isSuicide(colour igame.ChipColour, td igame.TurnData) bool {
+	for _, n := range field.neighbours(td) {
+		c := field.at(n)
+		if c == igame.NoColour {
+			return false
+		}
+		_, liberties := field.group(n)
+		// joining own chain with other liberties, or capturing opponent's chain.
+		if (c == colour && liberties > 1) || (c != colour && liberties == 1) {
+			return false
+		}
+	}
+	return true
+}
+
+// LegalMoves returns all positions where the chip of colour can be put now
+func (field *Field) LegalMoves(colour igame.ChipColour) []igame.TurnData {
+	moves := make([]igame.TurnData, 0)
+	if field.checkColour(colour) != nil {
+		return moves
+	}
+	for x := 1; x <= field.size; x++ {
+		for y := 1; y <= field.size; y++ {
+			if td := (igame.TurnData{X: x, Y: y}); field.isLegal(colour, td) {
+				moves = append(moves, td)
+			}
+		}
+	}
+	return moves
+}
+
+// BookMoves returns moves of book known for the current position
+// which are legal for the colour to move
+func (field *Field) BookMoves(book igame.OpeningBook) []igame.BookMove {
+	moves := make([]igame.BookMove, 0)
+	colour := field.toMove()
+	if field.checkColour(colour) != nil {
+		return moves
+	}
+	for _, move := range book.Lookup(field.hash) {
+		td := move.Position
+		if td.X < 1 || td.Y < 1 || td.X > field.size || td.Y > field.size {
+			continue
+		}
+		if move.Colour == colour && field.isLegal(colour, td) {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// hasLegalMoves returns true if the chip of colour can be put anywhere
+func (field *Field) hasLegalMoves(colour igame.ChipColour) bool {
+	for x := 1; x <= field.size; x++ {
+		for y := 1; y <= field.size; y++ {
+			if field.isLegal(colour, igame.TurnData{X: x, Y: y}) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,262 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+type placement struct {
+	colour igame.ChipColour
+	td     igame.TurnData
+}
+
+// koShape is a position where white captures at 2:2 and creates a ko at 3:2
+var koShape = []placement{
+	{colour: igame.Black, td: igame.TurnData{X: 2, Y: 1}},
+	{colour: igame.White, td: igame.TurnData{X: 3, Y: 1}},
+	{colour: igame.Black, td: igame.TurnData{X: 1, Y: 2}},
+	{colour: igame.White, td: igame.TurnData{X: 4, Y: 2}},
+	{colour: igame.Black, td: igame.TurnData{X: 2, Y: 3}},
+	{colour: igame.White, td: igame.TurnData{X: 3, Y: 3}},
+	{colour: igame.Black, td: igame.TurnData{X: 3, Y: 2}},
+	{colour: igame.White, td: igame.TurnData{X: 2, Y: 2}},
+}
+
+func play(t *testing.T, field *Field, moves []placement) {
+	for _, m := range moves {
+		td := m.td
+		if err := field.Move(m.colour, &td); err != nil {
+			t.Fatalf("Unexpected Move() err on %v: %v", td, err)
+		}
+	}
+}
+
+func TestCapture(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	play(t, field, []placement{
+		{colour: igame.White, td: igame.TurnData{X: 1, Y: 1}},
+		{colour: igame.Black, td: igame.TurnData{X: 2, Y: 1}},
+		{colour: igame.Black, td: igame.TurnData{X: 1, Y: 2}},
+	})
+
+	state := field.State()
+	if len(state.ChipsOnBoard[igame.White]) != 0 {
+		t.Errorf("Unexpected white chips on board after capture:\nwant: 0,\ngot: %d.", len(state.ChipsOnBoard[igame.White]))
+	}
+	if state.ChipsCuptured[igame.White] != 1 {
+		t.Errorf("Unexpected number of captured white chips:\nwant: 1,\ngot: %d.", state.ChipsCuptured[igame.White])
+	}
+	if state.Prisoners[igame.Black] != 1 || state.Prisoners[igame.White] != 0 {
+		t.Errorf("Unexpected prisoners after capture:\nwant: black: 1, white: 0,\ngot: %v.", state.Prisoners)
+	}
+	if state.KoPoint != nil {
+		t.Errorf("Unexpected KoPoint after simple capture:\nwant: nil,\ngot: %v.", state.KoPoint)
+	}
+
+	if err := field.Undo(); err != nil {
+		t.Fatalf("Unexpected Undo() err: %v", err)
+	}
+	if state := field.State(); state.ChipsCuptured[igame.White] != 0 || len(state.ChipsOnBoard[igame.White]) != 1 {
+		t.Errorf("Unexpected white chips after Undo() of capture:\nwant: 0 captured, 1 on board,\ngot: %d captured, %d on board.",
+			state.ChipsCuptured[igame.White], len(state.ChipsOnBoard[igame.White]))
+	}
+}
+
+func TestSuicide(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	play(t, field, []placement{
+		{colour: igame.Black, td: igame.TurnData{X: 2, Y: 1}},
+		{colour: igame.Black, td: igame.TurnData{X: 1, Y: 2}},
+	})
+
+	preCount := field.State().ChipsInCup[igame.White]
+	want := ErrSuicide
+	if err := field.Move(igame.White, &igame.TurnData{X: 1, Y: 1}); !errors.Is(err, want) {
+		t.Errorf("Unexpected Move() err:\nwant: %v,\ngot: %v.", want, err)
+	}
+
+	state := field.State()
+	if len(state.ChipsOnBoard[igame.White]) != 0 || state.ChipsInCup[igame.White] != preCount {
+		t.Errorf("Unexpected state after rejected suicide: %v", state)
+	}
+}
+
+func TestKo(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape)
+
+	want := igame.TurnData{X: 3, Y: 2}
+	state := field.State()
+	if state.KoPoint == nil || *state.KoPoint != want {
+		t.Fatalf("Unexpected KoPoint:\nwant: %v,\ngot: %v.", want, state.KoPoint)
+	}
+
+	if err := field.Move(igame.Black, &igame.TurnData{X: 3, Y: 2}); !errors.Is(err, ErrKo) {
+		t.Errorf("Unexpected Move() err on ko retake:\nwant: %v,\ngot: %v.", ErrKo, err)
+	}
+
+	play(t, field, []placement{
+		{colour: igame.Black, td: igame.TurnData{X: 9, Y: 9}},
+		{colour: igame.White, td: igame.TurnData{X: 9, Y: 8}},
+	})
+	if state := field.State(); state.KoPoint != nil {
+		t.Errorf("Unexpected KoPoint after ko threat:\nwant: nil,\ngot: %v.", state.KoPoint)
+	}
+	play(t, field, []placement{
+		{colour: igame.Black, td: igame.TurnData{X: 3, Y: 2}},
+	})
+}
+
+func TestAtariGroups(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	play(t, field, []placement{
+		{colour: igame.White, td: igame.TurnData{X: 1, Y: 1}},
+		{colour: igame.White, td: igame.TurnData{X: 2, Y: 1}},
+		{colour: igame.Black, td: igame.TurnData{X: 3, Y: 1}},
+		{colour: igame.Black, td: igame.TurnData{X: 1, Y: 2}},
+		{colour: igame.Black, td: igame.TurnData{X: 5, Y: 5}},
+	})
+
+	groups := field.AtariGroups()
+	if len(groups[igame.Black]) != 0 {
+		t.Errorf("Unexpected black groups in atari:\nwant: 0,\ngot: %v.", groups[igame.Black])
+	}
+	if len(groups[igame.White]) != 1 || len(groups[igame.White][0]) != 2 {
+		t.Fatalf("Unexpected white groups in atari:\nwant: one group of 2 chips,\ngot: %v.", groups[igame.White])
+	}
+
+	play(t, field, []placement{
+		{colour: igame.Black, td: igame.TurnData{X: 2, Y: 2}},
+	})
+	if groups := field.AtariGroups(); len(groups[igame.White]) != 0 {
+		t.Errorf("Unexpected white groups in atari after capture:\nwant: 0,\ngot: %v.", groups[igame.White])
+	}
+}
+
+var moveErrorTests = []struct {
+	name   string
+	colour igame.ChipColour
+	td     igame.TurnData
+	want   error
+}{
+	{name: "no colour", colour: igame.NoColour, td: igame.TurnData{X: 5, Y: 5}, want: ErrColour},
+	{name: "out of range", colour: igame.Black, td: igame.TurnData{X: 10, Y: 5}, want: ErrPosition},
+	{name: "occupied", colour: igame.Black, td: igame.TurnData{X: 2, Y: 2}, want: ErrOccupied},
+	{name: "ko", colour: igame.Black, td: igame.TurnData{X: 3, Y: 2}, want: ErrKo},
+	{name: "suicide", colour: igame.Black, td: igame.TurnData{X: 9, Y: 9}, want: ErrSuicide},
+}
+
+func TestMoveError(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, []placement{
+		{colour: igame.White, td: igame.TurnData{X: 9, Y: 8}},
+		{colour: igame.White, td: igame.TurnData{X: 8, Y: 9}},
+	})
+	play(t, field, koShape)
+	wantKo := igame.TurnData{X: 3, Y: 2}
+
+	for _, test := range moveErrorTests {
+		t.Run(test.name, func(t *testing.T) {
+			td := test.td
+			err := field.Move(test.colour, &td)
+
+			var moveErr *MoveError
+			if !errors.As(err, &moveErr) {
+				t.Fatalf("Unexpected Move() err type:\nwant: *MoveError,\ngot: %T.", err)
+			}
+			if !errors.Is(err, test.want) || moveErr.Reason != test.want ||
+				moveErr.Position != test.td || moveErr.Colour != test.colour {
+				t.Errorf("Unexpected MoveError:\nwant: %v at %v,\ngot: %v.", test.want, test.td, moveErr)
+			}
+			if moveErr.KoPoint == nil || *moveErr.KoPoint != wantKo {
+				t.Errorf("Unexpected MoveError.KoPoint:\nwant: %v,\ngot: %v.", wantKo, moveErr.KoPoint)
+			}
+		})
+	}
+}
+
+func TestCheckMove(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, []placement{
+		{colour: igame.White, td: igame.TurnData{X: 9, Y: 8}},
+		{colour: igame.White, td: igame.TurnData{X: 8, Y: 9}},
+	})
+	play(t, field, koShape)
+	before := field.State()
+
+	for _, test := range moveErrorTests {
+		t.Run(test.name, func(t *testing.T) {
+			td := test.td
+			if err := field.CheckMove(test.colour, &td); !errors.Is(err, test.want) {
+				t.Errorf("Unexpected CheckMove() err:\nwant: %v,\ngot: %v.", test.want, err)
+			}
+		})
+	}
+
+	if err := field.CheckMove(igame.Black, &igame.TurnData{X: 5, Y: 5}); err != nil {
+		t.Errorf("Unexpected CheckMove() err on legal move: %v", err)
+	}
+	if !reflect.DeepEqual(before, field.State()) {
+		t.Errorf("Unexpected field change by CheckMove()")
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	field, err := ParseDiagram(`
+. X .
+X . .
+. . O
+`, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected ParseDiagram() error: %v", err)
+	}
+
+	want := []igame.TurnData{{X: 1, Y: 3}, {X: 2, Y: 2}, {X: 2, Y: 3}, {X: 3, Y: 1}, {X: 3, Y: 2}}
+	if got := field.LegalMoves(igame.White); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected LegalMoves():\nwant: %v,\ngot: %v.", want, got)
+	}
+	if got := field.LegalMoves(igame.NoColour); len(got) != 0 {
+		t.Errorf("Unexpected LegalMoves() for NoColour:\nwant: [],\ngot: %v.", got)
+	}
+}
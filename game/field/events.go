@@ -0,0 +1,60 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// CaptureHandler is called after chips are removed from the field.
+// colour is the colour of the gamer who took the chips at positions captured.
+type CaptureHandler func(colour igame.ChipColour, captured []igame.TurnData)
+
+// GameOverHandler is called after the move which ends the game
+// with the state of the finished game.
+type GameOverHandler func(state *igame.FieldState)
+
+// OnCapture registers handler to be called on every capture.
+// Handlers are called synchronously by Move in order of registration.
+func (field *Field) OnCapture(handler CaptureHandler) {
+	field.captureHandlers = append(field.captureHandlers, handler)
+}
+
+// OnGameOver registers handler to be called when the game ends.
+// Handlers are called synchronously by Move, Pass or Resign in order of registration.
+func (field *Field) OnGameOver(handler GameOverHandler) {
+	field.gameOverHandlers = append(field.gameOverHandlers, handler)
+}
+
+// notify calls handlers of events caused by the move of rec
+func (field *Field) notify(rec record) {
+	for _, handler := range field.captureHandlers {
+		if len(rec.captured) > 0 {
+			handler(rec.move.Colour, copyPositions(rec.captured))
+		}
+		if len(rec.suicided) > 0 {
+			handler(opponent(rec.move.Colour), copyPositions(rec.suicided))
+		}
+	}
+
+	if len(field.gameOverHandlers) == 0 {
+		return
+	}
+	if state := field.State(); state.GameOver {
+		for _, handler := range field.gameOverHandlers {
+			handler(state)
+		}
+	}
+}
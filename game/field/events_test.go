@@ -0,0 +1,75 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestOnCapture(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	var colours []igame.ChipColour
+	var captured [][]igame.TurnData
+	field.OnCapture(func(colour igame.ChipColour, positions []igame.TurnData) {
+		colours = append(colours, colour)
+		captured = append(captured, positions)
+	})
+
+	play(t, field, koShape)
+	wantColours := []igame.ChipColour{igame.White}
+	wantCaptured := [][]igame.TurnData{{{X: 3, Y: 2}}}
+	if !reflect.DeepEqual(colours, wantColours) || !reflect.DeepEqual(captured, wantCaptured) {
+		t.Errorf("Unexpected capture events:\nwant: %v %v,\ngot: %v %v.", wantColours, wantCaptured, colours, captured)
+	}
+}
+
+func TestOnGameOver(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	var states []*igame.FieldState
+	field.OnGameOver(func(state *igame.FieldState) {
+		states = append(states, state)
+	})
+
+	if err := field.Pass(igame.Black); err != nil {
+		t.Fatalf("Unexpected Pass() err: %v", err)
+	}
+	if len(states) != 0 {
+		t.Errorf("Unexpected game over event after one pass: %v", states)
+	}
+	if err := field.Resign(igame.White); err != nil {
+		t.Fatalf("Unexpected Resign() err: %v", err)
+	}
+	if len(states) != 1 || states[0].Termination != igame.Resignation {
+		t.Errorf("Unexpected game over events after resignation:\nwant: one with %v,\ngot: %v.", igame.Resignation, states)
+	}
+
+	if clone := field.Clone(); clone.Undo() != nil || clone.Pass(igame.White) != nil || len(states) != 1 {
+		t.Errorf("Unexpected game over event from clone of the field: %v", states)
+	}
+}
@@ -19,13 +19,14 @@ package field
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/yagoggame/gomaster/game/igame"
 )
 
 var (
 	// ErrFieldSize error occures when New is called with wrong size
-	ErrFieldSize = errors.New("field size is out of range (from 1x1 to 19x19)")
+	ErrFieldSize = errors.New("field size is out of range (from 2x2 to 19x19)")
 	// ErrColour error occurs when some of operations is made with No Colour
 	ErrColour = errors.New("only black and white chips allowed")
 	// ErrPosition error occurs when Move is made with TurnData out of range
@@ -36,12 +37,53 @@ var (
 	ErrNoChips = errors.New("no chips left")
 	// ErrGameOver error occurs when attempt operation on game wich is over
 	ErrGameOver = errors.New("the game is over")
+	// ErrSuicide error occurs when Move leaves own chain without liberties
+	ErrSuicide = errors.New("the move is suicide")
+	// ErrKo error occurs when Move retakes the ko immediately
+	ErrKo = errors.New("the position is forbidden by ko")
+	// ErrNoMoves error occurs when Undo is called with no moves made
+	ErrNoMoves = errors.New("no moves to undo")
+	// ErrSetup error occurs when Setup or SetFirst is called after the first move
+	ErrSetup = errors.New("setup is allowed only before the first move")
+	// ErrRuleset error occurs when New is called with unknown ruleset
+	ErrRuleset = errors.New("unknown ruleset")
+	// ErrNoChip error occurs when a chip is expected at empty position
+	ErrNoChip = errors.New("no chip at the position")
+	// ErrKomi error occurs when New is called with komi out of range
+	// or not allowed by the granularity
+	ErrKomi = errors.New("wrong komi value")
+	// ErrKomiFixed error occurs when SetKomi is called after the first move of white
+	ErrKomiFixed = errors.New("komi can be changed only before the second move")
 )
 
+// MoveError describes an illegal move.
+// Reason is one of ErrColour, ErrGameOver, ErrPosition,
+// ErrOccupied, ErrKo or ErrSuicide, also available with errors.Is.
+type MoveError struct {
+	Reason   error
+	Colour   igame.ChipColour
+	Position igame.TurnData
+	KoPoint  *igame.TurnData // ko point at the moment of the move, nil if there is no ko
+}
+
+// Error provides compatibility with error interface.
+func (e *MoveError) Error() string {
+	msg := fmt.Sprintf("%s: colour: %v, position: %v", e.Reason, e.Colour, e.Position)
+	if e.KoPoint != nil {
+		msg = fmt.Sprintf("%s, ko point: %v", msg, *e.KoPoint)
+	}
+	return msg
+}
+
+// Unwrap returns the reason of the error.
+func (e *MoveError) Unwrap() error {
+	return e.Reason
+}
+
 const (
 	whiteMax = 180
 	blackMax = 181
-	minSize  = 1
+	minSize  = 2 // the only point of 1x1 field is suicide, so there are no legal moves on it
 	maxSize  = 19
 )
 
@@ -50,11 +92,27 @@ type Field struct {
 	field       [][]igame.ChipColour
 	size        int
 	komi        float64
+	ruleset     igame.Ruleset
+	halfKomi    bool             // komi must be a multiple of 0.5
+	captureGo   bool             // the first capture wins the game
+	jigoWinner  igame.ChipColour // colour winning on equal scores, NoColour if jigo is a draw
 	chipsNumber map[igame.ChipColour]int
+	captured    map[igame.ChipColour]int // number of chips of colour captured by the opponent
+	koPoint     *igame.TurnData
+	history     []record
+	setup       []igame.Placement
+	first       igame.ChipColour                      // colour making the first move
+	chips       map[igame.ChipColour][]igame.TurnData // sorted positions of chips on the field
+	hash        uint64                                // Zobrist hash of the position
+	terminated  igame.Termination                     // reason of the game end, updated by changes of the position
+
+	captureHandlers  []CaptureHandler
+	gameOverHandlers []GameOverHandler
+	filters          []StateFilter
 }
 
 // New generate Field with demensions of size x size
-func New(size int, komi float64) (*Field, error) {
+func New(size int, komi float64, opts ...Option) (*Field, error) {
 	if size < minSize || size > maxSize {
 		return nil, fmt.Errorf("%w: desired sise is %[2]dx%[2]d", ErrFieldSize, size)
 	}
@@ -67,70 +125,381 @@ func New(size int, komi float64) (*Field, error) {
 			igame.Black: blackMax,
 			igame.White: whiteMax,
 		},
+		captured: map[igame.ChipColour]int{
+			igame.Black: 0,
+			igame.White: 0,
+		},
+		chips: map[igame.ChipColour][]igame.TurnData{
+			igame.Black: make([]igame.TurnData, 0),
+			igame.White: make([]igame.TurnData, 0),
+		},
+		hash:  zobristSizes[size],
+		first: igame.Black,
 	}
 	for i := range field.field {
 		field.field[i] = make([]igame.ChipColour, size)
 	}
+
+	for _, opt := range opts {
+		opt(field)
+	}
+	if _, ok := presets[field.ruleset]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrRuleset, field.ruleset)
+	}
+	if err := field.checkKomi(); err != nil {
+		return nil, err
+	}
+	field.updateTermination()
 	return field, nil
 }
 
+// checkKomi validates komi of the field: it can't exceed the area of the field
+func (field *Field) checkKomi() error {
+	if math.IsNaN(field.komi) || math.Abs(field.komi) > float64(field.size*field.size) {
+		return fmt.Errorf("%w: %v is out of range for %[3]dx%[3]d field", ErrKomi, field.komi, field.size)
+	}
+	if field.halfKomi && math.Mod(field.komi*2, 1) != 0 {
+		return fmt.Errorf("%w: %v is not a multiple of 0.5", ErrKomi, field.komi)
+	}
+	return nil
+}
+
+// SetKomi changes komi of the field while it's negotiated,
+// before the second move of the game
+func (field *Field) SetKomi(komi float64) error {
+	if len(field.history) > 1 {
+		return ErrKomiFixed
+	}
+	old := field.komi
+	field.komi = komi
+	if err := field.checkKomi(); err != nil {
+		field.komi = old
+		return err
+	}
+	return nil
+}
+
+// Clone returns an independent deep copy of the field.
+// Event handlers are not copied.
+func (field *Field) Clone() *Field {
+	clone := &Field{
+		size:        field.size,
+		komi:        field.komi,
+		ruleset:     field.ruleset,
+		halfKomi:    field.halfKomi,
+		captureGo:   field.captureGo,
+		jigoWinner:  field.jigoWinner,
+		filters:     append([]StateFilter(nil), field.filters...),
+		field:       make([][]igame.ChipColour, field.size),
+		chipsNumber: make(map[igame.ChipColour]int, len(field.chipsNumber)),
+		captured:    make(map[igame.ChipColour]int, len(field.captured)),
+		history:     make([]record, len(field.history)),
+		setup:       make([]igame.Placement, len(field.setup)),
+		chips:       make(map[igame.ChipColour][]igame.TurnData, len(field.chips)),
+		hash:        field.hash,
+		terminated:  field.terminated,
+		first:       field.first,
+	}
+	for i := range field.field {
+		clone.field[i] = make([]igame.ChipColour, field.size)
+		copy(clone.field[i], field.field[i])
+	}
+	for colour, number := range field.chipsNumber {
+		clone.chipsNumber[colour] = number
+	}
+	for colour, number := range field.captured {
+		clone.captured[colour] = number
+	}
+	for colour, chips := range field.chips {
+		clone.chips[colour] = append(make([]igame.TurnData, 0, len(chips)), chips...)
+	}
+	for i, rec := range field.history {
+		clone.history[i] = rec.clone()
+	}
+	copy(clone.setup, field.setup)
+	clone.koPoint = copyTurnData(field.koPoint)
+	return clone
+}
+
 // Size returns field's size
 func (field *Field) Size() int {
 	return field.size
 }
 
+// Ruleset returns the ruleset of the field
+func (field *Field) Ruleset() igame.Ruleset {
+	return field.ruleset
+}
+
+// Variant describes options of the field changing rules of the game
+type Variant struct {
+	HalfPointKomi bool             // komi must be a multiple of 0.5
+	CaptureGo     bool             // the first capture wins the game
+	JigoWinner    igame.ChipColour // colour winning on equal scores, NoColour if jigo is a draw
+	Filters       int              // number of filters of the state added by WithStateFilter, WithBlind and WithOneColour
+}
+
+// Variant returns options of the field other than the ruleset
+func (field *Field) Variant() Variant {
+	return Variant{
+		HalfPointKomi: field.halfKomi,
+		CaptureGo:     field.captureGo,
+		JigoWinner:    field.jigoWinner,
+		Filters:       len(field.filters),
+	}
+}
+
 // Move performs move with attempt to put chip of colour to position td
 func (field *Field) Move(colour igame.ChipColour, td *igame.TurnData) error {
 	if err := field.precheck(colour, td); err != nil {
 		return err
 	}
-	if err := field.checkPosition(td); err != nil {
+	if err := field.checkPosition(colour, td); err != nil {
 		return err
 	}
 
+	field.set(*td, colour)
+	captured := field.capture(*td, colour)
+	var suicided []igame.TurnData
+	if stones, liberties := field.group(*td); liberties == 0 {
+		if !field.rules().suicide {
+			field.set(*td, igame.NoColour)
+			return field.moveError(ErrSuicide, colour, td)
+		}
+		for _, s := range stones {
+			field.set(s, igame.NoColour)
+		}
+		suicided = stones
+	}
+
 	field.chipsNumber[colour] = field.chipsNumber[colour] - 1
-	field.field[td.Y-1][td.X-1] = colour
+	field.captured[opponent(colour)] = field.captured[opponent(colour)] + len(captured)
+	field.captured[colour] = field.captured[colour] + len(suicided)
+	field.history = append(field.history, record{
+		move:     igame.Move{Colour: colour, Kind: igame.PlaceMove, Position: *td},
+		captured: captured,
+		suicided: suicided,
+		koPoint:  field.koPoint,
+	})
+	field.koPoint = field.koAfter(*td, captured)
+	field.updateTermination()
+	field.notify(field.history[len(field.history)-1])
+	return nil
+}
+
+// CheckMove performs all checks of the move of colour to position td
+// without changing the field. It returns the same errors as Move.
+func (field *Field) CheckMove(colour igame.ChipColour, td *igame.TurnData) error {
+	if err := field.precheck(colour, td); err != nil {
+		return err
+	}
+	if err := field.checkPosition(colour, td); err != nil {
+		return err
+	}
+	if !field.rules().suicide && field.isSuicide(colour, *td) {
+		return field.moveError(ErrSuicide, colour, td)
+	}
+	return nil
+}
+
+// Setup puts chips of placements on the field before the first move.
+// Either all placements are put or none of them.
+func (field *Field) Setup(placements []igame.Placement) error {
+	if len(field.history) > 0 {
+		return ErrSetup
+	}
+
+	occupied := make(map[igame.TurnData]bool)
+	number := make(map[igame.ChipColour]int)
+	for i := range placements {
+		p := &placements[i]
+		if err := field.precheck(p.Colour, &p.Position); err != nil {
+			return err
+		}
+		if err := field.checkPosition(p.Colour, &p.Position); err != nil || occupied[p.Position] {
+			return fmt.Errorf("%w: at %v", ErrOccupied, p.Position)
+		}
+		occupied[p.Position] = true
+		number[p.Colour]++
+		if number[p.Colour] > field.chipsNumber[p.Colour] {
+			return fmt.Errorf("%w: colour: %v", ErrNoChips, p.Colour)
+		}
+	}
+
+	for _, p := range placements {
+		field.set(p.Position, p.Colour)
+		field.chipsNumber[p.Colour] = field.chipsNumber[p.Colour] - 1
+		field.setup = append(field.setup, p)
+	}
+	field.updateTermination()
+	return nil
+}
+
+// SetFirst sets colour making the first move, black by default.
+// White moves first after handicap chips of black.
+func (field *Field) SetFirst(colour igame.ChipColour) error {
+	if colour != igame.Black && colour != igame.White {
+		return fmt.Errorf("%w: colour: %v", ErrColour, colour)
+	}
+	if len(field.history) > 0 {
+		return ErrSetup
+	}
+	field.first = colour
+	field.updateTermination()
+	return nil
+}
+
+// Pass performs pass of the gamer playing by colour
+func (field *Field) Pass(colour igame.ChipColour) error {
+	if err := field.precheckColour(colour); err != nil {
+		return err
+	}
+
+	field.history = append(field.history, record{
+		move:    igame.Move{Colour: colour, Kind: igame.PassMove},
+		koPoint: field.koPoint,
+	})
+	field.koPoint = nil
+	field.updateTermination()
+	field.notify(field.history[len(field.history)-1])
+	return nil
+}
+
+// Resign performs resignation of the gamer playing by colour.
+// The game ends immediately, the opponent wins.
+func (field *Field) Resign(colour igame.ChipColour) error {
+	if err := field.precheckColour(colour); err != nil {
+		return err
+	}
+
+	field.history = append(field.history, record{
+		move:    igame.Move{Colour: colour, Kind: igame.ResignMove},
+		koPoint: field.koPoint,
+	})
+	field.koPoint = nil
+	field.updateTermination()
+	field.notify(field.history[len(field.history)-1])
 	return nil
 }
 
-// State calculate full state description
+// State calculate full state description.
+// The state doesn't share any data with the field, so it's safe
+// to retain and modify it across moves.
 func (field *Field) State() *igame.FieldState {
 	state := &igame.FieldState{
 		ChipsInCup:         make(map[igame.ChipColour]int, 2),
 		ChipsCuptured:      make(map[igame.ChipColour]int, 2),
+		Prisoners:          make(map[igame.ChipColour]int, 2),
 		PointsUnderControl: make(map[igame.ChipColour][]*igame.TurnData, 2),
 		Scores:             make(map[igame.ChipColour]float64, 2),
-		ChipsOnBoard:       make(map[igame.ChipColour][]*igame.TurnData, 2),
+		ChipsOnBoard:       field.chipsOnBoard(),
+		Komi:               field.komi,
+		KoPoint:            copyTurnData(field.koPoint),
+		Hash:               field.hash,
 	}
 
 	colours := []igame.ChipColour{igame.White, igame.Black}
-	initialNumber := map[igame.ChipColour]int{
-		igame.White: whiteMax,
-		igame.Black: blackMax,
-	}
-
 	for _, colour := range colours {
 		state.ChipsInCup[colour] = field.chipsNumber[colour]
-		state.ChipsOnBoard[colour] = field.getChipsOnBoard(colour)
-		state.ChipsCuptured[colour] = initialNumber[colour] - state.ChipsInCup[colour] - len(state.ChipsOnBoard[colour])
+		state.ChipsCuptured[colour] = field.captured[colour]
 		state.PointsUnderControl[colour] = field.pointsUnderControl(colour)
-		state.Scores[colour] = float64(state.ChipsCuptured[colour] + len(state.PointsUnderControl[colour]))
+		state.Prisoners[colour] = field.prisoners(colour)
+		state.Scores[colour] = float64(state.Prisoners[colour] + len(state.PointsUnderControl[colour]))
 	}
 	state.Scores[igame.White] = state.Scores[igame.White] + state.Komi
-	state.GameOver = field.isGameOver()
+	state.Termination = field.termination()
+	state.GameOver = state.Termination != igame.NotTerminated
+	state.Result = field.result(state.Termination)
+	if len(field.history) > 0 {
+		lm := field.history[len(field.history)-1].move
+		state.LastMove = &lm
+	}
 
 	return state
 }
 
 func (field *Field) isGameOver() bool {
+	return field.termination() != igame.NotTerminated
+}
+
+// termination returns the reason of the game end
+func (field *Field) termination() igame.Termination {
+	return field.terminated
+}
+
+// updateTermination checks the reason of the game end after a change of the position.
+// It scans the whole field for legal moves, so it's done once per change, not per read.
+func (field *Field) updateTermination() {
+	field.terminated = field.checkTermination()
+}
+
+// checkTermination calculates the reason of the game end
+func (field *Field) checkTermination() igame.Termination {
+	if field.captureGo && field.capturer() != igame.NoColour {
+		return igame.FirstCapture
+	}
+
 	colours := []igame.ChipColour{igame.White, igame.Black}
 	for _, colour := range colours {
 		if field.chipsNumber[colour] < 1 {
-			return true
+			return igame.NoChipsLeft
+		}
+	}
+
+	n := len(field.history)
+	if n > 0 && field.history[n-1].move.Kind == igame.ResignMove {
+		return igame.Resignation
+	}
+	if n > 1 && field.history[n-1].move.Kind == igame.PassMove && field.history[n-2].move.Kind == igame.PassMove &&
+		(!field.rules().whitePassesLast || field.history[n-1].move.Colour == igame.White) {
+		return igame.TwoPasses
+	}
+	if !field.hasLegalMoves(field.toMove()) {
+		return igame.NoLegalMoves
+	}
+	return igame.NotTerminated
+}
+
+// result returns the outcome of the game finished for termination reason,
+// or nil if the game is in progress.
+// Games not ended by resignation are decided by score with all chips alive.
+func (field *Field) result(termination igame.Termination) *igame.Result {
+	switch termination {
+	case igame.NotTerminated:
+		return nil
+	case igame.Resignation:
+		return &igame.Result{
+			Winner: opponent(field.history[len(field.history)-1].move.Colour),
+			Method: igame.ResignMethod,
 		}
+	case igame.FirstCapture:
+		return &igame.Result{Winner: field.capturer(), Method: igame.CaptureMethod}
+	}
+	result, _ := field.FinalScore(nil)
+	return result
+}
+
+// capturer returns the colour of the gamer who took chips by the last move,
+// or NoColour if the last move captured nothing
+func (field *Field) capturer() igame.ChipColour {
+	n := len(field.history)
+	switch {
+	case n == 0:
+		return igame.NoColour
+	case len(field.history[n-1].captured) > 0:
+		return field.history[n-1].move.Colour
+	case len(field.history[n-1].suicided) > 0:
+		return opponent(field.history[n-1].move.Colour)
+	}
+	return igame.NoColour
+}
+
+// toMove returns the colour expected to make the next move
+func (field *Field) toMove() igame.ChipColour {
+	if n := len(field.history); n > 0 {
+		return opponent(field.history[n-1].move.Colour)
 	}
-	// TODO: calculate additional critetria
-	return false
+	return field.first
 }
 
 func (field *Field) pointsUnderControl(colour igame.ChipColour) []*igame.TurnData {
@@ -139,39 +508,79 @@ func (field *Field) pointsUnderControl(colour igame.ChipColour) []*igame.TurnDat
 	return positions
 }
 
-func (field *Field) getChipsOnBoard(colour igame.ChipColour) []*igame.TurnData {
-	positions := make([]*igame.TurnData, 0)
+// chipsOnBoard returns copies of positions of chips of both colours.
+// All positions are allocated at once to keep State() cheap.
+func (field *Field) chipsOnBoard() map[igame.ChipColour][]*igame.TurnData {
+	black, white := field.chips[igame.Black], field.chips[igame.White]
+	values := make([]igame.TurnData, len(black)+len(white))
+	copy(values, black)
+	copy(values[len(black):], white)
 
-	for x := 0; x < field.Size(); x++ {
-		for y := 0; y < field.Size(); y++ {
-			td := &igame.TurnData{X: x + 1, Y: y + 1}
-			if field.field[td.Y-1][td.X-1] == colour {
-				positions = append(positions, td)
-			}
-		}
+	positions := make([]*igame.TurnData, len(values))
+	for i := range values {
+		positions[i] = &values[i]
+	}
+	return map[igame.ChipColour][]*igame.TurnData{
+		igame.Black: positions[:len(black):len(black)],
+		igame.White: positions[len(black):],
 	}
-
-	return positions
 }
 
 func (field *Field) precheck(colour igame.ChipColour, td *igame.TurnData) error {
-	if colour != igame.Black && colour != igame.White {
-		return fmt.Errorf("%w: got colour: %v", ErrColour, colour)
+	if reason := field.checkColour(colour); reason != nil {
+		return field.moveError(reason, colour, td)
 	}
 
 	if td.X < 1 || td.Y < 1 || td.X > field.size || td.Y > field.size {
-		return fmt.Errorf("%w: got turn data: %v", ErrPosition, td)
+		return field.moveError(ErrPosition, colour, td)
 	}
+
+	return nil
+}
+
+func (field *Field) precheckColour(colour igame.ChipColour) error {
+	if reason := field.checkColour(colour); reason != nil {
+		return fmt.Errorf("%w: colour: %v", reason, colour)
+	}
+	return nil
+}
+
+// checkColour returns the reason why gamer playing by colour can't move, or nil
+func (field *Field) checkColour(colour igame.ChipColour) error {
+	if colour != igame.Black && colour != igame.White {
+		return ErrColour
+	}
+
 	if field.isGameOver() {
-		return fmt.Errorf("%w: colour: %v", ErrGameOver, colour)
+		return ErrGameOver
 	}
 
 	return nil
 }
 
-func (field *Field) checkPosition(td *igame.TurnData) error {
-	if field.field[td.Y-1][td.X-1] != igame.NoColour {
-		return fmt.Errorf("%w: at %d", ErrOccupied, td)
+func (field *Field) moveError(reason error, colour igame.ChipColour, td *igame.TurnData) error {
+	return &MoveError{
+		Reason:   reason,
+		Colour:   colour,
+		Position: *td,
+		KoPoint:  copyTurnData(field.koPoint),
+	}
+}
+
+func copyTurnData(td *igame.TurnData) *igame.TurnData {
+	if td == nil {
+		return nil
+	}
+	tdCpy := *td
+	return &tdCpy
+}
+
+func (field *Field) checkPosition(colour igame.ChipColour, td *igame.TurnData) error {
+	if field.at(*td) != igame.NoColour {
+		return field.moveError(ErrOccupied, colour, td)
+	}
+	if field.koPoint != nil && *field.koPoint == *td {
+		return field.moveError(ErrKo, colour, td)
 	}
 	return nil
 }
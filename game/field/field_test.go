@@ -18,6 +18,8 @@ package field_test
 
 import (
 	"errors"
+	"math"
+	"reflect"
 	"testing"
 
 	. "github.com/yagoggame/gomaster/game/field"
@@ -43,6 +45,11 @@ var (
 			size: 0,
 			want: ErrFieldSize,
 		},
+		{
+			name: "1 size",
+			size: 1,
+			want: ErrFieldSize,
+		},
 		{
 			name: "20 size",
 			size: 20,
@@ -143,6 +150,35 @@ func TestNew(t *testing.T) {
 	}
 }
 
+var komiTests = []struct {
+	name string
+	komi float64
+	opts []Option
+	want error
+}{
+	{name: "usual", komi: 6.5, want: nil},
+	{name: "reverse", komi: -5, want: nil},
+	{name: "too big", komi: 81.5, want: ErrKomi},
+	{name: "nan", komi: math.NaN(), want: ErrKomi},
+	{name: "infinite", komi: math.Inf(1), want: ErrKomi},
+	{name: "half point", komi: 7, opts: []Option{WithHalfPointKomi()}, want: nil},
+	{name: "wrong granularity", komi: 6.3, opts: []Option{WithHalfPointKomi()}, want: ErrKomi},
+}
+
+func TestNewKomi(t *testing.T) {
+	for _, test := range komiTests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := New(usualSize, test.komi, test.opts...)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("Unexpected New() err:\nwant: %v,\ngot: %v.", test.want, err)
+			}
+			if err == nil && field.State().Komi != test.komi {
+				t.Errorf("Unexpected State().Komi:\nwant: %v,\ngot: %v.", test.komi, field.State().Komi)
+			}
+		})
+	}
+}
+
 func TestMove(t *testing.T) {
 	var field igame.Master
 	field, err := New(usualSize, defaultKomi)
@@ -237,3 +273,329 @@ func TestNoBlackChips(t *testing.T) {
 		}
 	}
 }
+
+func TestLastMove(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	if state := field.State(); state.LastMove != nil {
+		t.Errorf("Unexpected LastMove on empty field:\nwant: nil,\ngot: %v.", state.LastMove)
+	}
+
+	if err := field.Move(igame.Black, &igame.TurnData{X: 3, Y: 4}); err != nil {
+		t.Fatalf("Unexpected Move() error: %v", err)
+	}
+	want := igame.Move{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 3, Y: 4}}
+	if state := field.State(); state.LastMove == nil || *state.LastMove != want {
+		t.Errorf("Unexpected LastMove after Move():\nwant: %v,\ngot: %v.", want, state.LastMove)
+	}
+
+	if err := field.Pass(igame.White); err != nil {
+		t.Fatalf("Unexpected Pass() error: %v", err)
+	}
+	want = igame.Move{Colour: igame.White, Kind: igame.PassMove}
+	if state := field.State(); state.LastMove == nil || *state.LastMove != want {
+		t.Errorf("Unexpected LastMove after Pass():\nwant: %v,\ngot: %v.", want, state.LastMove)
+	}
+
+	wantErr := ErrColour
+	if err := field.Pass(igame.NoColour); !errors.Is(err, wantErr) {
+		t.Errorf("Unexpected Pass() err:\nwant: %v,\ngot: %v.", wantErr, err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	want := []igame.Move{
+		{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 3, Y: 3}},
+		{Colour: igame.White, Kind: igame.PassMove},
+		{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 5, Y: 5}},
+	}
+	for _, m := range want {
+		td := m.Position
+		switch m.Kind {
+		case igame.PlaceMove:
+			err = field.Move(m.Colour, &td)
+		case igame.PassMove:
+			err = field.Pass(m.Colour)
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error on move %v: %v", m, err)
+		}
+	}
+	// rejected moves should not be recorded.
+	_ = field.Move(igame.White, &igame.TurnData{X: 3, Y: 3})
+
+	history := field.History()
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("Unexpected History():\nwant: %v,\ngot: %v.", want, history)
+	}
+
+	history[0].Colour = igame.White
+	if field.History()[0].Colour != igame.Black {
+		t.Errorf("Unexpected History() aliasing: internal history changed by caller")
+	}
+}
+
+var setupTests = []struct {
+	name       string
+	placements []igame.Placement
+	want       error
+}{
+	{
+		name:       "no colour",
+		placements: []igame.Placement{{Colour: igame.NoColour, Position: igame.TurnData{X: 1, Y: 1}}},
+		want:       ErrColour,
+	},
+	{
+		name:       "out of range",
+		placements: []igame.Placement{{Colour: igame.Black, Position: igame.TurnData{X: 0, Y: 1}}},
+		want:       ErrPosition,
+	},
+	{
+		name: "same position",
+		placements: []igame.Placement{
+			{Colour: igame.Black, Position: igame.TurnData{X: 1, Y: 1}},
+			{Colour: igame.White, Position: igame.TurnData{X: 1, Y: 1}},
+		},
+		want: ErrOccupied,
+	},
+	{
+		name: "ok",
+		placements: []igame.Placement{
+			{Colour: igame.Black, Position: igame.TurnData{X: 3, Y: 3}},
+			{Colour: igame.Black, Position: igame.TurnData{X: 7, Y: 7}},
+			{Colour: igame.White, Position: igame.TurnData{X: 5, Y: 5}},
+		},
+		want: nil,
+	},
+	{
+		name:       "occupied",
+		placements: []igame.Placement{{Colour: igame.White, Position: igame.TurnData{X: 3, Y: 3}}},
+		want:       ErrOccupied,
+	},
+}
+
+func TestSetup(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	for _, test := range setupTests {
+		t.Run(test.name, func(t *testing.T) {
+			before := field.State()
+			err := field.Setup(test.placements)
+			if !errors.Is(err, test.want) {
+				t.Errorf("Unexpected Setup() err:\nwant: %v,\ngot: %v.", test.want, err)
+			}
+			if err != nil && !reflect.DeepEqual(before, field.State()) {
+				t.Errorf("Unexpected State() change on failed Setup():\nwant: %v,\ngot: %v.", before, field.State())
+			}
+		})
+	}
+
+	state := field.State()
+	if len(state.ChipsOnBoard[igame.Black]) != 2 || state.ChipsInCup[igame.Black] != maxBlack-2 {
+		t.Errorf("Unexpected black chips after Setup(): on board %d, in cup %d.",
+			len(state.ChipsOnBoard[igame.Black]), state.ChipsInCup[igame.Black])
+	}
+	if len(field.History()) != 0 || len(field.Placements()) != 3 {
+		t.Errorf("Unexpected Setup() records:\nwant: 0 moves and 3 placements,\ngot: %d moves and %d placements.",
+			len(field.History()), len(field.Placements()))
+	}
+
+	if err := field.Pass(igame.Black); err != nil {
+		t.Fatalf("Unexpected Pass() error: %v", err)
+	}
+	want := ErrSetup
+	if err := field.Setup(setupTests[len(setupTests)-2].placements); !errors.Is(err, want) {
+		t.Errorf("Unexpected Setup() err after first move:\nwant: %v,\ngot: %v.", want, err)
+	}
+	if err := field.Undo(); err != nil || len(field.Placements()) != 3 {
+		t.Errorf("Unexpected Undo() result: err %v, placements %v.", err, field.Placements())
+	}
+}
+
+func TestStateIndependence(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape)
+
+	want := field.State()
+	got := field.State()
+	got.ChipsOnBoard[igame.Black][0].X = 9
+	got.ChipsOnBoard[igame.White] = got.ChipsOnBoard[igame.White][:1]
+	got.KoPoint.Y = 9
+	got.LastMove.Colour = igame.Black
+	if !reflect.DeepEqual(want, field.State()) {
+		t.Errorf("Unexpected State() change by modification of the previous state:\nwant: %v,\ngot: %v.", want, field.State())
+	}
+
+	before := field.State()
+	play(t, field, []placement{{colour: igame.Black, td: igame.TurnData{X: 9, Y: 9}}})
+	if !reflect.DeepEqual(want, before) {
+		t.Errorf("Unexpected retained state change by Move():\nwant: %v,\ngot: %v.", want, before)
+	}
+}
+
+func TestTwoPasses(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	if err := field.Pass(igame.Black); err != nil {
+		t.Fatalf("Unexpected Pass() error: %v", err)
+	}
+	if state := field.State(); state.GameOver || state.Termination != igame.NotTerminated {
+		t.Errorf("Unexpected game over after one pass: %v", state.Termination)
+	}
+	if err := field.Pass(igame.White); err != nil {
+		t.Fatalf("Unexpected Pass() error: %v", err)
+	}
+	if state := field.State(); !state.GameOver || state.Termination != igame.TwoPasses {
+		t.Errorf("Unexpected Termination after two passes:\nwant: %v,\ngot: %v.", igame.TwoPasses, state.Termination)
+	}
+	if result := field.State().Result; result == nil || result.Method != igame.ScoreMethod || result.Winner != igame.NoColour {
+		t.Errorf("Unexpected Result after two passes on empty field:\nwant: jigo by score,\ngot: %v.", result)
+	}
+
+	want := ErrGameOver
+	if err := field.Move(igame.Black, &igame.TurnData{X: 1, Y: 1}); !errors.Is(err, want) {
+		t.Errorf("Unexpected Move() err after two passes:\nwant: %v,\ngot: %v.", want, err)
+	}
+
+	if err := field.Undo(); err != nil {
+		t.Fatalf("Unexpected Undo() error: %v", err)
+	}
+	if state := field.State(); state.GameOver {
+		t.Errorf("Unexpected game over after Undo() of the second pass")
+	}
+}
+
+func TestResign(t *testing.T) {
+	var field igame.Master
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	if err := field.Move(igame.Black, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected Move() error: %v", err)
+	}
+	if err := field.Resign(igame.White); err != nil {
+		t.Fatalf("Unexpected Resign() error: %v", err)
+	}
+
+	state := field.State()
+	if !state.GameOver || state.Termination != igame.Resignation {
+		t.Errorf("Unexpected Termination after resignation:\nwant: %v,\ngot: %v.", igame.Resignation, state.Termination)
+	}
+	want := &igame.Result{Winner: igame.Black, Method: igame.ResignMethod}
+	if !reflect.DeepEqual(state.Result, want) {
+		t.Errorf("Unexpected Result after resignation:\nwant: %v,\ngot: %v.", want, state.Result)
+	}
+
+	if err := field.Resign(igame.Black); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Unexpected Resign() err after resignation:\nwant: %v,\ngot: %v.", ErrGameOver, err)
+	}
+}
+
+func TestNoLegalMoves(t *testing.T) {
+	field, err := New(2, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	play(t, field, []placement{{colour: igame.Black, td: igame.TurnData{X: 1, Y: 1}}})
+	if err := field.Pass(igame.White); err != nil {
+		t.Fatalf("Unexpected Pass() error: %v", err)
+	}
+	play(t, field, []placement{{colour: igame.Black, td: igame.TurnData{X: 2, Y: 2}}})
+
+	if state := field.State(); !state.GameOver || state.Termination != igame.NoLegalMoves {
+		t.Errorf("Unexpected Termination:\nwant: %v,\ngot: %v.", igame.NoLegalMoves, state.Termination)
+	}
+}
+
+// TestNoLegalMovesHandicap checks that the colour to move is set by SetFirst, not guessed by setup chips.
+func TestNoLegalMovesHandicap(t *testing.T) {
+	field, err := New(2, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	// black has no legal moves, but white can capture all black chips.
+	handicap := []igame.Placement{
+		{Colour: igame.Black, Position: igame.TurnData{X: 1, Y: 1}},
+		{Colour: igame.Black, Position: igame.TurnData{X: 1, Y: 2}},
+		{Colour: igame.Black, Position: igame.TurnData{X: 2, Y: 1}},
+	}
+	if err := field.Setup(handicap); err != nil {
+		t.Fatalf("Unexpected Setup() error: %v", err)
+	}
+	if state := field.State(); state.Termination != igame.NoLegalMoves {
+		t.Errorf("Unexpected Termination of black to move:\nwant: %v,\ngot: %v.", igame.NoLegalMoves, state.Termination)
+	}
+	if err := field.SetFirst(igame.NoColour); !errors.Is(err, ErrColour) {
+		t.Errorf("Unexpected SetFirst() error:\nwant: %v,\ngot: %v.", ErrColour, err)
+	}
+	if err := field.SetFirst(igame.White); err != nil {
+		t.Fatalf("Unexpected SetFirst() error: %v", err)
+	}
+	if state := field.State(); state.GameOver {
+		t.Fatalf("Unexpected game over after handicap: %v", state.Termination)
+	}
+	play(t, field, []placement{{colour: igame.White, td: igame.TurnData{X: 2, Y: 2}}})
+	if state := field.State(); len(state.ChipsOnBoard[igame.Black]) != 0 {
+		t.Errorf("Unexpected black chips after capture: %v", state.ChipsOnBoard[igame.Black])
+	}
+	if err := field.SetFirst(igame.Black); !errors.Is(err, ErrSetup) {
+		t.Errorf("Unexpected SetFirst() error after the move:\nwant: %v,\ngot: %v.", ErrSetup, err)
+	}
+}
+
+func TestNoChipsLeftTermination(t *testing.T) {
+	field, err := New(maxSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	for i := 0; i < maxWhite; i++ {
+		td := &igame.TurnData{X: i%maxSize + 1, Y: i/maxSize + 1}
+		if err := field.Move(igame.White, td); err != nil {
+			t.Fatalf("Unexpected Move() error: %v", err)
+		}
+	}
+	if state := field.State(); state.Termination != igame.NoChipsLeft {
+		t.Errorf("Unexpected Termination:\nwant: %v,\ngot: %v.", igame.NoChipsLeft, state.Termination)
+	}
+}
+
+func TestSetKomi(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape[:1])
+
+	if err := field.SetKomi(6.5); err != nil || field.State().Komi != 6.5 {
+		t.Errorf("Unexpected SetKomi() result:\nwant: komi 6.5,\ngot: komi %v, err %v.", field.State().Komi, err)
+	}
+	if err := field.SetKomi(200); !errors.Is(err, ErrKomi) || field.State().Komi != 6.5 {
+		t.Errorf("Unexpected SetKomi() result on wrong komi:\nwant: %v and komi 6.5,\ngot: %v and komi %v.", ErrKomi, err, field.State().Komi)
+	}
+
+	play(t, field, koShape[1:2])
+	if err := field.SetKomi(7.5); !errors.Is(err, ErrKomiFixed) {
+		t.Errorf("Unexpected SetKomi() err after the second move:\nwant: %v,\ngot: %v.", ErrKomiFixed, err)
+	}
+}
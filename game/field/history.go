@@ -0,0 +1,90 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// record holds a move and data needed to revert it
+type record struct {
+	move     igame.Move
+	captured []igame.TurnData // positions of chips captured by the move
+	suicided []igame.TurnData // positions of own chips removed by suicide
+	koPoint  *igame.TurnData  // ko point before the move
+}
+
+// clone returns a deep copy of the record
+func (rec record) clone() record {
+	return record{
+		move:     rec.move,
+		captured: copyPositions(rec.captured),
+		suicided: copyPositions(rec.suicided),
+		koPoint:  copyTurnData(rec.koPoint),
+	}
+}
+
+// copyPositions returns a copy of positions, nil stays nil
+func copyPositions(positions []igame.TurnData) []igame.TurnData {
+	if positions == nil {
+		return nil
+	}
+	positionsCpy := make([]igame.TurnData, len(positions))
+	copy(positionsCpy, positions)
+	return positionsCpy
+}
+
+// History returns all moves made on the field in order they were made
+func (field *Field) History() []igame.Move {
+	history := make([]igame.Move, len(field.history))
+	for i := range field.history {
+		history[i] = field.history[i].move
+	}
+	return history
+}
+
+// Placements returns chips put on the field by Setup
+func (field *Field) Placements() []igame.Placement {
+	placements := make([]igame.Placement, len(field.setup))
+	copy(placements, field.setup)
+	return placements
+}
+
+// Undo reverts the last move made on the field
+func (field *Field) Undo() error {
+	if len(field.history) == 0 {
+		return ErrNoMoves
+	}
+
+	last := field.history[len(field.history)-1]
+	field.history = field.history[:len(field.history)-1]
+
+	if last.move.Kind == igame.PlaceMove {
+		for _, td := range last.suicided {
+			field.set(td, last.move.Colour)
+		}
+		field.captured[last.move.Colour] = field.captured[last.move.Colour] - len(last.suicided)
+		field.set(last.move.Position, igame.NoColour)
+		field.chipsNumber[last.move.Colour] = field.chipsNumber[last.move.Colour] + 1
+
+		for _, td := range last.captured {
+			field.set(td, opponent(last.move.Colour))
+		}
+		field.captured[opponent(last.move.Colour)] = field.captured[opponent(last.move.Colour)] - len(last.captured)
+	}
+	field.koPoint = last.koPoint
+	field.updateTermination()
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestUndo(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	want := ErrNoMoves
+	if err := field.Undo(); !errors.Is(err, want) {
+		t.Errorf("Unexpected Undo() err on empty field:\nwant: %v,\ngot: %v.", want, err)
+	}
+
+	play(t, field, koShape[:len(koShape)-1])
+	before := field.State()
+	play(t, field, koShape[len(koShape)-1:])
+
+	if err := field.Undo(); err != nil {
+		t.Fatalf("Unexpected Undo() err: %v", err)
+	}
+	if after := field.State(); !reflect.DeepEqual(before, after) {
+		t.Errorf("Unexpected State() after Undo() of capture:\nwant: %v,\ngot: %v.", before, after)
+	}
+
+	play(t, field, koShape[len(koShape)-1:])
+	if err := field.Pass(igame.Black); err != nil {
+		t.Fatalf("Unexpected Pass() err: %v", err)
+	}
+	if err := field.Undo(); err != nil {
+		t.Fatalf("Unexpected Undo() err: %v", err)
+	}
+	wantKo := igame.TurnData{X: 3, Y: 2}
+	if state := field.State(); state.KoPoint == nil || *state.KoPoint != wantKo {
+		t.Errorf("Unexpected KoPoint after Undo() of pass:\nwant: %v,\ngot: %v.", wantKo, state.KoPoint)
+	}
+
+	for range koShape {
+		if err := field.Undo(); err != nil {
+			t.Fatalf("Unexpected Undo() err: %v", err)
+		}
+	}
+	empty, _ := New(usualSize, defaultKomi)
+	if !reflect.DeepEqual(field.State(), empty.State()) {
+		t.Errorf("Unexpected State() after Undo() of all moves:\nwant: %v,\ngot: %v.", empty.State(), field.State())
+	}
+}
+
+func TestClone(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape)
+
+	clone := field.Clone()
+	if !reflect.DeepEqual(field.State(), clone.State()) || !reflect.DeepEqual(field.History(), clone.History()) {
+		t.Fatalf("Unexpected Clone() result:\nwant: %v,\ngot: %v.", field.State(), clone.State())
+	}
+
+	before := field.State()
+	if err := clone.Move(igame.Black, &igame.TurnData{X: 5, Y: 5}); err != nil {
+		t.Fatalf("Unexpected Move() err on clone: %v", err)
+	}
+	for range koShape {
+		if err := clone.Undo(); err != nil {
+			t.Fatalf("Unexpected Undo() err on clone: %v", err)
+		}
+	}
+	if after := field.State(); !reflect.DeepEqual(before, after) {
+		t.Errorf("Unexpected change of original field by clone:\nwant: %v,\ngot: %v.", before, after)
+	}
+	if len(field.History()) != len(koShape) {
+		t.Errorf("Unexpected History() length of original field:\nwant: %d,\ngot: %d.", len(koShape), len(field.History()))
+	}
+}
+
+// TestUndoTermination checks that Undo of the move ending the game resumes it.
+func TestUndoTermination(t *testing.T) {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	for _, colour := range []igame.ChipColour{igame.Black, igame.White} {
+		if err := field.Pass(colour); err != nil {
+			t.Fatalf("Unexpected Pass() error: %v", err)
+		}
+	}
+	if state := field.Clone().State(); state.Termination != igame.TwoPasses {
+		t.Errorf("Unexpected Termination of the clone:\nwant: %v,\ngot: %v.", igame.TwoPasses, state.Termination)
+	}
+
+	if err := field.Undo(); err != nil {
+		t.Fatalf("Unexpected Undo() error: %v", err)
+	}
+	if state := field.State(); state.GameOver || state.Termination != igame.NotTerminated {
+		t.Errorf("Unexpected Termination after Undo():\nwant: %v,\ngot: %v.", igame.NotTerminated, state.Termination)
+	}
+	if err := field.Pass(igame.White); err != nil {
+		t.Errorf("Unexpected Pass() error after Undo(): %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// Option configures the Field on creation
+type Option func(field *Field)
+
+// WithRuleset sets the ruleset of the Field, Japanese rules are used by default
+func WithRuleset(ruleset igame.Ruleset) Option {
+	return func(field *Field) {
+		field.ruleset = ruleset
+	}
+}
+
+// WithHalfPointKomi makes New accept only komi which is a multiple of 0.5
+func WithHalfPointKomi() Option {
+	return func(field *Field) {
+		field.halfKomi = true
+	}
+}
+
+// WithCaptureGo turns the Field to capture go (atari go) teaching variant:
+// the game ends as soon as any chip is captured and the capturer wins
+func WithCaptureGo() Option {
+	return func(field *Field) {
+		field.captureGo = true
+	}
+}
+
+// WithJigoWinner breaks ties of FinalScore: the gamer playing by colour
+// wins the game with equal scores instead of the draw
+func WithJigoWinner(colour igame.ChipColour) Option {
+	return func(field *Field) {
+		field.jigoWinner = colour
+	}
+}
+
+// WithStateFilter adds filter applied to the state shown to gamers by StateFor
+func WithStateFilter(filter StateFilter) Option {
+	return func(field *Field) {
+		field.filters = append(field.filters, filter)
+	}
+}
+
+// WithBlind turns the Field to blind go: StateFor hides chips of the opponent
+// from the gamer, or all chips if all is true, while the rules see the whole field.
+// Spectators watching by NoColour and the finished game see all chips
+func WithBlind(all bool) Option {
+	return WithStateFilter(blind(all))
+}
+
+// WithOneColour turns the Field to one-colour go: StateFor shows all chips on board
+// as chips of NoColour, while true colours are kept for the rules
+func WithOneColour() Option {
+	return WithStateFilter(oneColour)
+}
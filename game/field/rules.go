@@ -0,0 +1,40 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// rules holds properties of a ruleset which affect the Field
+type rules struct {
+	area            bool // area scoring instead of territory scoring
+	passStones      bool // a pass gives a prisoner to the opponent
+	whitePassesLast bool // two passes end the game only if white passed last
+	suicide         bool // suicide of chains is allowed
+}
+
+// presets of supported rulesets
+var presets = map[igame.Ruleset]rules{
+	igame.JapaneseRules:   {},
+	igame.ChineseRules:    {area: true},
+	igame.AGARules:        {passStones: true, whitePassesLast: true},
+	igame.NewZealandRules: {area: true, suicide: true},
+}
+
+// rules returns properties of the ruleset of the field
+func (field *Field) rules() rules {
+	return presets[field.ruleset]
+}
@@ -0,0 +1,176 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+var rulesetScoreTests = []struct {
+	name    string
+	ruleset igame.Ruleset
+	winner  igame.ChipColour
+	scores  map[igame.ChipColour]float64
+}{
+	{
+		name:    "japanese",
+		ruleset: igame.JapaneseRules,
+		winner:  igame.Black,
+		scores:  map[igame.ChipColour]float64{igame.Black: 19, igame.White: 18},
+	},
+	{
+		name:    "aga pass stones",
+		ruleset: igame.AGARules,
+		winner:  igame.NoColour,
+		scores:  map[igame.ChipColour]float64{igame.Black: 19, igame.White: 19},
+	},
+	{
+		name:    "chinese",
+		ruleset: igame.ChineseRules,
+		winner:  igame.NoColour,
+		scores:  map[igame.ChipColour]float64{igame.Black: 27, igame.White: 27},
+	},
+	{
+		name:    "new zealand",
+		ruleset: igame.NewZealandRules,
+		winner:  igame.NoColour,
+		scores:  map[igame.ChipColour]float64{igame.Black: 27, igame.White: 27},
+	},
+}
+
+func TestRulesetScores(t *testing.T) {
+	for _, test := range rulesetScoreTests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := New(usualSize, defaultKomi, WithRuleset(test.ruleset))
+			if err != nil {
+				t.Fatalf("Unexpected New() error: %v", err)
+			}
+			play(t, field, walls(3, 7))
+			play(t, field, []placement{{colour: igame.White, td: igame.TurnData{X: 1, Y: 5}}})
+			if err := field.Pass(igame.Black); err != nil {
+				t.Fatalf("Unexpected Pass() err: %v", err)
+			}
+
+			result, err := field.FinalScore([]igame.TurnData{{X: 1, Y: 5}})
+			if err != nil {
+				t.Fatalf("Unexpected FinalScore() err: %v", err)
+			}
+			if result.Winner != test.winner || !reflect.DeepEqual(result.Scores, test.scores) {
+				t.Errorf("Unexpected FinalScore() result:\nwant: winner %v, scores %v,\ngot: winner %v, scores %v.",
+					test.winner, test.scores, result.Winner, result.Scores)
+			}
+		})
+	}
+}
+
+func TestWhitePassesLast(t *testing.T) {
+	field, err := New(usualSize, defaultKomi, WithRuleset(igame.AGARules))
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+
+	for _, colour := range []igame.ChipColour{igame.White, igame.Black} {
+		if err := field.Pass(colour); err != nil {
+			t.Fatalf("Unexpected Pass() err: %v", err)
+		}
+	}
+	state := field.State()
+	if state.GameOver {
+		t.Errorf("Unexpected game over after black passed last")
+	}
+	if state.Prisoners[igame.Black] != 1 || state.Prisoners[igame.White] != 1 {
+		t.Errorf("Unexpected pass stones in prisoners:\nwant: black: 1, white: 1,\ngot: %v.", state.Prisoners)
+	}
+
+	if err := field.Pass(igame.White); err != nil {
+		t.Fatalf("Unexpected Pass() err: %v", err)
+	}
+	if state := field.State(); state.Termination != igame.TwoPasses {
+		t.Errorf("Unexpected Termination after white passed last:\nwant: %v,\ngot: %v.", igame.TwoPasses, state.Termination)
+	}
+}
+
+func TestSuicideAllowed(t *testing.T) {
+	position := []placement{
+		{colour: igame.White, td: igame.TurnData{X: 1, Y: 1}},
+		{colour: igame.Black, td: igame.TurnData{X: 3, Y: 1}},
+		{colour: igame.Black, td: igame.TurnData{X: 1, Y: 2}},
+		{colour: igame.Black, td: igame.TurnData{X: 2, Y: 2}},
+	}
+	suicide := igame.TurnData{X: 2, Y: 1}
+
+	japanese, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, japanese, position)
+	if err := japanese.Move(igame.White, &suicide); !errors.Is(err, ErrSuicide) {
+		t.Errorf("Unexpected Move() err under japanese rules:\nwant: %v,\ngot: %v.", ErrSuicide, err)
+	}
+
+	field, err := New(usualSize, defaultKomi, WithRuleset(igame.NewZealandRules))
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, position)
+	before := field.State()
+	play(t, field, []placement{{colour: igame.White, td: suicide}})
+
+	state := field.State()
+	if len(state.ChipsOnBoard[igame.White]) != 0 || state.ChipsCuptured[igame.White] != 2 {
+		t.Errorf("Unexpected white chips after suicide:\nwant: 0 on board, 2 captured,\ngot: %d on board, %d captured.",
+			len(state.ChipsOnBoard[igame.White]), state.ChipsCuptured[igame.White])
+	}
+
+	if err := field.Undo(); err != nil {
+		t.Fatalf("Unexpected Undo() err: %v", err)
+	}
+	if !reflect.DeepEqual(before, field.State()) {
+		t.Errorf("Unexpected state after Undo() of suicide:\nwant: %v,\ngot: %v.", before, field.State())
+	}
+}
+
+func TestCaptureGo(t *testing.T) {
+	field, err := New(usualSize, defaultKomi, WithCaptureGo())
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape[:len(koShape)-1])
+	if state := field.State(); state.GameOver {
+		t.Fatalf("Unexpected game over before capture: %v", state.Termination)
+	}
+	play(t, field, koShape[len(koShape)-1:])
+
+	state := field.State()
+	want := &igame.Result{Winner: igame.White, Method: igame.CaptureMethod}
+	if state.Termination != igame.FirstCapture || !reflect.DeepEqual(state.Result, want) {
+		t.Errorf("Unexpected end of capture go:\nwant: %v with %v,\ngot: %v with %v.",
+			igame.FirstCapture, want, state.Termination, state.Result)
+	}
+	if err := field.Move(igame.Black, &igame.TurnData{X: 9, Y: 9}); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Unexpected Move() err after capture:\nwant: %v,\ngot: %v.", ErrGameOver, err)
+	}
+	if got, want := field.SGF(), "RE[W+]"; !strings.Contains(got, want) {
+		t.Errorf("Unexpected SGF() result of capture go:\nwant: %s in,\ngot: %s.", want, got)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import (
+	"sync"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Safe wraps the Field to be used from multiple goroutines.
+// It implements igame.Master. Event handlers are called with the lock held,
+// so they must not call methods of the Safe.
+type Safe struct {
+	mu    sync.RWMutex
+	field *Field
+}
+
+// NewSafe generate concurrency safe Field with demensions of size x size
+func NewSafe(size int, komi float64, opts ...Option) (*Safe, error) {
+	field, err := New(size, komi, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Safe{field: field}, nil
+}
+
+// Size returns the size of the field
+func (safe *Safe) Size() int {
+	return safe.field.Size()
+}
+
+// Move performs move with attempt to put chip of colour to position td
+func (safe *Safe) Move(colour igame.ChipColour, td *igame.TurnData) error {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	return safe.field.Move(colour, td)
+}
+
+// CheckMove performs all checks of the move of colour to position td
+// without changing the field
+func (safe *Safe) CheckMove(colour igame.ChipColour, td *igame.TurnData) error {
+	safe.mu.RLock()
+	defer safe.mu.RUnlock()
+	return safe.field.CheckMove(colour, td)
+}
+
+// Pass performs pass of the gamer playing by colour
+func (safe *Safe) Pass(colour igame.ChipColour) error {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	return safe.field.Pass(colour)
+}
+
+// Resign performs resignation of the gamer playing by colour
+func (safe *Safe) Resign(colour igame.ChipColour) error {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	return safe.field.Resign(colour)
+}
+
+// SetKomi changes komi of the field while it's negotiated
+func (safe *Safe) SetKomi(komi float64) error {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	return safe.field.SetKomi(komi)
+}
+
+// Undo reverts the last move made on the field
+func (safe *Safe) Undo() error {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	return safe.field.Undo()
+}
+
+// State calculate full state description
+func (safe *Safe) State() *igame.FieldState {
+	safe.mu.RLock()
+	defer safe.mu.RUnlock()
+	return safe.field.State()
+}
+
+// StateFor calculate the state shown to the gamer playing by viewer
+func (safe *Safe) StateFor(viewer igame.ChipColour) *igame.FieldState {
+	safe.mu.RLock()
+	defer safe.mu.RUnlock()
+	return safe.field.StateFor(viewer)
+}
+
+// History returns all moves made on the field in order they were made
+func (safe *Safe) History() []igame.Move {
+	safe.mu.RLock()
+	defer safe.mu.RUnlock()
+	return safe.field.History()
+}
+
+// Estimate returns an approximate evaluation of the game in progress
+func (safe *Safe) Estimate() *igame.Estimate {
+	safe.mu.RLock()
+	defer safe.mu.RUnlock()
+	return safe.field.Estimate()
+}
+
+// FinalScore returns the result of the game with chains of deadGroups removed
+func (safe *Safe) FinalScore(deadGroups []igame.TurnData) (*igame.Result, error) {
+	safe.mu.RLock()
+	defer safe.mu.RUnlock()
+	return safe.field.FinalScore(deadGroups)
+}
+
+// OnCapture registers handler to be called on every capture
+func (safe *Safe) OnCapture(handler CaptureHandler) {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	safe.field.OnCapture(handler)
+}
+
+// OnGameOver registers handler to be called when the game ends
+func (safe *Safe) OnGameOver(handler GameOverHandler) {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	safe.field.OnGameOver(handler)
+}
+
+// Field returns an independent copy of the wrapped field
+func (safe *Safe) Field() *Field {
+	safe.mu.RLock()
+	defer safe.mu.RUnlock()
+	return safe.field.Clone()
+}
+
+// String provides compatibility with Stringer interface
+func (safe *Safe) String() string {
+	safe.mu.RLock()
+	defer safe.mu.RUnlock()
+	return safe.field.String()
+}
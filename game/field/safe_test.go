@@ -0,0 +1,69 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestSafe(t *testing.T) {
+	var field igame.Master
+	field, err := NewSafe(maxSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected NewSafe() error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for x := 1; x <= maxSize; x++ {
+		wg.Add(2)
+		go func(x int) {
+			defer wg.Done()
+			colour := igame.ChipColour(igame.Black)
+			if x%2 == 0 {
+				colour = igame.White
+			}
+			for y := 1; y <= maxSize; y += 2 {
+				if err := field.Move(colour, &igame.TurnData{X: x, Y: y}); err != nil {
+					t.Errorf("Unexpected Move() error: %v", err)
+				}
+			}
+		}(x)
+		go func() {
+			defer wg.Done()
+			_ = field.State()
+		}()
+	}
+	wg.Wait()
+
+	state := field.State()
+	want := maxSize * (maxSize + 1) / 2
+	if got := len(state.ChipsOnBoard[igame.Black]) + len(state.ChipsOnBoard[igame.White]); got != want {
+		t.Errorf("Unexpected number of chips on board:\nwant: %d,\ngot: %d.", want, got)
+	}
+}
+
+func TestNewSafe(t *testing.T) {
+	want := ErrFieldSize
+	if _, err := NewSafe(maxSize+1, defaultKomi); !errors.Is(err, want) {
+		t.Errorf("Unexpected NewSafe() err:\nwant: %v,\ngot: %v.", want, err)
+	}
+}
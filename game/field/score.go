@@ -0,0 +1,227 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import (
+	"fmt"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+const (
+	// influenceRadius is the maximal distance of influence of a chip
+	influenceRadius = 4
+	// influenceThreshold is the minimal influence of a colour
+	// to consider the empty point as it's territory
+	influenceThreshold = 2
+)
+
+// Estimate returns an approximate evaluation of the game
+// based on influence of chips on empty points.
+// Chips are not checked to be alive, so the estimation is rough mid-game.
+func (field *Field) Estimate() *igame.Estimate {
+	estimate := &igame.Estimate{
+		Territory: map[igame.ChipColour][]*igame.TurnData{
+			igame.Black: make([]*igame.TurnData, 0),
+			igame.White: make([]*igame.TurnData, 0),
+		},
+		Scores: make(map[igame.ChipColour]float64, 2),
+	}
+
+	influence := field.influence()
+	for x := 1; x <= field.size; x++ {
+		for y := 1; y <= field.size; y++ {
+			td := igame.TurnData{X: x, Y: y}
+			if field.at(td) != igame.NoColour {
+				continue
+			}
+			switch v := influence[y-1][x-1]; {
+			case v >= influenceThreshold:
+				estimate.Territory[igame.Black] = append(estimate.Territory[igame.Black], &td)
+			case v <= -influenceThreshold:
+				estimate.Territory[igame.White] = append(estimate.Territory[igame.White], &td)
+			}
+		}
+	}
+
+	for _, colour := range []igame.ChipColour{igame.Black, igame.White} {
+		estimate.Scores[colour] = float64(len(field.chips[colour]) + len(estimate.Territory[colour]))
+	}
+	estimate.Scores[igame.White] = estimate.Scores[igame.White] + field.komi
+
+	switch diff := estimate.Scores[igame.Black] - estimate.Scores[igame.White]; {
+	case diff > 0:
+		estimate.Leader, estimate.Margin = igame.Black, diff
+	case diff < 0:
+		estimate.Leader, estimate.Margin = igame.White, -diff
+	}
+	return estimate
+}
+
+// influence returns the sum of influence of all chips for each point of the field.
+// Black chips have positive influence, white ones - negative.
+func (field *Field) influence() [][]int {
+	influence := make([][]int, field.size)
+	for i := range influence {
+		influence[i] = make([]int, field.size)
+	}
+
+	for colour, sign := range map[igame.ChipColour]int{igame.Black: 1, igame.White: -1} {
+		for _, chip := range field.chips[colour] {
+			for dx := -influenceRadius; dx <= influenceRadius; dx++ {
+				for dy := -influenceRadius; dy <= influenceRadius; dy++ {
+					distance := abs(dx) + abs(dy)
+					x, y := chip.X+dx, chip.Y+dy
+					if distance > influenceRadius || x < 1 || y < 1 || x > field.size || y > field.size {
+						continue
+					}
+					influence[y-1][x-1] += sign * (influenceRadius + 1 - distance)
+				}
+			}
+		}
+	}
+	return influence
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// FinalScore removes chains of dead chips containing positions of deadGroups,
+// counts territory and prisoners according to the ruleset and returns the result.
+// Dead chips are removed on a copy, so the field itself is not changed.
+func (field *Field) FinalScore(deadGroups []igame.TurnData) (*igame.Result, error) {
+	scoring := field
+	if len(deadGroups) > 0 {
+		scoring = field.Clone()
+	}
+	prisoners := map[igame.ChipColour]int{
+		igame.Black: field.prisoners(igame.Black),
+		igame.White: field.prisoners(igame.White),
+	}
+
+	for _, td := range deadGroups {
+		if td.X < 1 || td.Y < 1 || td.X > field.size || td.Y > field.size {
+			return nil, fmt.Errorf("%w: got dead chip position: %v", ErrPosition, td)
+		}
+		colour := scoring.at(td)
+		if colour == igame.NoColour {
+			if field.at(td) != igame.NoColour {
+				// the chain is already removed by other position of it.
+				continue
+			}
+			return nil, fmt.Errorf("%w: got dead chip position: %v", ErrNoChip, td)
+		}
+
+		stones, _ := scoring.group(td)
+		for _, s := range stones {
+			scoring.set(s, igame.NoColour)
+		}
+		prisoners[opponent(colour)] += len(stones)
+	}
+
+	territory := scoring.territory()
+	result := &igame.Result{Method: igame.ScoreMethod, Scores: make(map[igame.ChipColour]float64, 2)}
+	for _, colour := range []igame.ChipColour{igame.Black, igame.White} {
+		if field.rules().area {
+			result.Scores[colour] = float64(len(scoring.chips[colour]) + len(territory[colour]))
+		} else {
+			result.Scores[colour] = float64(prisoners[colour] + len(territory[colour]))
+		}
+	}
+	result.Scores[igame.White] = result.Scores[igame.White] + field.komi
+
+	switch diff := result.Scores[igame.Black] - result.Scores[igame.White]; {
+	case diff > 0:
+		result.Winner, result.Margin = igame.Black, diff
+	case diff < 0:
+		result.Winner, result.Margin = igame.White, -diff
+	default:
+		result.Winner, result.Jigo = field.jigoWinner, true
+	}
+	return result, nil
+}
+
+// prisoners returns the number of chips taken by the gamer playing by colour,
+// pass stones of the opponent included if the ruleset uses them
+func (field *Field) prisoners(colour igame.ChipColour) int {
+	number := field.captured[opponent(colour)]
+	if field.rules().passStones {
+		for _, rec := range field.history {
+			if rec.move.Kind == igame.PassMove && rec.move.Colour == opponent(colour) {
+				number++
+			}
+		}
+	}
+	return number
+}
+
+// territory returns empty points surrounded by chips of only one colour
+func (field *Field) territory() map[igame.ChipColour][]igame.TurnData {
+	territory := map[igame.ChipColour][]igame.TurnData{
+		igame.Black: make([]igame.TurnData, 0),
+		igame.White: make([]igame.TurnData, 0),
+	}
+	visited := make([]bool, field.size*field.size)
+
+	for x := 1; x <= field.size; x++ {
+		for y := 1; y <= field.size; y++ {
+			td := igame.TurnData{X: x, Y: y}
+			if field.at(td) != igame.NoColour || visited[field.index(td)] {
+				continue
+			}
+
+			region, borders := field.region(td, visited)
+			if len(borders) == 1 {
+				for colour := range borders {
+					territory[colour] = append(territory[colour], region...)
+				}
+			}
+		}
+	}
+	return territory
+}
+
+// region returns the empty region containing td
+// and the set of colours of chips bordering it
+func (field *Field) region(td igame.TurnData, visited []bool) ([]igame.TurnData, map[igame.ChipColour]bool) {
+	region := make([]igame.TurnData, 0)
+	borders := make(map[igame.ChipColour]bool, 2)
+	visited[field.index(td)] = true
+	stack := []igame.TurnData{td}
+
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		region = append(region, cur)
+
+		for _, n := range field.neighbours(cur) {
+			if colour := field.at(n); colour != igame.NoColour {
+				borders[colour] = true
+				continue
+			}
+			if !visited[field.index(n)] {
+				visited[field.index(n)] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+	return region, borders
+}
@@ -0,0 +1,194 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// walls returns moves building a black wall on column bx
+// and a white wall on column wx of 9x9 field
+func walls(bx, wx int) []placement {
+	moves := make([]placement, 0, 2*usualSize)
+	for y := 1; y <= usualSize; y++ {
+		moves = append(moves,
+			placement{colour: igame.Black, td: igame.TurnData{X: bx, Y: y}},
+			placement{colour: igame.White, td: igame.TurnData{X: wx, Y: y}})
+	}
+	return moves
+}
+
+var estimateTests = []struct {
+	name   string
+	komi   float64
+	bx, wx int
+	leader igame.ChipColour
+	margin float64
+}{
+	{name: "equal", komi: 0, bx: 3, wx: 7, leader: igame.NoColour, margin: 0},
+	{name: "komi", komi: 6.5, bx: 3, wx: 7, leader: igame.White, margin: 6.5},
+	{name: "black wider", komi: 0, bx: 4, wx: 8, leader: igame.Black, margin: 18},
+}
+
+func TestEstimate(t *testing.T) {
+	empty, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	if e := empty.Estimate(); e.Leader != igame.NoColour || len(e.Territory[igame.Black])+len(e.Territory[igame.White]) != 0 {
+		t.Errorf("Unexpected Estimate() on empty field: %v", e)
+	}
+
+	for _, test := range estimateTests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := New(usualSize, test.komi)
+			if err != nil {
+				t.Fatalf("Unexpected New() error: %v", err)
+			}
+			play(t, field, walls(test.bx, test.wx))
+
+			e := field.Estimate()
+			if e.Leader != test.leader || e.Margin != test.margin {
+				t.Errorf("Unexpected Estimate() result:\nwant: leader %v by %v,\ngot: leader %v by %v, scores %v.",
+					test.leader, test.margin, e.Leader, e.Margin, e.Scores)
+			}
+		})
+	}
+}
+
+var finalScoreTests = []struct {
+	name    string
+	ruleset igame.Ruleset
+	komi    float64
+	dead    []igame.TurnData
+	winner  igame.ChipColour
+	scores  map[igame.ChipColour]float64
+	want    error
+}{
+	{
+		name:    "japanese no dead",
+		ruleset: igame.JapaneseRules,
+		winner:  igame.White,
+		scores:  map[igame.ChipColour]float64{igame.Black: 0, igame.White: 18},
+	},
+	{
+		name:    "japanese dead",
+		ruleset: igame.JapaneseRules,
+		komi:    0.5,
+		dead:    []igame.TurnData{{X: 1, Y: 5}},
+		winner:  igame.Black,
+		scores:  map[igame.ChipColour]float64{igame.Black: 19, igame.White: 18.5},
+	},
+	{
+		name:    "chinese dead",
+		ruleset: igame.ChineseRules,
+		komi:    7.5,
+		dead:    []igame.TurnData{{X: 1, Y: 5}, {X: 1, Y: 5}},
+		winner:  igame.White,
+		scores:  map[igame.ChipColour]float64{igame.Black: 27, igame.White: 34.5},
+	},
+	{
+		name:    "jigo",
+		ruleset: igame.ChineseRules,
+		dead:    []igame.TurnData{{X: 1, Y: 5}},
+		winner:  igame.NoColour,
+		scores:  map[igame.ChipColour]float64{igame.Black: 27, igame.White: 27},
+	},
+	{
+		name:    "empty point",
+		ruleset: igame.JapaneseRules,
+		dead:    []igame.TurnData{{X: 1, Y: 4}},
+		want:    ErrNoChip,
+	},
+	{
+		name:    "out of range",
+		ruleset: igame.JapaneseRules,
+		dead:    []igame.TurnData{{X: 0, Y: 4}},
+		want:    ErrPosition,
+	},
+}
+
+func TestFinalScore(t *testing.T) {
+	for _, test := range finalScoreTests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := New(usualSize, test.komi, WithRuleset(test.ruleset))
+			if err != nil {
+				t.Fatalf("Unexpected New() error: %v", err)
+			}
+			play(t, field, walls(3, 7))
+			play(t, field, []placement{{colour: igame.White, td: igame.TurnData{X: 1, Y: 5}}})
+			before := field.State()
+
+			result, err := field.FinalScore(test.dead)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("Unexpected FinalScore() err:\nwant: %v,\ngot: %v.", test.want, err)
+			}
+			if !reflect.DeepEqual(before, field.State()) {
+				t.Errorf("Unexpected field change by FinalScore()")
+			}
+			if err != nil {
+				return
+			}
+			if result.Winner != test.winner || !reflect.DeepEqual(result.Scores, test.scores) {
+				t.Errorf("Unexpected FinalScore() result:\nwant: winner %v, scores %v,\ngot: winner %v, scores %v.",
+					test.winner, test.scores, result.Winner, result.Scores)
+			}
+		})
+	}
+}
+
+var jigoTests = []struct {
+	name   string
+	opts   []Option
+	winner igame.ChipColour
+}{
+	{name: "draw", winner: igame.NoColour},
+	{name: "white wins", opts: []Option{WithJigoWinner(igame.White)}, winner: igame.White},
+}
+
+func TestJigo(t *testing.T) {
+	for _, test := range jigoTests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := New(usualSize, 0, test.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected New() error: %v", err)
+			}
+			play(t, field, walls(3, 7))
+
+			result, err := field.FinalScore(nil)
+			if err != nil {
+				t.Fatalf("Unexpected FinalScore() err: %v", err)
+			}
+			if result.Jigo != true || result.Winner != test.winner || result.Margin != 0 {
+				t.Errorf("Unexpected FinalScore() result:\nwant: jigo, winner %v,\ngot: jigo %v, winner %v, margin %v.",
+					test.winner, result.Jigo, result.Winner, result.Margin)
+			}
+		})
+	}
+}
+
+func TestUnknownRuleset(t *testing.T) {
+	want := ErrRuleset
+	if _, err := New(usualSize, defaultKomi, WithRuleset(igame.Ruleset(-1))); !errors.Is(err, want) {
+		t.Errorf("Unexpected New() err:\nwant: %v,\ngot: %v.", want, err)
+	}
+}
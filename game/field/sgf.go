@@ -0,0 +1,103 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/yagoggame/gomaster/game/igame"
+	"github.com/yagoggame/gomaster/game/sgf"
+)
+
+// Record returns the game record of the field
+func (field *Field) Record() *sgf.Record {
+	return &sgf.Record{
+		Size:   field.size,
+		Komi:   field.komi,
+		Result: sgf.FormatResult(field.result(field.termination())),
+		Setup:  field.Placements(),
+		Moves:  field.History(),
+	}
+}
+
+// SGF returns the game record of the field in SGF format
+func (field *Field) SGF() string {
+	return field.Record().String()
+}
+
+// Load creates the Field and replays all moves of the game record rec on it
+func Load(rec *sgf.Record) (*Field, error) {
+	field, err := New(rec.Size, rec.Komi)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := field.Setup(rec.Setup); err != nil {
+		return nil, fmt.Errorf("failed to setup position: %w", err)
+	}
+	if rec.Handicap >= 2 {
+		// white moves first after handicap chips.
+		if err := field.SetFirst(igame.White); err != nil {
+			return nil, fmt.Errorf("failed to setup position: %w", err)
+		}
+	}
+
+	for i, move := range rec.Moves {
+		switch move.Kind {
+		case igame.PlaceMove:
+			td := move.Position
+			err = field.Move(move.Colour, &td)
+		case igame.PassMove:
+			err = field.Pass(move.Colour)
+		case igame.ResignMove:
+			err = field.Resign(move.Colour)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay move %d: %w", i+1, err)
+		}
+	}
+
+	// SGF keeps resignation in the result only.
+	if loser, ok := resignedBy(rec.Result); ok && field.termination() == igame.NotTerminated {
+		if err := field.Resign(loser); err != nil {
+			return nil, fmt.Errorf("failed to replay resignation: %w", err)
+		}
+	}
+	return field, nil
+}
+
+// resignedBy returns the colour of the gamer who resigned according to result
+func resignedBy(result string) (igame.ChipColour, bool) {
+	switch result {
+	case "B+R", "B+Resign":
+		return igame.White, true
+	case "W+R", "W+Resign":
+		return igame.Black, true
+	}
+	return igame.NoColour, false
+}
+
+// FromSGF reads the game record in SGF format from r
+// and creates the Field with the resulting position and history
+func FromSGF(r io.Reader) (*Field, error) {
+	rec, err := sgf.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return Load(rec)
+}
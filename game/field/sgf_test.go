@@ -0,0 +1,97 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestSGF(t *testing.T) {
+	field, err := New(usualSize, 6.5)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape[:2])
+	if err := field.Pass(igame.Black); err != nil {
+		t.Fatalf("Unexpected Pass() err: %v", err)
+	}
+
+	want := "(;FF[4]GM[1]CA[UTF-8]SZ[9]KM[6.5];B[ba];W[ca];B[])"
+	if got := field.SGF(); got != want {
+		t.Errorf("Unexpected SGF():\nwant: %s,\ngot: %s.", want, got)
+	}
+}
+
+func TestFromSGF(t *testing.T) {
+	field, err := New(usualSize, 6.5)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	err = field.Setup([]igame.Placement{
+		{Colour: igame.Black, Position: igame.TurnData{X: 7, Y: 7}},
+		{Colour: igame.White, Position: igame.TurnData{X: 3, Y: 7}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected Setup() error: %v", err)
+	}
+	play(t, field, koShape)
+
+	loaded, err := FromSGF(strings.NewReader(field.SGF()))
+	if err != nil {
+		t.Fatalf("Unexpected FromSGF() err: %v", err)
+	}
+	if !reflect.DeepEqual(field.State(), loaded.State()) || !reflect.DeepEqual(field.History(), loaded.History()) ||
+		!reflect.DeepEqual(field.Placements(), loaded.Placements()) {
+		t.Errorf("Unexpected FromSGF() result:\nwant: %v,\ngot: %v.", field.State(), loaded.State())
+	}
+
+	want := ErrKo
+	data := strings.TrimSuffix(field.SGF(), ")") + ";B[cb])"
+	if _, err := FromSGF(strings.NewReader(data)); !errors.Is(err, want) {
+		t.Errorf("Unexpected FromSGF() err on illegal move:\nwant: %v,\ngot: %v.", want, err)
+	}
+}
+
+func TestResignSGF(t *testing.T) {
+	field, err := New(usualSize, 6.5)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape[:1])
+	if err := field.Resign(igame.White); err != nil {
+		t.Fatalf("Unexpected Resign() err: %v", err)
+	}
+
+	want := "(;FF[4]GM[1]CA[UTF-8]SZ[9]KM[6.5]RE[B+R];B[ba])"
+	if got := field.SGF(); got != want {
+		t.Errorf("Unexpected SGF():\nwant: %s,\ngot: %s.", want, got)
+	}
+
+	loaded, err := FromSGF(strings.NewReader(want))
+	if err != nil {
+		t.Fatalf("Unexpected FromSGF() err: %v", err)
+	}
+	if !reflect.DeepEqual(field.State(), loaded.State()) {
+		t.Errorf("Unexpected FromSGF() result:\nwant: %v,\ngot: %v.", field.State(), loaded.State())
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// Canonical returns the symmetry mapping the position to its canonical form:
+// the lexicographically smallest rotation or reflection, with points compared
+// row by row. The hash of the canonical form is the same for all symmetric positions.
+func (field *Field) Canonical() (igame.Symmetry, uint64) {
+	best := igame.Identity
+	for _, s := range igame.Symmetries[1:] {
+		if field.lessTransformed(s, best) {
+			best = s
+		}
+	}
+	return best, field.transformedHash(best)
+}
+
+// CanonicalHash returns the Zobrist hash of the canonical form of the position
+func (field *Field) CanonicalHash() uint64 {
+	_, hash := field.Canonical()
+	return hash
+}
+
+// lessTransformed returns true if the position transformed by a
+// is less than the position transformed by b
+func (field *Field) lessTransformed(a, b igame.Symmetry) bool {
+	inverseA, inverseB := a.Inverse(), b.Inverse()
+	for y := 1; y <= field.size; y++ {
+		for x := 1; x <= field.size; x++ {
+			td := igame.TurnData{X: x, Y: y}
+			ca := field.at(inverseA.Apply(td, field.size))
+			cb := field.at(inverseB.Apply(td, field.size))
+			if ca != cb {
+				return ca < cb
+			}
+		}
+	}
+	return false
+}
+
+// transformedHash returns the Zobrist hash of the position transformed by s
+func (field *Field) transformedHash(s igame.Symmetry) uint64 {
+	hash := zobristSizes[field.size]
+	for _, colour := range []igame.ChipColour{igame.Black, igame.White} {
+		for _, td := range field.chips[colour] {
+			hash = hash ^ zobristKey(s.Apply(td, field.size), colour)
+		}
+	}
+	return hash
+}
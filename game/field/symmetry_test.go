@@ -0,0 +1,75 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+var asymmetricShape = []igame.Placement{
+	{Colour: igame.Black, Position: igame.TurnData{X: 3, Y: 4}},
+	{Colour: igame.White, Position: igame.TurnData{X: 5, Y: 3}},
+	{Colour: igame.Black, Position: igame.TurnData{X: 7, Y: 7}},
+	{Colour: igame.White, Position: igame.TurnData{X: 2, Y: 6}},
+}
+
+func setup(t *testing.T, placements []igame.Placement) *Field {
+	field, err := New(usualSize, defaultKomi)
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	if err := field.Setup(placements); err != nil {
+		t.Fatalf("Unexpected Setup() error: %v", err)
+	}
+	return field
+}
+
+func TestCanonicalHash(t *testing.T) {
+	want := setup(t, asymmetricShape).CanonicalHash()
+
+	for _, s := range igame.Symmetries {
+		placements := make([]igame.Placement, len(asymmetricShape))
+		for i, p := range asymmetricShape {
+			placements[i] = igame.Placement{Colour: p.Colour, Position: s.Apply(p.Position, usualSize)}
+		}
+		field := setup(t, placements)
+		if got := field.CanonicalHash(); got != want {
+			t.Errorf("Unexpected CanonicalHash() for symmetry %v:\nwant: %x,\ngot: %x.", s, want, got)
+		}
+	}
+
+	other := setup(t, asymmetricShape[:3])
+	if other.CanonicalHash() == want {
+		t.Errorf("Unexpected CanonicalHash() equality of different positions")
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	field := setup(t, asymmetricShape)
+	s, hash := field.Canonical()
+
+	placements := make([]igame.Placement, len(asymmetricShape))
+	for i, p := range asymmetricShape {
+		placements[i] = igame.Placement{Colour: p.Colour, Position: s.Apply(p.Position, usualSize)}
+	}
+	if got := setup(t, placements).State().Hash; got != hash {
+		t.Errorf("Unexpected hash of canonical form:\nwant: %x,\ngot: %x.", hash, got)
+	}
+}
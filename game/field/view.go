@@ -0,0 +1,69 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// StateFilter changes the state of the field shown to the gamer playing by viewer
+type StateFilter func(viewer igame.ChipColour, state *igame.FieldState)
+
+// StateFor calculate the state shown to the gamer playing by viewer,
+// filters of the field are applied in order of registration.
+// It implements igame.Viewer.
+func (field *Field) StateFor(viewer igame.ChipColour) *igame.FieldState {
+	state := field.State()
+	for _, filter := range field.filters {
+		filter(viewer, state)
+	}
+	return state
+}
+
+// blind returns a StateFilter of blind go: chips of the opponent,
+// or all chips if all is true, are hidden from the gamer until the game is over.
+// The state shown to a spectator playing by NoColour is not changed
+func blind(all bool) StateFilter {
+	return func(viewer igame.ChipColour, state *igame.FieldState) {
+		if viewer == igame.NoColour || state.GameOver {
+			return
+		}
+		for _, colour := range []igame.ChipColour{igame.Black, igame.White} {
+			if colour == viewer && !all {
+				continue
+			}
+			state.ChipsOnBoard[colour] = []*igame.TurnData{}
+			state.PointsUnderControl[colour] = []*igame.TurnData{}
+			if state.LastMove != nil && state.LastMove.Colour == colour {
+				state.LastMove.Position = igame.TurnData{}
+			}
+		}
+		// the position can be guessed by the hash and the ko point.
+		state.Hash, state.KoPoint = 0, nil
+	}
+}
+
+// oneColour is a StateFilter of one-colour go: all chips on board
+// are shown as chips of NoColour
+func oneColour(viewer igame.ChipColour, state *igame.FieldState) {
+	neutral := make([]*igame.TurnData, 0, len(state.ChipsOnBoard[igame.Black])+len(state.ChipsOnBoard[igame.White]))
+	neutral = append(neutral, state.ChipsOnBoard[igame.Black]...)
+	neutral = append(neutral, state.ChipsOnBoard[igame.White]...)
+	state.ChipsOnBoard = map[igame.ChipColour][]*igame.TurnData{
+		igame.NoColour: neutral,
+		igame.Black:    {},
+		igame.White:    {},
+	}
+}
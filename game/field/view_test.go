@@ -0,0 +1,86 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestOneColour(t *testing.T) {
+	field, err := New(usualSize, defaultKomi, WithOneColour())
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape[:2])
+
+	view := field.StateFor(igame.Black)
+	if len(view.ChipsOnBoard[igame.NoColour]) != 2 || len(view.ChipsOnBoard[igame.Black])+len(view.ChipsOnBoard[igame.White]) != 0 {
+		t.Errorf("Unexpected chips on board shown in one-colour go:\nwant: 2 of NoColour,\ngot: %v.", view.ChipsOnBoard)
+	}
+
+	state := field.State()
+	if len(state.ChipsOnBoard[igame.Black]) != 1 || len(state.ChipsOnBoard[igame.White]) != 1 {
+		t.Errorf("Unexpected true chips on board in one-colour go:\nwant: 1 black and 1 white,\ngot: %v.", state.ChipsOnBoard)
+	}
+	if err := field.Move(igame.Black, &koShape[1].td); !errors.Is(err, ErrOccupied) {
+		t.Errorf("Unexpected Move() err on hidden chip:\nwant: %v,\ngot: %v.", ErrOccupied, err)
+	}
+}
+
+func TestStateFilter(t *testing.T) {
+	var viewers []igame.ChipColour
+	field, err := New(usualSize, defaultKomi, WithStateFilter(func(viewer igame.ChipColour, state *igame.FieldState) {
+		viewers = append(viewers, viewer)
+		state.KoPoint = nil
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected New() error: %v", err)
+	}
+	play(t, field, koShape)
+
+	if state := field.StateFor(igame.White); state.KoPoint != nil || len(viewers) != 1 || viewers[0] != igame.White {
+		t.Errorf("Unexpected StateFor() result:\nwant: filtered for white,\ngot: %v for %v.", state.KoPoint, viewers)
+	}
+	if state := field.State(); state.KoPoint == nil {
+		t.Errorf("Unexpected State() filtering")
+	}
+}
+
+func TestBlind(t *testing.T) {
+	for _, all := range []bool{false, true} {
+		field, err := New(usualSize, defaultKomi, WithBlind(all))
+		if err != nil {
+			t.Fatalf("Unexpected New() error: %v", err)
+		}
+		play(t, field, koShape[:2])
+
+		view := field.StateFor(igame.Black)
+		if own := len(view.ChipsOnBoard[igame.Black]); (all && own != 0) || (!all && own != 1) || len(view.ChipsOnBoard[igame.White]) != 0 {
+			t.Errorf("Unexpected chips on board shown to black in blind go (all: %v): %v.", all, view.ChipsOnBoard)
+		}
+		if view.LastMove == nil || view.LastMove.Colour != igame.White || view.LastMove.Position != (igame.TurnData{}) {
+			t.Errorf("Unexpected last move shown to black in blind go (all: %v): %v.", all, view.LastMove)
+		}
+		if view := field.StateFor(igame.NoColour); len(view.ChipsOnBoard[igame.Black]) != 1 || len(view.ChipsOnBoard[igame.White]) != 1 {
+			t.Errorf("Unexpected chips on board shown to spectator in blind go (all: %v): %v.", all, view.ChipsOnBoard)
+		}
+	}
+}
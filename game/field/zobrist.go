@@ -0,0 +1,52 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field
+
+import (
+	"math/rand"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// zobristSeed is fixed to keep hashes stable between processes
+const zobristSeed = 20200315
+
+var (
+	// zobristChips holds random keys of the chips of each colour at each position
+	zobristChips [maxSize * maxSize][2]uint64
+	// zobristSizes holds random keys of the field sizes
+	zobristSizes [maxSize + 1]uint64
+)
+
+func init() {
+	r := rand.New(rand.NewSource(zobristSeed))
+	for i := range zobristChips {
+		zobristChips[i][0] = r.Uint64()
+		zobristChips[i][1] = r.Uint64()
+	}
+	for i := range zobristSizes {
+		zobristSizes[i] = r.Uint64()
+	}
+}
+
+// zobristKey returns the key of the chip of colour at position td
+func zobristKey(td igame.TurnData, colour igame.ChipColour) uint64 {
+	if colour != igame.Black && colour != igame.White {
+		return 0
+	}
+	return zobristChips[(td.Y-1)*maxSize+td.X-1][colour-1]
+}
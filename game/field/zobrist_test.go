@@ -0,0 +1,68 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package field_test
+
+import (
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestHash(t *testing.T) {
+	small, _ := New(usualSize, defaultKomi)
+	big, _ := New(maxSize, defaultKomi)
+	if small.State().Hash == big.State().Hash {
+		t.Errorf("Unexpected equal hashes of empty fields of different sizes")
+	}
+
+	first, _ := New(usualSize, defaultKomi)
+	second, _ := New(usualSize, defaultKomi)
+	empty := first.State().Hash
+
+	play(t, first, koShape[:4])
+	reordered := []placement{koShape[2], koShape[3], koShape[0], koShape[1]}
+	play(t, second, reordered)
+	if first.State().Hash != second.State().Hash {
+		t.Errorf("Unexpected different hashes of the same position reached by different move orders")
+	}
+
+	before := first.State().Hash
+	play(t, first, koShape[4:])
+	if first.State().Hash == before {
+		t.Errorf("Unexpected hash not changed by moves")
+	}
+	for i := 4; i < len(koShape); i++ {
+		if err := first.Undo(); err != nil {
+			t.Fatalf("Unexpected Undo() err: %v", err)
+		}
+	}
+	if first.State().Hash != before {
+		t.Errorf("Unexpected hash after Undo():\nwant: %d,\ngot: %d.", before, first.State().Hash)
+	}
+
+	for range koShape[:4] {
+		_ = first.Undo()
+	}
+	if first.State().Hash != empty {
+		t.Errorf("Unexpected hash of emptied field:\nwant: %d,\ngot: %d.", empty, first.State().Hash)
+	}
+
+	if err := first.Pass(igame.Black); err != nil || first.State().Hash != empty {
+		t.Errorf("Unexpected hash change by Pass(): err %v", err)
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/yagoggame/gomaster/game/field"
 	"github.com/yagoggame/gomaster/game/igame"
@@ -47,247 +49,1093 @@ var (
 	// when he is already left
 	ErrOtherGamerLeft = errors.New("other gamer left the game")
 	// ErrGameDestroyed is an error of performing any operation on Game object
-	// when it is closed as chanel
+	// after it is destroyed
 	ErrGameDestroyed = errors.New("the game is destroyed")
-	// ErrResourceNotAvailable is an error of performing any whaing operation
-	// when the game is over
-	ErrResourceNotAvailable = errors.New("send on closed channel")
+	// ErrResourceNotAvailable is an error of performing any operation
+	// after the game is destroyed.
+	//
+	// Deprecated: operations on the destroyed game return ErrGameDestroyed.
+	ErrResourceNotAvailable = ErrGameDestroyed
+	// ErrNoNegotiation is an error of negotiation of the game settings
+	// when it's not allowed
+	ErrNoNegotiation = errors.New("no negotiation allowed at this point of the game")
+	// ErrGameNotOver is an error of requesting the result of the game in progress
+	ErrGameNotOver = errors.New("the game is not over")
+	// ErrCounting is an error of making a move while the game is in counting phase
+	ErrCounting = errors.New("the game is in counting phase")
+	// ErrNotCounting is an error of operation with dead chips
+	// while the game is not in counting phase
+	ErrNotCounting = errors.New("the game is not in counting phase")
+	// ErrUndoNotAllowed is an error of requesting an undo
+	// when there is no move of the gamer to revert
+	ErrUndoNotAllowed = errors.New("undo is not allowed at this point of the game")
+	// ErrNoUndoRequest is an error of answering an undo request
+	// when the other gamer didn't request it
+	ErrNoUndoRequest = errors.New("no undo request to answer")
+	// ErrNoRematchOffer is an error of accepting a rematch
+	// when the other gamer didn't offer it
+	ErrNoRematchOffer = errors.New("no rematch offer to accept")
+	// ErrAlreadyJoined is an error of joining or watching the game
+	// by gamer who is already a gamer or a spectator of it
+	ErrAlreadyJoined = errors.New("gamer already joined the game")
+	// ErrAlreadyLeft is an error of leaving the game by the gamer who already left it
+	ErrAlreadyLeft = errors.New("gamer already left the game")
+	// ErrMuted is an error of saying in the chat by a muted spectator
+	ErrMuted = errors.New("spectators are muted")
+	// ErrNoGrace is an error of disconnecting from the game
+	// which has no reconnect grace period
+	ErrNoGrace = errors.New("the game has no reconnect grace period")
+	// ErrNotDisconnected is an error of rejoining the game by connected gamer
+	ErrNotDisconnected = errors.New("gamer is not disconnected")
+	// ErrPaused is an error of making a move while the game is paused
+	ErrPaused = errors.New("the game is paused")
+	// ErrNotPaused is an error of resuming the game which is not paused
+	ErrNotPaused = errors.New("the game is not paused")
+	// ErrNoAbortOffer is an error of accepting an abort
+	// when the other gamer didn't offer it
+	ErrNoAbortOffer = errors.New("no abort offer to accept")
+	// ErrHandicap is an error of creation of the game with handicap
+	// which can't be placed on the field
+	ErrHandicap = errors.New("handicap is not supported for the field size")
+	// ErrRematchNotAllowed is an error of rematch of the game
+	// created by NewGameWithMaster
+	ErrRematchNotAllowed = errors.New("rematch is not allowed for the game")
+	// ErrNoEstimate is an error of requesting the score of the game
+	// played on the master which can't estimate it
+	ErrNoEstimate = errors.New("the game can't estimate the score")
+	// ErrAbandoned is an error of awaiting in the game destroyed
+	// because gamers issued no commands for the inactivity timeout
+	ErrAbandoned = errors.New("the game is abandoned")
+	// ErrNoSettingsProposal is an error of answering a proposal of settings
+	// when the other gamer didn't propose them
+	ErrNoSettingsProposal = errors.New("no settings proposal to answer")
+	// ErrNoSettings is an error of proposing nil settings
+	ErrNoSettings = errors.New("no settings to propose")
+	// ErrInvalidTurn is an error of making a turn without the position
+	// or with the position out of the field
+	ErrInvalidTurn = errors.New("invalid turn")
+	// ErrForceEnded is an error of awaiting in the game ended by the administrator
+	ErrForceEnded = errors.New("the game is ended by the administrator")
+	// ErrWrongColour is an error of passing a colour other than Black, White and NoColour
+	ErrWrongColour = errors.New("wrong colour")
+	// ErrNoAdmin is an error of using the Admin not bound to a game by WithAdmin
+	ErrNoAdmin = errors.New("the administrator is not bound to a game")
+	// ErrReferee is an error of adjudication of dead chips by the referee
+	ErrReferee = errors.New("the referee failed to adjudicate dead chips")
+	// ErrBlind is an error of requesting data hidden from gamers of blind go
+	ErrBlind = errors.New("hidden from gamers of blind go until the game is over")
+	// ErrNoReview is an error of reviewing the game in progress
+	// or the game played on the master passed to NewGameWithMaster
+	ErrNoReview = errors.New("review is available only after the game on the field is over")
+	// ErrNoVariation is an error of moving the cursor of the review to a missing variation
+	ErrNoVariation = errors.New("no such variation in the review")
+	// ErrSnapshot is an error of snapshotting the game which can't be persisted
+	// or of restoring the game from inconsistent snapshot
+	ErrSnapshot = errors.New("inconsistent snapshot of the game")
+	// ErrAdjournment is an error of reading data which is not the adjournment
+	// or the adjournment of the unsupported version
+	ErrAdjournment = errors.New("unsupported adjournment")
+	// ErrNoVacation is an error of starting a vacation
+	// when the gamer has no vacation time left
+	ErrNoVacation = errors.New("no vacation time left")
 )
 
-// Game is a datatype based on chanel, to provide a thread safe game entity.
-type Game chan *gameCommand
+// OperationError is an error of an operation performed by a gamer on the game.
+// The cause is one of the Err* errors or an error of the Master.
+type OperationError struct {
+	Op      string // name of the operation
+	GamerID int    // id of the gamer performed the operation
+	Err     error  // cause of the failure
+}
 
-// Queries on actions
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("failed to %s for gamer with id %d: %s", e.Op, e.GamerID, e.Err)
+}
 
-// End releases game resources and closes a Game object as chanel.
-// Use this function only to abort, if creation failed.
-// Normaly - Leave invocation for all users has the same consequences.
-// If the End() invoked after this - an error will be returned.
-func (g Game) End() (err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
-
-	c := make(chan interface{})
-	g <- &gameCommand{act: endCMD, rez: c}
-	<-c
-	return nil
+func (e *OperationError) Unwrap() error {
+	return e.Err
 }
 
-// Join tries to join gamer to this Game.
-func (g Game) Join(gamer *Gamer) (err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
+// Game is a thread safe game entity.
+// Its commands are processed one by one by the goroutine of the game.
+type Game struct {
+	cmds  chan *gameCommand // commands to the goroutine of the game
+	done  chan struct{}     // closed when the game is destroyed
+	mu    sync.RWMutex      // guards cfg, size and left replaced by the goroutine of the game and stale
+	cfg   *config           // settings of the game, nil until the goroutine of the game is run
+	size  int               // size of the field, 0 until the goroutine of the game is run
+	left  map[int]bool      // ids of gamers left the game, set when the game is destroyed
+	stale []*gameCommand    // awaitings cancelled by the context, dropped by the goroutine of the game
+}
+
+// config returns settings of the game and the size of the field cached by the handle
+func (g *Game) config() (cfg *config, size int) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.cfg, g.size
+}
+
+// setConfig caches settings of the game and the size of the field in the handle
+func (g *Game) setConfig(cfg *config, size int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cfg, g.size = cfg, size
+}
+
+// newGameHandle creates the Game object without the goroutine
+func newGameHandle() *Game {
+	return &Game{cmds: make(chan *gameCommand), done: make(chan struct{})}
+}
+
+// takeStale returns awaitings cancelled since the previous call
+func (g *Game) takeStale() []*gameCommand {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	stale := g.stale
+	g.stale = nil
+	return stale
+}
+
+// setLeft keeps ids of gamers left the destroyed game in the handle
+func (g *Game) setLeft(left map[int]bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.left = left
+}
+
+// hasLeft checks that the gamer with id left the destroyed game
+func (g *Game) hasLeft(id int) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.left[id]
+}
+
+// Queries on actions
 
-	c := make(chan interface{})
-	g <- &gameCommand{act: joinCMD, gamer: gamer, rez: c}
+// request sends cmd to the game and returns the reply.
+// Sending of cmd and awaiting of the reply are cancelled by ctx.
+// ErrGameDestroyed is returned if the game is destroyed before cmd is sent.
+func (g *Game) request(ctx context.Context, cmd *gameCommand) (rez interface{}, err error) {
+	//buffered because when killed by cancelation - internal mechanism can block other invocation on attemption to write to this chanel later
+	c := make(chan interface{}, 1)
+	cmd.rez = c
+	if ctx.Err() != nil {
+		return nil, ErrCancellation
+	}
+	select {
+	case g.cmds <- cmd:
+	case <-g.done:
+		return nil, ErrGameDestroyed
+	case <-ctx.Done():
+		return nil, ErrCancellation
+	}
+
+	// the goroutine of the game replies to every received command,
+	// so the reply comes even if the game is destroyed meanwhile.
 
-	if err := <-c; err != nil {
-		return err.(error)
+	select {
+	case rez := <-c:
+		if cfg, _ := g.config(); cfg != nil && cfg.logger != nil {
+			if err, ok := rez.(error); ok {
+				logError(cfg, err)
+			}
+		}
+		return rez, nil
+	case <-ctx.Done():
+		if awaitings[cmd.act] {
+			g.cancelWait(cmd.id, c)
+		}
+		return nil, ErrCancellation
 	}
-	return nil
 }
 
-// GamerState returns a copy of Internal State of a gamer
-// (to prevent a manual changing).
-func (g Game) GamerState(id int) (state *GamerState, err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
+// cancelWait notes the awaiting of the gamer with id replied to c as stale.
+// The game drops it before the next command, so the caller isn't blocked
+// by the game busy with another command and the following commands of the caller see it dropped.
+func (g *Game) cancelWait(id int, c chan<- interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stale = append(g.stale, &gameCommand{id: id, waiter: c})
+}
 
-	c := make(chan interface{})
-	g <- &gameCommand{act: gamerStateCMD, id: id, rez: c}
-	rez := <-c
+// query sends the command to the game and returns the reply of type T.
+// An error replied by the game is returned as is.
+func query[T any](ctx context.Context, g *Game, cmd *gameCommand) (val T, err error) {
+	rez, err := g.request(ctx, cmd)
+	if err != nil {
+		return val, err
+	}
 
 	switch rez := rez.(type) {
 	case error:
-		return &GamerState{}, rez
-	case *GamerState:
+		return val, rez
+	case T:
 		return rez, nil
 	}
 
-	return &GamerState{}, fmt.Errorf("returned value %v of Type %T: %w", rez, rez, ErrUnknownTypeReturned)
+	return val, fmt.Errorf("returned value %v of Type %T: %w", rez, rez, ErrUnknownTypeReturned)
+}
+
+// checkTurn checks that turn has the position on the field of the game
+func (g *Game) checkTurn(turn *igame.TurnData) error {
+	if turn == nil {
+		return &wrongTurnError{err: fmt.Errorf("%w: no position", ErrInvalidTurn)}
+	}
+	_, size := g.config()
+	if turn.X < 1 || turn.Y < 1 || (size > 0 && (turn.X > size || turn.Y > size)) {
+		return &wrongTurnError{err: fmt.Errorf("%w: position %v is out of the field", ErrInvalidTurn, *turn)}
+	}
+	return nil
+}
+
+// errorOf returns the error replied by the game, if any
+func errorOf(rez interface{}, err error) error {
+	if err != nil {
+		return err
+	}
+	if err, ok := rez.(error); ok == true {
+		return err
+	}
+	return nil
+}
 
+// End releases game resources and destroys a Game object.
+// Use this function only to abort, if creation failed.
+// Normaly - Leave invocation for all users has the same consequences.
+// If the End() invoked after this - ErrGameDestroyed will be returned.
+func (g *Game) End() error {
+	return g.EndContext(context.Background())
 }
 
-// FieldSize returns a size of game's field.
-func (g Game) FieldSize(id int) (size int, err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
+// EndContext is like End, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) EndContext(ctx context.Context) error {
+	return errorOf(g.request(ctx, &gameCommand{act: endCMD}))
+}
 
-	c := make(chan interface{})
-	g <- &gameCommand{act: gameFieldSize, id: id, rez: c}
-	rez := <-c
+// Join tries to join gamer to this Game.
+func (g *Game) Join(gamer *Gamer) error {
+	return g.JoinContext(context.Background(), gamer)
+}
 
-	switch rez := rez.(type) {
-	case error:
-		return 0, rez
-	case int:
-		return rez, nil
+// JoinContext is like Join, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) JoinContext(ctx context.Context, gamer *Gamer) error {
+	return errorOf(g.request(ctx, &gameCommand{act: joinCMD, gamer: gamer}))
+}
+
+// JoinTeam tries to join gamer to this Game playing by colour.
+// In rengo colour selects the team of two gamers, gamers get seats in the team in order of joining.
+func (g *Game) JoinTeam(gamer *Gamer, colour igame.ChipColour) error {
+	return g.JoinTeamContext(context.Background(), gamer, colour)
+}
+
+// JoinTeamContext is like JoinTeam, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) JoinTeamContext(ctx context.Context, gamer *Gamer, colour igame.ChipColour) error {
+	return errorOf(g.request(ctx, &gameCommand{act: joinCMD, gamer: gamer, colour: colour}))
+}
+
+// Watch attaches gamer to this Game as a spectator.
+// Spectators can get GameState, Result and Subscribe to events,
+// but can't make moves.
+func (g *Game) Watch(gamer *Gamer) error {
+	return g.WatchContext(context.Background(), gamer)
+}
+
+// WatchContext is like Watch, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) WatchContext(ctx context.Context, gamer *Gamer) error {
+	return errorOf(g.request(ctx, &gameCommand{act: watchCMD, gamer: gamer}))
+}
+
+// StopWatching detaches the spectator with id from this Game.
+func (g *Game) StopWatching(id int) error {
+	return g.StopWatchingContext(context.Background(), id)
+}
+
+// StopWatchingContext is like StopWatching, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) StopWatchingContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: stopWatchingCMD, id: id}))
+}
+
+// Spectators returns the number of spectators of this Game.
+func (g *Game) Spectators(id int) (number int, err error) {
+	return g.SpectatorsContext(context.Background(), id)
+}
+
+// SpectatorsContext is like Spectators, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) SpectatorsContext(ctx context.Context, id int) (number int, err error) {
+	return query[int](ctx, g, &gameCommand{act: spectatorsCMD, id: id})
+}
+
+// GamerState returns a copy of Internal State of a gamer
+// (to prevent a manual changing).
+func (g *Game) GamerState(id int) (state *GamerState, err error) {
+	return g.GamerStateContext(context.Background(), id)
+}
+
+// GamerStateContext is like GamerState, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) GamerStateContext(ctx context.Context, id int) (state *GamerState, err error) {
+	state, err = query[*GamerState](ctx, g, &gameCommand{act: gamerStateCMD, id: id})
+	if err != nil {
+		return &GamerState{}, err
 	}
+	return state, nil
+}
 
-	return 0, fmt.Errorf("returned value %v of Type %T: %w", rez, rez, ErrUnknownTypeReturned)
+// FieldSize returns a size of game's field.
+func (g *Game) FieldSize(id int) (size int, err error) {
+	return g.FieldSizeContext(context.Background(), id)
+}
 
+// FieldSizeContext is like FieldSize, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) FieldSizeContext(ctx context.Context, id int) (size int, err error) {
+	return query[int](ctx, g, &gameCommand{act: gameFieldSize, id: id})
 }
 
 // GameState returns a structure with full description of game situation.
-func (g Game) GameState(id int) (state *igame.FieldState, err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
+func (g *Game) GameState(id int) (state *igame.FieldState, err error) {
+	return g.GameStateContext(context.Background(), id)
+}
 
-	c := make(chan interface{})
-	g <- &gameCommand{act: gameStateCMD, id: id, rez: c}
-	rez := <-c
+// GameStateContext is like GameState, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) GameStateContext(ctx context.Context, id int) (state *igame.FieldState, err error) {
+	return query[*igame.FieldState](ctx, g, &gameCommand{act: gameStateCMD, id: id})
+}
 
-	switch rez := rez.(type) {
-	case error:
-		return nil, rez
-	case *igame.FieldState:
-		return rez, nil
-	}
+// Result returns the outcome of the finished game.
+// It's available after the game is over, when other operations return ErrGameOver.
+// The game left by a gamer is won by the remaining one by forfeit.
+func (g *Game) Result(id int) (result *igame.Result, err error) {
+	return g.ResultContext(context.Background(), id)
+}
 
-	return nil, fmt.Errorf("returned value %v of Type %T: %w", rez, rez, ErrUnknownTypeReturned)
+// ResultContext is like Result, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ResultContext(ctx context.Context, id int) (result *igame.Result, err error) {
+	return query[*igame.Result](ctx, g, &gameCommand{act: resultCMD, id: id})
+}
 
+// BookMoves returns moves of the opening book known for the current position
+// for the colour to move. It's empty if the game has no opening book.
+func (g *Game) BookMoves(id int) (moves []igame.BookMove, err error) {
+	return g.BookMovesContext(context.Background(), id)
+}
+
+// BookMovesContext is like BookMoves, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) BookMovesContext(ctx context.Context, id int) (moves []igame.BookMove, err error) {
+	return query[[]igame.BookMove](ctx, g, &gameCommand{act: bookMovesCMD, id: id})
 }
 
 // WaitBegin waits for game begin.
 // If gamer identified by id started this game
 // - awaiting another person.
-func (g Game) WaitBegin(ctx context.Context, id int) (err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
-
-	//buffered because when killed by cancelation - internal mechanism can block other invocation on attemption to write to this chanel later
-	c := make(chan interface{}, 1)
-	g <- &gameCommand{act: wBeginCMD, id: id, rez: c}
-	select {
-	case err := <-c:
-		if err, ok := err.(error); ok == true {
-			return err
-		}
-	case <-ctx.Done():
-		return ErrCancellation
+func (g *Game) WaitBegin(ctx context.Context, id int) error {
+	rez, err := g.WaitBeginResult(ctx, id)
+	if err != nil {
+		return err
 	}
-	return nil
+	return rez.Err()
+}
+
+// WaitBeginResult is like WaitBegin, but describes why awaiting finished
+// instead of reporting it as an error.
+// Errors are returned only on failures of the query itself.
+func (g *Game) WaitBeginResult(ctx context.Context, id int) (*WaitResult, error) {
+	return query[*WaitResult](ctx, g, &gameCommand{act: wBeginCMD, id: id})
 }
 
 // IsGameBegun return true, if all gamers joined to a game.
 // Function provided to avoid of sleep on WaitBegin call.
-func (g Game) IsGameBegun(id int) (igb bool, err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
+func (g *Game) IsGameBegun(id int) (igb bool, err error) {
+	return g.IsGameBegunContext(context.Background(), id)
+}
 
-	c := make(chan interface{}, 1)
-	g <- &gameCommand{act: isGameBegunCMD, id: id, rez: c}
-	rez := <-c
+// IsGameBegunContext is like IsGameBegun, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) IsGameBegunContext(ctx context.Context, id int) (igb bool, err error) {
+	return query[bool](ctx, g, &gameCommand{act: isGameBegunCMD, id: id})
+}
 
-	switch rez := rez.(type) {
-	case error:
-		return false, rez
-	case bool:
-		return rez, nil
+// WaitTurn waits for your turn.
+func (g *Game) WaitTurn(ctx context.Context, id int) error {
+	rez, err := g.WaitTurnResult(ctx, id)
+	if err != nil {
+		return err
 	}
+	return rez.Err()
+}
 
-	return false, fmt.Errorf("returned value %v of Type %T: %w", rez, rez, ErrUnknownTypeReturned)
+// WaitTurnResult is like WaitTurn, but describes why awaiting finished
+// instead of reporting it as an error.
+// Errors are returned only on failures of the query itself.
+func (g *Game) WaitTurnResult(ctx context.Context, id int) (*WaitResult, error) {
+	return query[*WaitResult](ctx, g, &gameCommand{act: wTurnCMD, id: id})
 }
 
-// WaitTurn waits for your turn.
-func (g Game) WaitTurn(ctx context.Context, id int) (err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
+// WaitMove waits for the gamer's turn like WaitTurn
+// and returns the last move made in the game, nil if no moves made yet.
+// If the game is over or counting phase begun, the move which caused it
+// is returned with ErrGameOver or ErrCounting.
+func (g *Game) WaitMove(ctx context.Context, id int) (move *igame.Move, err error) {
+	rez, err := g.WaitTurnResult(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	//buffered because when killed by cancelation - internal mechanism can block other invocation on attemption to write to this chanel later
-	c := make(chan interface{}, 1)
-	g <- &gameCommand{act: wTurnCMD, id: id, rez: c}
-	select {
-	case err := <-c:
-		if err, ok := err.(error); ok == true {
-			return err
-		}
-	case <-ctx.Done():
-		return ErrCancellation
+	switch rez.Reason {
+	case TurnBegunReason, GameOverReason, CountingReason:
+		return rez.Move, rez.Err()
 	}
-	return nil
+	return nil, rez.Err()
 }
 
 // IsMyTurn returns true, if now is a gamer's turn else - false.
 // Gamer is identified by his id.
 // Function provided to avoid of sleep on WaitTurn call.
-func (g Game) IsMyTurn(id int) (imt bool, err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
+// In the teaching mode it is always true for the gamer of the game.
+func (g *Game) IsMyTurn(id int) (imt bool, err error) {
+	return g.IsMyTurnContext(context.Background(), id)
+}
+
+// IsMyTurnContext is like IsMyTurn, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) IsMyTurnContext(ctx context.Context, id int) (imt bool, err error) {
+	return query[bool](ctx, g, &gameCommand{act: isMyTurnCMD, id: id})
+}
+
+// MakeTurn tries to make a turn.
+// The turn without the position or with the position out of the field
+// is rejected with ErrInvalidTurn, which is ErrWrongTurn too.
+func (g *Game) MakeTurn(id int, turn *igame.TurnData) error {
+	return g.MakeTurnContext(context.Background(), id, turn)
+}
+
+// MakeTurnContext is like MakeTurn, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) MakeTurnContext(ctx context.Context, id int, turn *igame.TurnData) error {
+	if err := g.checkTurn(turn); err != nil {
+		return opError("makeTurn", id, err)
+	}
+	return errorOf(g.request(ctx, &gameCommand{act: makeTurnCMD, id: id, turn: turn}))
+}
+
+// MakeTurnWithMeta is like MakeTurn, but the move is recorded with metadata reported by the client,
+// such as the time the move was sent, to be checked by the analyzer set by WithMoveAnalyzer.
+func (g *Game) MakeTurnWithMeta(id int, turn *igame.TurnData, meta map[string]string) error {
+	return g.MakeTurnWithMetaContext(context.Background(), id, turn, meta)
+}
+
+// MakeTurnWithMetaContext is like MakeTurnWithMeta, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) MakeTurnWithMetaContext(ctx context.Context, id int, turn *igame.TurnData, meta map[string]string) error {
+	if err := g.checkTurn(turn); err != nil {
+		return opError("makeTurn", id, err)
+	}
+	metaCpy := make(map[string]string, len(meta))
+	for k, v := range meta {
+		metaCpy[k] = v
+	}
+	return errorOf(g.request(ctx, &gameCommand{act: makeTurnCMD, id: id, turn: turn, meta: metaCpy}))
+}
+
+// Pass passes a turn.
+// Two passes in a row can finish the game, depending on the rules of the field.
+// The game is followed by counting phase if the field supports it,
+// awaiting gamers get ErrCounting. Otherwise they get ErrGameOver.
+func (g *Game) Pass(id int) error {
+	return g.PassContext(context.Background(), id)
+}
 
+// PassContext is like Pass, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) PassContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: passCMD, id: id}))
+}
+
+// Resign concedes the game by the gamer with id.
+// It's allowed at any time of the game, not only on the gamer's turn.
+// The game is over, awaiting gamers get ErrGameOver.
+func (g *Game) Resign(id int) error {
+	return g.ResignContext(context.Background(), id)
+}
+
+// ResignContext is like Resign, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ResignContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: resignCMD, id: id}))
+}
+
+// MarkDead marks the chain of chips containing td as dead.
+// It's allowed only in counting phase, which follows two passes in a row.
+// Marking cancels acceptance of the score by both gamers.
+func (g *Game) MarkDead(id int, td *igame.TurnData) error {
+	return g.MarkDeadContext(context.Background(), id, td)
+}
+
+// MarkDeadContext is like MarkDead, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) MarkDeadContext(ctx context.Context, id int, td *igame.TurnData) error {
+	if err := g.checkTurn(td); err != nil {
+		return opError("markDead", id, err)
+	}
+	return errorOf(g.request(ctx, &gameCommand{act: markDeadCMD, id: id, turn: td}))
+}
+
+// AcceptScore accepts the score with chips marked dead by MarkDead.
+// When both gamers accept the score, the game is over
+// and it's result is available by Result.
+func (g *Game) AcceptScore(id int) error {
+	return g.AcceptScoreContext(context.Background(), id)
+}
+
+// AcceptScoreContext is like AcceptScore, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) AcceptScoreContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: acceptScoreCMD, id: id}))
+}
+
+// RejectScore rejects the score with chips marked dead by MarkDead without resumption of the play.
+// The referee set by WithReferee adjudicates dead chips after the configured number of rejections,
+// then the game is over with the result decided by RefereeMethod.
+func (g *Game) RejectScore(id int) error {
+	return g.RejectScoreContext(context.Background(), id)
+}
+
+// RejectScoreContext is like RejectScore, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) RejectScoreContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: rejectScoreCMD, id: id}))
+}
+
+// ResumePlay rejects the score and resumes the play after disagreement on dead chips.
+// The last pass is reverted, so the gamer passed last has the turn.
+func (g *Game) ResumePlay(id int) error {
+	return g.ResumePlayContext(context.Background(), id)
+}
+
+// ResumePlayContext is like ResumePlay, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ResumePlayContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: resumePlayCMD, id: id}))
+}
+
+// RequestUndo requests the other gamer to revert the last move of the gamer with id.
+// If the other gamer made a move after it, that move is reverted too.
+// The request is cancelled by any move made before the answer.
+func (g *Game) RequestUndo(id int) error {
+	return g.RequestUndoContext(context.Background(), id)
+}
+
+// RequestUndoContext is like RequestUndo, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) RequestUndoContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: requestUndoCMD, id: id}))
+}
+
+// AnswerUndo answers the undo request of the other gamer.
+// If accept is true, moves are reverted and the turn returns to the requesting gamer.
+func (g *Game) AnswerUndo(id int, accept bool) error {
+	return g.AnswerUndoContext(context.Background(), id, accept)
+}
+
+// AnswerUndoContext is like AnswerUndo, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) AnswerUndoContext(ctx context.Context, id int, accept bool) error {
+	return errorOf(g.request(ctx, &gameCommand{act: answerUndoCMD, id: id, accept: accept}))
+}
+
+// OfferRematch offers the other gamer a new game with colours swapped
+// and the same size, komi and options, after this game is over.
+// It waits for the other gamer to accept the offer by AcceptRematch
+// and returns the new game both gamers are joined to.
+func (g *Game) OfferRematch(ctx context.Context, id int) (rematch *Game, err error) {
+	return query[*Game](ctx, g, &gameCommand{act: offerRematchCMD, id: id})
+}
+
+// AcceptRematch accepts the rematch offered by the other gamer
+// and returns the new game both gamers are joined to.
+func (g *Game) AcceptRematch(id int) (rematch *Game, err error) {
+	return g.AcceptRematchContext(context.Background(), id)
+}
+
+// AcceptRematchContext is like AcceptRematch, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) AcceptRematchContext(ctx context.Context, id int) (rematch *Game, err error) {
+	return query[*Game](ctx, g, &gameCommand{act: acceptRematchCMD, id: id})
+}
+
+// Subscribe subscribes the gamer with id to events of the game.
+// Events are delivered in order they happen until cancel is called
+// or the game is destroyed, then the channel is closed.
+// The channel is closed immediately if the gamer is not joined to the game.
+// cancel doesn't wait for the game busy with another command,
+// the channel is closed when the game drops the subscription.
+func (g *Game) Subscribe(id int) (events <-chan GameEvent, cancel func()) {
+	return g.SubscribeContext(context.Background(), id)
+}
+
+// SubscribeContext is like Subscribe, but sending of the query and awaiting of the reply are cancelled by ctx.
+// The channel is closed immediately on cancellation.
+func (g *Game) SubscribeContext(ctx context.Context, id int) (events <-chan GameEvent, cancel func()) {
+	return g.attach(ctx, id, newSubscription())
+}
+
+// SubscribeDeltas is like Subscribe, but events of moves carry changes of the state
+// since the previous event in Delta instead of the whole State.
+// The first event is ResyncEvent with the whole State, Resync requests it again.
+func (g *Game) SubscribeDeltas(id int) (events <-chan GameEvent, cancel func()) {
+	return g.SubscribeDeltasContext(context.Background(), id)
+}
+
+// SubscribeDeltasContext is like SubscribeDeltas, but sending of the query and awaiting of the reply are cancelled by ctx.
+// The channel is closed immediately on cancellation.
+func (g *Game) SubscribeDeltasContext(ctx context.Context, id int) (events <-chan GameEvent, cancel func()) {
+	sub := newSubscription()
+	sub.deltas = true
+	return g.attach(ctx, id, sub)
+}
+
+// Resync sends ResyncEvent with the whole State to subscriptions of the gamer with id made by SubscribeDeltas,
+// following deltas are counted from it.
+func (g *Game) Resync(id int) error {
+	return g.ResyncContext(context.Background(), id)
+}
+
+// ResyncContext is like Resync, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ResyncContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: resyncCMD, id: id}))
+}
+
+// attach subscribes sub to events of the game for the gamer with id
+func (g *Game) attach(ctx context.Context, id int, sub *subscription) (events <-chan GameEvent, cancel func()) {
+	var once sync.Once
+	cancel = func() {
+		once.Do(func() {
+			g.unsubscribe(sub, func() { close(sub.done) })
+		})
+	}
+
+	if err := g.subscribe(ctx, id, sub); err != nil {
+		// the subscription could be passed to the game before cancellation.
+		g.unsubscribe(sub, sub.close)
+	}
+	return sub.out, cancel
+}
+
+// WaitState waits for a change of the game visible to the gamer with id
+// (a move, a pass, a join or a leave of a gamer, an expiry of a clock and so on)
+// and returns the state of the game after it. Chat messages do not change the game.
+func (g *Game) WaitState(id int) (state *igame.FieldState, err error) {
+	return g.WaitStateContext(context.Background(), id)
+}
+
+// WaitStateContext is like WaitState, but waiting is cancelled by ctx.
+func (g *Game) WaitStateContext(ctx context.Context, id int) (state *igame.FieldState, err error) {
+	events, cancel := g.SubscribeContext(ctx, id)
+	defer cancel()
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if ok == false {
+				// the gamer is not joined, ctx is cancelled or the game is destroyed
+				if _, err := g.GameStateContext(ctx, id); err != nil {
+					return nil, err
+				}
+				return nil, &OperationError{Op: "waitState", GamerID: id, Err: ErrGameDestroyed}
+			}
+			if ev.Kind == ChatEvent {
+				continue
+			}
+			return g.GameStateContext(ctx, id)
+		case <-ctx.Done():
+			return nil, ErrCancellation
+		}
+	}
+}
+
+// subscribe passes the subscription of the gamer with id to the game
+func (g *Game) subscribe(ctx context.Context, id int, sub *subscription) error {
+	return errorOf(g.request(ctx, &gameCommand{act: subscribeCMD, id: id, sub: sub}))
+}
+
+// unsubscribe stops publishing of events to the subscription and calls done, if it's not nil,
+// when the game dropped the subscription or is destroyed.
+// It doesn't block the caller, because the game may be busy with another command.
+func (g *Game) unsubscribe(sub *subscription, done func()) {
 	c := make(chan interface{}, 1)
-	g <- &gameCommand{act: isMyTurnCMD, id: id, rez: c}
-	rez := <-c
+	go func() {
+		select {
+		case g.cmds <- &gameCommand{act: unsubscribeCMD, sub: sub, rez: c}:
+			<-c
+		case <-g.done:
+		}
+		if done != nil {
+			done()
+		}
+	}()
+}
 
-	switch rez := rez.(type) {
-	case error:
-		return false, rez
-	case bool:
-		return rez, nil
+// Say sends the message with text to the game chat.
+// The message is delivered to subscribers by ChatEvent and kept in the chat history.
+func (g *Game) Say(id int, text string) error {
+	return g.SayContext(context.Background(), id, text)
+}
+
+// SayContext is like Say, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) SayContext(ctx context.Context, id int, text string) error {
+	return errorOf(g.request(ctx, &gameCommand{act: sayCMD, id: id, text: text}))
+}
+
+// ChatHistory returns all messages said in the game chat in order.
+func (g *Game) ChatHistory(id int) (messages []ChatMessage, err error) {
+	return g.ChatHistoryContext(context.Background(), id)
+}
+
+// ChatHistoryContext is like ChatHistory, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ChatHistoryContext(ctx context.Context, id int) (messages []ChatMessage, err error) {
+	return query[[]ChatMessage](ctx, g, &gameCommand{act: chatHistoryCMD, id: id})
+}
+
+// Clocks returns states of clocks of gamers by their colours.
+// In rengo the clock of the team mate moving next is returned for each colour.
+func (g *Game) Clocks(id int) (clocks map[igame.ChipColour]Clock, err error) {
+	return g.ClocksContext(context.Background(), id)
+}
+
+// ClocksContext is like Clocks, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ClocksContext(ctx context.Context, id int) (clocks map[igame.ChipColour]Clock, err error) {
+	return query[map[igame.ChipColour]Clock](ctx, g, &gameCommand{act: clocksCMD, id: id})
+}
+
+// History returns moves made in the game in order they were made.
+// Moves reverted by undo are not included.
+func (g *Game) History(id int) (moves []HistoryMove, err error) {
+	return g.HistoryContext(context.Background(), id)
+}
+
+// HistoryContext is like History, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) HistoryContext(ctx context.Context, id int) (moves []HistoryMove, err error) {
+	return query[[]HistoryMove](ctx, g, &gameCommand{act: historyCMD, id: id})
+}
+
+// SGF returns the record of the game in SGF format
+// with names of gamers, time control settings and result of the game.
+func (g *Game) SGF(id int) (record string, err error) {
+	return g.SGFContext(context.Background(), id)
+}
+
+// SGFContext is like SGF, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) SGFContext(ctx context.Context, id int) (record string, err error) {
+	return query[string](ctx, g, &gameCommand{act: sgfCMD, id: id})
+}
+
+// Snapshot returns the state of the game to persist it and resume by RestoreGame.
+func (g *Game) Snapshot() (snap *Snapshot, err error) {
+	return g.SnapshotContext(context.Background())
+}
+
+// SnapshotContext is like Snapshot, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) SnapshotContext(ctx context.Context) (snap *Snapshot, err error) {
+	return query[*Snapshot](ctx, g, &gameCommand{act: snapshotCMD})
+}
+
+// Disconnect starts the grace period of the gamer with id:
+// the clock of the gamer is paused until the gamer Rejoins the game.
+// If the gamer doesn't rejoin within the period set by WithReconnectGrace,
+// the gamer leaves the game and loses it by forfeit.
+func (g *Game) Disconnect(id int) error {
+	return g.DisconnectContext(context.Background(), id)
+}
+
+// DisconnectContext is like Disconnect, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) DisconnectContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: disconnectCMD, id: id}))
+}
+
+// Rejoin returns the disconnected gamer with id to the game.
+func (g *Game) Rejoin(id int) error {
+	return g.RejoinContext(context.Background(), id)
+}
+
+// RejoinContext is like Rejoin, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) RejoinContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: rejoinCMD, id: id}))
+}
+
+// Stats returns statistics of the game.
+func (g *Game) Stats(id int) (stats *Stats, err error) {
+	return g.StatsContext(context.Background(), id)
+}
+
+// StatsContext is like Stats, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) StatsContext(ctx context.Context, id int) (stats *Stats, err error) {
+	return query[*Stats](ctx, g, &gameCommand{act: statsCMD, id: id})
+}
+
+// ProposeSettings proposes the other gamer to change settings of the game before the first move.
+// The proposal replaces the previous one, the other gamer accepts or declines it by AnswerSettings.
+func (g *Game) ProposeSettings(id int, settings *Settings) error {
+	return g.ProposeSettingsContext(context.Background(), id, settings)
+}
+
+// ProposeSettingsContext is like ProposeSettings, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ProposeSettingsContext(ctx context.Context, id int, settings *Settings) error {
+	if settings == nil {
+		return opError("proposeSettings", id, ErrNoSettings)
 	}
+	// the copy is passed to the game to prevent change from the outside
+	proposal := *settings
+	return errorOf(g.request(ctx, &gameCommand{act: proposeSettingsCMD, id: id, settings: &proposal}))
+}
 
-	return false, fmt.Errorf("returned value %v of Type %T: %w", rez, rez, ErrUnknownTypeReturned)
+// AnswerSettings accepts or declines settings proposed by the other gamer.
+// On acceptance the field is set up again and clocks are restarted with the proposed settings.
+func (g *Game) AnswerSettings(id int, accept bool) error {
+	return g.AnswerSettingsContext(context.Background(), id, accept)
 }
 
-// MakeTurn tries to make a turn.
-func (g Game) MakeTurn(id int, turn *igame.TurnData) (err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
+// AnswerSettingsContext is like AnswerSettings, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) AnswerSettingsContext(ctx context.Context, id int, accept bool) error {
+	return errorOf(g.request(ctx, &gameCommand{act: answerSettingsCMD, id: id, accept: accept}))
+}
 
-	c := make(chan interface{})
-	g <- &gameCommand{act: makeTurnCMD, id: id, rez: c, turn: turn}
+// Settings returns the effective configuration of the game
+// available to gamers and spectators of the game.
+func (g *Game) Settings(id int) (conf *Configuration, err error) {
+	return g.SettingsContext(context.Background(), id)
+}
 
-	if err, ok := (<-c).(error); ok == true {
-		return err
+// SettingsContext is like Settings, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) SettingsContext(ctx context.Context, id int) (conf *Configuration, err error) {
+	return query[*Configuration](ctx, g, &gameCommand{act: settingsCMD, id: id})
+}
+
+// Score returns an approximate evaluation of the game in progress
+// available to gamers and spectators of the game.
+func (g *Game) Score(id int) (estimate *igame.Estimate, err error) {
+	return g.ScoreContext(context.Background(), id)
+}
+
+// ScoreContext is like Score, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ScoreContext(ctx context.Context, id int) (estimate *igame.Estimate, err error) {
+	return query[*igame.Estimate](ctx, g, &gameCommand{act: scoreCMD, id: id})
+}
+
+// Status returns the current turn, flags of the game and states of the gamers in one query.
+func (g *Game) Status(id int) (status *Status, err error) {
+	return g.StatusContext(context.Background(), id)
+}
+
+// StatusContext is like Status, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) StatusContext(ctx context.Context, id int) (status *Status, err error) {
+	return query[*Status](ctx, g, &gameCommand{act: statusCMD, id: id})
+}
+
+// Gamers returns states of all gamers joined the game by id with their clocks.
+// It's available to gamers and spectators of the game.
+func (g *Game) Gamers(id int) (gamers map[int]*GamerState, err error) {
+	return g.GamersContext(context.Background(), id)
+}
+
+// GamersContext is like Gamers, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) GamersContext(ctx context.Context, id int) (gamers map[int]*GamerState, err error) {
+	return query[map[int]*GamerState](ctx, g, &gameCommand{act: gamersCMD, id: id})
+}
+
+// ReviewPosition returns the position of the cursor of the review of the finished game.
+// Gamers and spectators share the cursor, it's at the initial position when the review begins.
+func (g *Game) ReviewPosition(id int) (pos *ReviewPosition, err error) {
+	return g.ReviewPositionContext(context.Background(), id)
+}
+
+// ReviewPositionContext is like ReviewPosition, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ReviewPositionContext(ctx context.Context, id int) (pos *ReviewPosition, err error) {
+	return query[*ReviewPosition](ctx, g, &gameCommand{act: reviewPositionCMD, id: id})
+}
+
+// ReviewGoto moves the cursor of the review to the position at path,
+// which lists variations chosen from the initial position, 0 is the main line.
+// Subscribers are informed by ReviewEvent.
+func (g *Game) ReviewGoto(id int, path []int) error {
+	return g.ReviewGotoContext(context.Background(), id, path)
+}
+
+// ReviewGotoContext is like ReviewGoto, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ReviewGotoContext(ctx context.Context, id int, path []int) error {
+	path = append([]int(nil), path...)
+	return errorOf(g.request(ctx, &gameCommand{act: reviewGotoCMD, id: id, path: path}))
+}
+
+// ReviewMove makes the move by the colour to move at the cursor of the review.
+// The move not made in the game or the review before branches a new variation.
+// Subscribers are informed by ReviewEvent.
+func (g *Game) ReviewMove(id int, turn *igame.TurnData) error {
+	return g.ReviewMoveContext(context.Background(), id, turn)
+}
+
+// ReviewMoveContext is like ReviewMove, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ReviewMoveContext(ctx context.Context, id int, turn *igame.TurnData) error {
+	if err := g.checkTurn(turn); err != nil {
+		return opError("reviewMove", id, err)
 	}
+	turnCpy := *turn
+	return errorOf(g.request(ctx, &gameCommand{act: reviewMoveCMD, id: id, turn: &turnCpy}))
+}
 
-	return nil
+// OfferAbort offers the other gamer to abort the game in progress.
+// The offer is valid until the game is over.
+func (g *Game) OfferAbort(id int) error {
+	return g.OfferAbortContext(context.Background(), id)
+}
+
+// OfferAbortContext is like OfferAbort, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) OfferAbortContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: offerAbortCMD, id: id}))
+}
+
+// AcceptAbort accepts the offer of the other gamer to abort the game.
+// The game is over without a winner, Result reports igame.AbortMethod.
+func (g *Game) AcceptAbort(id int) error {
+	return g.AcceptAbortContext(context.Background(), id)
+}
+
+// AcceptAbortContext is like AcceptAbort, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) AcceptAbortContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: acceptAbortCMD, id: id}))
+}
+
+// Pause requests to pause the game, the game is paused when both gamers requested it.
+// Clocks of the paused game are stopped and moves are rejected with ErrPaused.
+// Gamers awaiting a turn are informed with ErrPaused.
+func (g *Game) Pause(id int) error {
+	return g.PauseContext(context.Background(), id)
+}
+
+// PauseContext is like Pause, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) PauseContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: pauseCMD, id: id}))
+}
+
+// Resume requests to resume the paused game, the game is resumed when both gamers requested it.
+func (g *Game) Resume(id int) error {
+	return g.ResumeContext(context.Background(), id)
+}
+
+// ResumeContext is like Resume, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) ResumeContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: resumeCMD, id: id}))
+}
+
+// StartVacation pauses the clock of the gamer with id in correspondence games
+// until EndVacation is called or the vacation time of the gamer is over.
+func (g *Game) StartVacation(id int) error {
+	return g.StartVacationContext(context.Background(), id)
+}
+
+// StartVacationContext is like StartVacation, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) StartVacationContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: startVacationCMD, id: id}))
+}
+
+// EndVacation finishes the vacation of the gamer with id and resumes the clock of the gamer.
+func (g *Game) EndVacation(id int) error {
+	return g.EndVacationContext(context.Background(), id)
+}
+
+// EndVacationContext is like EndVacation, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) EndVacationContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: endVacationCMD, id: id}))
+}
+
+// Swap swaps colours of gamers by the pie rule.
+// It's allowed only for the white gamer right after the first move of black,
+// if the game is created with WithPieRule option.
+func (g *Game) Swap(id int) error {
+	return g.SwapContext(context.Background(), id)
+}
+
+// SwapContext is like Swap, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) SwapContext(ctx context.Context, id int) error {
+	return errorOf(g.request(ctx, &gameCommand{act: swapCMD, id: id}))
+}
+
+// SetKomi sets komi of the game instead of swapping colours by the pie rule.
+// It's allowed in the same cases as Swap.
+func (g *Game) SetKomi(id int, komi float64) error {
+	return g.SetKomiContext(context.Background(), id, komi)
+}
+
+// SetKomiContext is like SetKomi, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) SetKomiContext(ctx context.Context, id int, komi float64) error {
+	return errorOf(g.request(ctx, &gameCommand{act: setKomiCMD, id: id, komi: komi}))
 }
 
 // Leave leave a game.
 // No methods of this Game object should be invoked by this gamer
 // after this call - it will return an error.
-func (g Game) Leave(id int) (err error) {
-	// gamer leaving can close the Game object as chanel,
-	// it could cause a panic in other goroutines. process it.
-	defer recoverAsErr(&err)
-
-	c := make(chan interface{})
-	g <- &gameCommand{act: leaveCMD, id: id, rez: c}
+// Repeated Leave of the gamer returns ErrAlreadyLeft,
+// even if the game is destroyed after the last gamer left it.
+func (g *Game) Leave(id int) error {
+	return g.LeaveContext(context.Background(), id)
+}
 
-	if err, ok := (<-c).(error); ok == true {
-		return err
+// LeaveContext is like Leave, but sending of the query and awaiting of the reply are cancelled by ctx.
+func (g *Game) LeaveContext(ctx context.Context, id int) error {
+	err := errorOf(g.request(ctx, &gameCommand{act: leaveCMD, id: id}))
+	if errors.Is(err, ErrGameDestroyed) && g.hasLeft(id) {
+		return opError("leaveGame", id, ErrAlreadyLeft)
 	}
-
-	return nil
+	return err
 }
 
 // GamerState struct provides game internal data for one gamer.
 type GamerState struct {
-	Colour      igame.ChipColour   // colour of chip of this gamer
-	Name        string             //this gamer's name
-	beMSGChan   chan<- interface{} // delayed inform for WaitBegin's client
-	turnMSGChan chan<- interface{} // delayed inform for WaitTurn's client
+	Colour          igame.ChipColour   // colour of chip of this gamer
+	Name            string             //this gamer's name
+	beMSGChan       chan<- interface{} // delayed inform for WaitBegin's client
+	turnMSGChan     chan<- interface{} // delayed inform for WaitTurn's client
+	Remaining       time.Duration      // remaining time of the gamer, 0 if the game has no time control
+	Vacation        time.Duration      // remaining vacation time of the gamer
+	OnVacation      bool               // the gamer's clock is paused by the vacation
+	rematchMSGChan  chan<- interface{} // delayed inform for OfferRematch's client
+	vacationStarted time.Time          // time the current vacation started
+	vacationTimer   Timer              // timer to finish the vacation
+	Disconnected    bool               // the gamer disconnected and can rejoin within the grace period
+	disconnected    time.Time          // time the gamer disconnected
+	graceTimer      Timer              // timer to finish the grace period
+	Captures        int                // number of chips of the opponent captured by the gamer
+	Moves           int                // number of moves made by the gamer, passes included
+	TimeUsed        time.Duration      // time the gamer spent on the moves made
+	Seat            int                // order of the gamer in the team of rengo, 0 for the gamer moving first
+	Timeouts        int                // number of move deadlines missed by the gamer
 }
 
 // NewGame creates the Game.
 // Game mast be finished  by calling of End() method.
-func NewGame(size int, komi float64) (Game, error) {
-	field, err := field.New(size, komi)
+func NewGame(size int, komi float64, opts ...Option) (*Game, error) {
+	cfg := &config{komi: komi}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return newGame(size, cfg)
+}
+
+// NewGameWithMaster creates the Game played on master instead of the field.
+// Komi of the game is komi of master. Options of the field set by WithFieldOptions are ignored,
+// handicap chips are put only if master implements Setup([]igame.Placement) error.
+// The Game can't be rematched.
+// Game mast be finished  by calling of End() method.
+func NewGameWithMaster(master igame.Master, opts ...Option) (*Game, error) {
+	cfg := &config{komi: master.State().Komi, injected: true}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if err := setupHandicap(master, cfg.handicap); err != nil {
+		return nil, err
+	}
+	g := newGameHandle()
+	g.run(make(map[int]*GamerState), newDescriptor(master, cfg))
+	return g, nil
+}
+
+// newGame creates a new game object with settings of cfg.
+func newGame(size int, cfg *config) (*Game, error) {
+	if cfg.handicap > 0 {
+		cfg.komi = handicapKomi
+	}
+	field, err := field.New(size, cfg.komi, cfg.fieldOptions...)
 	if err != nil {
 		return nil, err
 	}
-	g := make(Game)
-	g.run(field)
+	if err := setupHandicap(field, cfg.handicap); err != nil {
+		return nil, err
+	}
+	g := newGameHandle()
+	g.run(make(map[int]*GamerState), newDescriptor(field, cfg))
 	return g, nil
 }
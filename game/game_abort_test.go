@@ -0,0 +1,73 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestAbort(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	if err := game.AcceptAbort(white); !errors.Is(err, ErrNoAbortOffer) {
+		t.Errorf("Unexpected AcceptAbort err without offer:\nwant: %v,\ngot: %v", ErrNoAbortOffer, err)
+	}
+	if err := game.OfferAbort(black); err != nil {
+		t.Fatalf("Unexpected OfferAbort err: %v", err)
+	}
+	if err := game.AcceptAbort(black); !errors.Is(err, ErrNoAbortOffer) {
+		t.Errorf("Unexpected AcceptAbort err by offerer:\nwant: %v,\ngot: %v", ErrNoAbortOffer, err)
+	}
+	if err := game.AcceptAbort(white); err != nil {
+		t.Fatalf("Unexpected AcceptAbort err: %v", err)
+	}
+
+	result, err := game.Result(white)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if result.Winner != igame.NoColour || result.Method != igame.AbortMethod {
+		t.Errorf("Unexpected Result of aborted game: %v", result)
+	}
+	if err := game.OfferAbort(black); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Unexpected OfferAbort err after the game:\nwant: %v,\ngot: %v", ErrGameOver, err)
+	}
+	if record, err := game.SGF(black); err != nil || !strings.Contains(record, "RE[Void]") {
+		t.Errorf("Unexpected SGF of aborted game: %s, err: %v", record, err)
+	}
+}
+
+// TestAbortZeroID checks the abort offered by the gamer with id 0.
+func TestAbortZeroID(t *testing.T) {
+	game, zero, other := zeroGame(t)
+	defer game.End()
+
+	if err := game.OfferAbort(zero); err != nil {
+		t.Fatalf("Unexpected OfferAbort err: %v", err)
+	}
+	if err := game.AcceptAbort(other); err != nil {
+		t.Fatalf("Unexpected AcceptAbort err: %v", err)
+	}
+	if result, err := game.Result(other); err != nil || result.Method != igame.AbortMethod {
+		t.Errorf("Unexpected Result of aborted game: %v, err: %v", result, err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// bookStub is an opening book with moves for any position
+type bookStub []igame.BookMove
+
+func (b bookStub) Lookup(hash uint64) []igame.BookMove {
+	return b
+}
+
+func TestBookMoves(t *testing.T) {
+	black := igame.BookMove{Colour: igame.Black, Position: igame.TurnData{X: 3, Y: 3}}
+	white := igame.BookMove{Colour: igame.White, Position: igame.TurnData{X: 7, Y: 7}}
+	book := bookStub{black, white}
+
+	game, err := NewGame(usualSize, usualKomi, WithOpeningBook(book))
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+	gamers := copyGamers(validGamers)
+	joinGamers(&commonArgs{t: t, game: game, gamers: gamers})
+
+	want := []igame.BookMove{black}
+	if moves, err := game.BookMoves(gamers[0].ID); err != nil || !reflect.DeepEqual(moves, want) {
+		t.Errorf("Unexpected BookMoves result:\nwant: %v,\ngot: %v, %v.", want, moves, err)
+	}
+	if _, err := game.BookMoves(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected BookMoves err:\nwant: %v,\ngot: %v.", ErrUnknownID, err)
+	}
+}
+
+// TestBookMovesFiltered checks that only legal moves of the book are returned
+// and nothing is returned to gamers of blind go.
+func TestBookMovesFiltered(t *testing.T) {
+	legal := igame.BookMove{Colour: igame.White, Position: igame.TurnData{X: 7, Y: 7}}
+	book := bookStub{
+		{Colour: igame.White, Position: igame.TurnData{X: 5, Y: 5}},
+		{Colour: igame.White, Position: igame.TurnData{X: 0, Y: 3}},
+		{Colour: igame.White, Position: igame.TurnData{X: usualSize + 1, Y: 3}},
+		{Colour: igame.Black, Position: igame.TurnData{X: 3, Y: 3}},
+		legal,
+	}
+
+	tests := []struct {
+		caseName string
+		opts     []Option
+		want     []igame.BookMove
+	}{
+		{caseName: "open", opts: []Option{WithOpeningBook(book)}, want: []igame.BookMove{legal}},
+		{caseName: "blind", opts: []Option{WithOpeningBook(book), WithBlindGo(false)}, want: []igame.BookMove{}},
+	}
+	for _, test := range tests {
+		t.Run(test.caseName, func(t *testing.T) {
+			game, _, white := pieGame(t, test.opts...)
+			defer game.End()
+
+			if moves, err := game.BookMoves(white); err != nil || !reflect.DeepEqual(moves, test.want) {
+				t.Errorf("Unexpected BookMoves result:\nwant: %v,\ngot: %v, %v.", test.want, moves, err)
+			}
+		})
+	}
+}
+
+// TestBookMovesHandicap checks that book moves of white are returned before the first move of the handicap game.
+func TestBookMovesHandicap(t *testing.T) {
+	white := igame.BookMove{Colour: igame.White, Position: igame.TurnData{X: 7, Y: 7}}
+	book := bookStub{{Colour: igame.Black, Position: igame.TurnData{X: 3, Y: 4}}, white}
+
+	game, err := NewGame(usualSize, usualKomi, WithHandicap(2), WithOpeningBook(book))
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+	gamers := copyGamers(validGamers)
+	joinGamers(&commonArgs{t: t, game: game, gamers: gamers})
+
+	want := []igame.BookMove{white}
+	if moves, err := game.BookMoves(gamers[0].ID); err != nil || !reflect.DeepEqual(moves, want) {
+		t.Errorf("Unexpected BookMoves result:\nwant: %v,\ngot: %v, %v.", want, moves, err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSay(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+	spectator := &Gamer{Name: "Watcher", ID: 4}
+	if err := game.Watch(spectator); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+
+	events, cancel := game.Subscribe(white)
+	defer cancel()
+
+	if err := game.Say(black, "hello"); err != nil {
+		t.Fatalf("Unexpected Say err: %v", err)
+	}
+	if err := game.Say(spectator.ID, "good luck"); err != nil {
+		t.Fatalf("Unexpected Say err of spectator: %v", err)
+	}
+	if err := game.Say(invalidGamer.ID, "hi"); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Say err of foreign gamer:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+
+	ev := nextEvent(t, events)
+	if ev.Kind != ChatEvent || ev.Message == nil || ev.Message.Text != "hello" {
+		t.Errorf("Unexpected chat event:\nwant: message \"hello\",\ngot: %+v", ev)
+	}
+
+	history, err := game.ChatHistory(spectator.ID)
+	if err != nil {
+		t.Fatalf("Unexpected ChatHistory err: %v", err)
+	}
+	gs, _ := game.GamerState(black)
+	want := []ChatMessage{
+		{ID: black, Name: gs.Name, Text: "hello"},
+		{ID: spectator.ID, Name: spectator.Name, Text: "good luck"},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("Unexpected ChatHistory:\nwant: %v,\ngot: %v", want, history)
+	}
+}
+
+func TestSayMutedSpectators(t *testing.T) {
+	game, black, _ := pieGame(t, WithMutedSpectators())
+	defer game.End()
+	spectator := &Gamer{Name: "Watcher", ID: 4}
+	if err := game.Watch(spectator); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+
+	if err := game.Say(spectator.ID, "good luck"); !errors.Is(err, ErrMuted) {
+		t.Errorf("Unexpected Say err of muted spectator:\nwant: %v,\ngot: %v", ErrMuted, err)
+	}
+	if err := game.Say(black, "hello"); err != nil {
+		t.Errorf("Unexpected Say err of gamer: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// countingGame creates the game with joined gamers where black put a chip
+// and then both gamers passed, and returns ids of black and white gamers
+func countingGame(t *testing.T) (game *Game, black, white int) {
+	game, black, white = pieGame(t)
+
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	if err := game.MarkDead(black, &igame.TurnData{X: 5, Y: 5}); !errors.Is(err, ErrNotCounting) {
+		t.Errorf("Unexpected MarkDead err before counting:\nwant: %v,\ngot: %v.", ErrNotCounting, err)
+	}
+	if err := game.Pass(black); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	return game, black, white
+}
+
+func TestAcceptScore(t *testing.T) {
+	game, black, white := countingGame(t)
+	defer game.End()
+
+	if err := game.MarkDead(white, &igame.TurnData{X: 1, Y: 1}); err == nil {
+		t.Errorf("Unexpected MarkDead success on empty point")
+	}
+	if err := game.MarkDead(white, nil); !errors.Is(err, ErrInvalidTurn) {
+		t.Errorf("Unexpected MarkDead err without position:\nwant: %v,\ngot: %v.", ErrInvalidTurn, err)
+	}
+	if err := game.MarkDead(white, &igame.TurnData{X: 0, Y: 5}); !errors.Is(err, ErrInvalidTurn) {
+		t.Errorf("Unexpected MarkDead err out of the field:\nwant: %v,\ngot: %v.", ErrInvalidTurn, err)
+	}
+	if err := game.AcceptScore(black); err != nil {
+		t.Fatalf("Unexpected AcceptScore err: %v", err)
+	}
+	if err := game.MarkDead(white, &igame.TurnData{X: 5, Y: 5}); err != nil {
+		t.Fatalf("Unexpected MarkDead err: %v", err)
+	}
+	if err := game.AcceptScore(white); err != nil {
+		t.Fatalf("Unexpected AcceptScore err: %v", err)
+	}
+	if _, err := game.Result(white); !errors.Is(err, ErrGameNotOver) {
+		t.Errorf("Unexpected Result err after marking dead chips:\nwant: %v,\ngot: %v.", ErrGameNotOver, err)
+	}
+	if err := game.AcceptScore(black); err != nil {
+		t.Fatalf("Unexpected AcceptScore err: %v", err)
+	}
+
+	result, err := game.Result(black)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if result.Winner != igame.White || result.Method != igame.ScoreMethod {
+		t.Errorf("Unexpected Result with dead black chip:\nwant: win of white by score,\ngot: %v.", result)
+	}
+	if err := game.ResumePlay(black); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Unexpected ResumePlay err after the game is over:\nwant: %v,\ngot: %v.", ErrGameOver, err)
+	}
+}
+
+func TestResumePlay(t *testing.T) {
+	game, black, white := countingGame(t)
+	defer game.End()
+
+	if err := game.ResumePlay(white); err != nil {
+		t.Fatalf("Unexpected ResumePlay err: %v", err)
+	}
+	if igt, _ := game.IsMyTurn(black); igt != true {
+		t.Errorf("Unexpected IsMyTurn of black gamer passed last:\nwant: true,\ngot: %v.", igt)
+	}
+	if err := game.AcceptScore(white); !errors.Is(err, ErrNotCounting) {
+		t.Errorf("Unexpected AcceptScore err after ResumePlay:\nwant: %v,\ngot: %v.", ErrNotCounting, err)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Errorf("Unexpected MakeTurn err after ResumePlay: %v", err)
+	}
+}
@@ -27,7 +27,7 @@ import (
 
 type waitGameRoutineParam struct {
 	ctx   context.Context
-	game  Game
+	game  *Game
 	gamer *Gamer
 	ch    chan<- error
 }
@@ -42,18 +42,18 @@ type checkWaitingNegativeParam struct {
 type commonArgs struct {
 	ctx    context.Context
 	t      *testing.T
-	game   Game
+	game   *Game
 	gamers []*Gamer
 	chans  []chan error
 	dur    time.Duration
 }
 
-func asyncGameEnd(game Game) (signal <-chan interface{}) {
+func asyncGameEnd(game *Game) (signal <-chan interface{}) {
 	c := make(chan interface{})
 
 	go func(c chan<- interface{}) {
 		game.End()
-		_, ok := <-game
+		_, ok := <-game.done
 		c <- ok
 		close(c)
 	}(c)
@@ -193,7 +193,7 @@ func checkWaitingNegative(par *checkWaitingNegativeParam) {
 
 func checkWaitingBreak(t *testing.T, ch chan error, dur time.Duration) {
 	want1 := ErrOtherGamerLeft
-	want2 := ErrResourceNotAvailable
+	want2 := ErrGameDestroyed
 
 	select {
 	case err, ok := <-ch:
@@ -288,3 +288,23 @@ func testFunctionsGameover(par *commonArgs, extraGamer *Gamer) {
 		par.t.Errorf("unexpected GamerState err:\nwant: nil,\ngot: %v", err)
 	}
 }
+
+// zeroGame returns the begun game of gamers with ids 0 and 1,
+// where the gamer with id 0 is to move
+func zeroGame(t *testing.T, opts ...Option) (game *Game, zero, other int) {
+	game, err := NewGame(usualSize, usualKomi, opts...)
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	joinGamers(&commonArgs{t: t, game: game, gamers: []*Gamer{{Name: "Zero", ID: 0}, {Name: "One", ID: 1}}})
+
+	zero, other = 0, 1
+	if gs, err := game.GamerState(zero); err != nil {
+		t.Fatalf("Unexpected GamerState err: %v", err)
+	} else if gs.Colour == igame.White {
+		if err := game.MakeTurn(other, &igame.TurnData{X: 5, Y: 5}); err != nil {
+			t.Fatalf("Unexpected MakeTurn err: %v", err)
+		}
+	}
+	return game, zero, other
+}
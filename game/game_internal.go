@@ -17,9 +17,9 @@
 package game
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
-	"strings"
 	"time"
 
 	"github.com/yagoggame/gomaster/game/igame"
@@ -30,45 +30,95 @@ type gameAction int
 
 // set of actions values of Game object
 const (
-	joinCMD        gameAction = iota //join This Game
-	endCMD                           //finish this game
-	gamerStateCMD                    //request state of gamer
-	gameStateCMD                     //request state of game
-	gameFieldSize                    //request size of game field
-	makeTurnCMD                      //make a turn
-	isGameBegunCMD                   //request of state to avoid of wBeginCMD
-	isMyTurnCMD                      //request of state to avoid of wTurnCMD
-	leaveCMD                         //leave a game
+	joinCMD            gameAction = iota //join This Game
+	endCMD                               //finish this game
+	gamerStateCMD                        //request state of gamer
+	gameStateCMD                         //request state of game
+	gameFieldSize                        //request size of game field
+	makeTurnCMD                          //make a turn
+	passCMD                              //pass a turn
+	resignCMD                            //resign a game
+	isGameBegunCMD                       //request of state to avoid of wBeginCMD
+	isMyTurnCMD                          //request of state to avoid of wTurnCMD
+	leaveCMD                             //leave a game
+	swapCMD                              //swap colours by the pie rule
+	setKomiCMD                           //set komi instead of swap
+	bookMovesCMD                         //request moves of the opening book
+	resultCMD                            //request result of the finished game
+	markDeadCMD                          //mark dead chips in counting phase
+	acceptScoreCMD                       //accept the score in counting phase
+	resumePlayCMD                        //resume the play in counting phase
+	requestUndoCMD                       //request to revert the last move
+	answerUndoCMD                        //answer the undo request
+	offerRematchCMD                      //offer a rematch
+	acceptRematchCMD                     //accept a rematch
+	subscribeCMD                         //subscribe to events of the game
+	unsubscribeCMD                       //cancel a subscription
+	watchCMD                             //attach a spectator
+	stopWatchingCMD                      //detach a spectator
+	spectatorsCMD                        //request number of spectators
+	sayCMD                               //say in the chat
+	chatHistoryCMD                       //request messages of the chat
+	timeoutCMD                           //report running out of time
+	startVacationCMD                     //pause the clock of a gamer
+	endVacationCMD                       //resume the clock of a gamer
+	vacationOverCMD                      //report running out of vacation time
+	clocksCMD                            //request states of clocks
+	historyCMD                           //request moves made in the game
+	sgfCMD                               //request the game record
+	snapshotCMD                          //request the state of the game to persist it
+	disconnectCMD                        //start the reconnect grace period of a gamer
+	rejoinCMD                            //finish the reconnect grace period of a gamer
+	graceOverCMD                         //report running out of reconnect grace period
+	pauseCMD                             //request or agree to pause the game
+	resumeCMD                            //request or agree to resume the game
+	offerAbortCMD                        //offer to abort the game
+	acceptAbortCMD                       //accept the offer to abort the game
+	statsCMD                             //request statistics of the game
+	statusCMD                            //request the status of the game
+	scoreCMD                             //request the estimate of the score
+	inactiveCMD                          //report running out of the inactivity timeout
+	proposeSettingsCMD                   //propose changed settings of the game
+	answerSettingsCMD                    //answer the proposal of settings
+	settingsCMD                          //request the effective settings of the game
+	gamersCMD                            //request states of all gamers
+	reviewPositionCMD                    //request the position of the cursor of the review
+	reviewGotoCMD                        //move the cursor of the review
+	reviewMoveCMD                        //make a move in the review
+	rejectScoreCMD                       //reject the score in counting phase
+	forceEndCMD                          //finish the game by the administrator
+	resyncCMD                            //resend the whole state to subscriptions of deltas
+	callbackCMD                          //register a callback of moves or of the end of the game
 
 	//action, which can cause an awaiting
 	wBeginCMD //wait of game begin
 	wTurnCMD  //wait for your turn
 )
 
-// gameCommand is a type to hold a comand to a Game
-type gameCommand struct {
-	act   gameAction
-	gamer *Gamer
-	id    int
-	rez   chan<- interface{}
-	turn  *igame.TurnData
+// awaitings is a set of actions replied when the awaited event happens,
+// they are dropped by cancelWait on cancellation
+var awaitings = map[gameAction]bool{
+	wBeginCMD:       true,
+	wTurnCMD:        true,
+	offerRematchCMD: true,
 }
 
-// recoverAsErr processes the panic
-// on any action after closing the Game as chanel
-func recoverAsErr(err *error) {
-	r := recover()
-	if r == nil {
-		return
-	}
-
-	if errR, ok := r.(error); ok == true {
-		*err = errR
-		if strings.Compare((*err).Error(), "send on closed channel") != 0 {
-			panic(r)
-		}
-		*err = ErrResourceNotAvailable
-	}
+// gameCommand is a type to hold a comand to a Game
+type gameCommand struct {
+	act      gameAction
+	gamer    *Gamer
+	id       int
+	rez      chan<- interface{}
+	turn     *igame.TurnData
+	komi     float64
+	accept   bool
+	sub      *subscription
+	text     string
+	settings *Settings
+	colour   igame.ChipColour
+	waiter   chan<- interface{}
+	path     []int
+	meta     map[string]string
 }
 
 // Process queries
@@ -78,7 +128,12 @@ func recoverAsErr(err *error) {
 func join(gamerStates *map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
 	defer close(cmd.rez)
 
-	if len(*gamerStates) > 1 {
+	if _, ok := (*gamerStates)[cmd.gamer.ID]; ok == true {
+		cmd.rez <- opError("join", cmd.gamer.ID, ErrAlreadyJoined)
+		return
+	}
+
+	if len(*gamerStates) >= gd.cfg.seats() {
 		cmd.rez <- ErrNoPlace
 		return
 	}
@@ -88,31 +143,68 @@ func join(gamerStates *map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor
 		return
 	}
 
-	chipColour := igame.ChipColour(rand.Intn(2) + 1)
-	for id := range *gamerStates {
-		chipColour = igame.ChipColour(3 - int((*gamerStates)[id].Colour))
+	if _, ok := gd.spectators[cmd.gamer.ID]; ok == true {
+		cmd.rez <- opError("join", cmd.gamer.ID, ErrAlreadyJoined)
+		return
+	}
+
+	chipColour, seat, ok := takeSeat(*gamerStates, cmd.colour, igame.ChipColour(gd.cfg.intn(2)+1), gd.cfg)
+	if ok == false {
+		cmd.rez <- ErrNoPlace
+		return
 	}
 
 	(*gamerStates)[cmd.gamer.ID] = &GamerState{
-		Colour: chipColour,
-		Name:   cmd.gamer.Name,
+		Colour:    chipColour,
+		Name:      cmd.gamer.Name,
+		Remaining: gd.cfg.mainTime,
+		Vacation:  gd.cfg.vacation,
+		Seat:      seat,
+	}
+	if gd.players[chipColour] != "" {
+		gd.players[chipColour] += " & " + cmd.gamer.Name
+	} else {
+		gd.players[chipColour] = cmd.gamer.Name
+	}
+	if gd.cfg.nigiri && !gd.cfg.rengo && len(*gamerStates) == 2 {
+		nigiri(*gamerStates, cmd.gamer.ID, gd)
+	}
+
+	publish(gd.subscribers, GameEvent{Kind: JoinedEvent, ID: cmd.gamer.ID, Colour: (*gamerStates)[cmd.gamer.ID].Colour})
+	if len(*gamerStates) == gd.cfg.seats() {
+		gd.begun = gd.cfg.timeSource().Now()
+		publish(gd.subscribers, GameEvent{Kind: BegunEvent, Nigiri: gd.nigiri.copy()})
 	}
 }
 
 // gamerState implements concurrently safe processing of querry of
 // GamerState function
-func gamerState(gamerStates map[int]*GamerState, cmd *gameCommand) {
+func gamerState(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
 	defer close(cmd.rez)
 
 	gs, ok := gamerStates[cmd.id]
 	if ok == false {
-		cmd.rez <- fmt.Errorf("failed to gamerState for gamer with id %d: %w", cmd.id, ErrUnknownID)
+		cmd.rez <- opError("gamerState", cmd.id, ErrUnknownID)
 		return
 	}
 
-	//make a copy of gamer state to prevent change from the outside
+	cmd.rez <- copyGamerState(gs, cmd.id, gd)
+}
+
+// copyGamerState makes a copy of the state of the gamer with id
+// with the clocks on the current time to prevent change from the outside
+func copyGamerState(gs *GamerState, id int, gd *gmaeDescriptor) *GamerState {
 	gsCpy := *gs
-	cmd.rez <- &gsCpy
+	gsCpy.Remaining -= gd.spent(id)
+	gsCpy.Vacation -= vacationSpent(gs, gd.cfg)
+	gsCpy.Moves, gsCpy.TimeUsed = movesStats(gd, id)
+	captured := gd.master.State().ChipsCuptured
+	if gs.Colour == igame.Black {
+		gsCpy.Captures = captured[igame.White]
+	} else {
+		gsCpy.Captures = captured[igame.Black]
+	}
+	return &gsCpy
 }
 
 // fieldSize implements concurrently safe processing of querry of
@@ -122,7 +214,7 @@ func fieldSize(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescri
 
 	_, ok := gamerStates[cmd.id]
 	if ok == false {
-		cmd.rez <- fmt.Errorf("failed to fieldSize for gamer with id %d: %w", cmd.id, ErrUnknownID)
+		cmd.rez <- opError("fieldSize", cmd.id, ErrUnknownID)
 		return
 	}
 
@@ -134,21 +226,119 @@ func fieldSize(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescri
 func gameState(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
 	defer close(cmd.rez)
 
-	_, ok := gamerStates[cmd.id]
+	colour, ok := viewerColour(gamerStates, cmd.id, gd)
 	if ok == false {
-		cmd.rez <- fmt.Errorf("failed to fieldSize for gamer with id %d: %w", cmd.id, ErrUnknownID)
+		cmd.rez <- opError("gameState", cmd.id, ErrUnknownID)
+		return
+	}
+
+	cmd.rez <- stateFor(gd, colour)
+}
+
+// score implements concurrently safe processing of querry of
+// Score function
+func score(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	colour, ok := viewerColour(gamerStates, cmd.id, gd)
+	if ok == false {
+		cmd.rez <- opError("score", cmd.id, ErrUnknownID)
+		return
+	}
+	if hidden(gd, colour, igame.NoColour) {
+		cmd.rez <- opError("score", cmd.id, ErrBlind)
+		return
+	}
+	estimator, ok := gd.master.(igame.Estimator)
+	if !ok {
+		cmd.rez <- opError("score", cmd.id, ErrNoEstimate)
+		return
+	}
+
+	cmd.rez <- estimator.Estimate()
+}
+
+// stateFor returns the state of the game shown to the viewer playing by colour
+func stateFor(gd *gmaeDescriptor, colour igame.ChipColour) *igame.FieldState {
+	state := gd.master.State()
+	if viewer, ok := gd.master.(igame.Viewer); ok {
+		state = viewer.StateFor(colour)
+	}
+	state.Handicap = gd.cfg.handicap
+	return state
+}
+
+// booker is implemented by Masters which filter moves of the opening book by legality
+type booker interface {
+	BookMoves(book igame.OpeningBook) []igame.BookMove
+}
+
+// bookMoves implements concurrently safe processing of querry of
+// BookMoves function
+func bookMoves(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, ok := gamerStates[cmd.id]
+	if ok == false {
+		cmd.rez <- opError("bookMoves", cmd.id, ErrUnknownID)
+		return
+	}
+
+	moves := make([]igame.BookMove, 0)
+	// the position looked up in the book would reveal hidden chips of blind go.
+	if gd.book == nil || hidden(gd, gs.Colour, igame.ChipColour(3-int(gs.Colour))) {
+		cmd.rez <- moves
+		return
+	}
+	if master, ok := gd.master.(booker); ok == true {
+		cmd.rez <- master.BookMoves(gd.book)
 		return
 	}
+	size := gd.master.Size()
+	for _, move := range gd.book.Lookup(gd.master.State().Hash) {
+		td := move.Position
+		if td.X < 1 || td.Y < 1 || td.X > size || td.Y > size {
+			continue
+		}
+		if isMyTurnCalc(gd.currentTurn, move.Colour) {
+			moves = append(moves, move)
+		}
+	}
+	cmd.rez <- moves
+}
+
+// gameResult implements concurrently safe processing of querry of
+// Result function
+func gameResult(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
 
-	cmd.rez <- gd.master.State()
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("gameResult", cmd.id, ErrUnknownID)
+		return
+	}
+	if gd.gameOver == false {
+		cmd.rez <- opError("gameResult", cmd.id, ErrGameNotOver)
+		return
+	}
+
+	cmd.rez <- finalResult(gd)
+}
+
+// finalResult returns the result of the finished game
+func finalResult(gd *gmaeDescriptor) *igame.Result {
+	if gd.result != nil {
+		rez := *gd.result
+		return &rez
+	}
+	return gd.master.State().Result
 }
 
 // waitBegin implements concurrently safe processing of querry of
 // WaitBegin function
 func waitBegin(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
-	gs, err := getGamerStateAndChecks(gamerStates, cmd.id, gd.gameOver)
+	gs, err := getGamerStateAndChecks(gamerStates, "waitBegin", cmd.id, gd.gameOver)
 	if err != nil {
-		cmd.rez <- err
+		cmd.rez <- waitError(gamerStates, cmd.id, gd, err)
 		close(cmd.rez)
 		return
 	}
@@ -157,9 +347,9 @@ func waitBegin(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescri
 	gs.beMSGChan = cmd.rez
 
 	//if number of players enough to begin a game - report to all players.
-	if len(gamerStates) == 2 {
-		for _, gs := range gamerStates {
-			reportOnChan(&gs.beMSGChan, nil)
+	if len(gamerStates) == gd.cfg.seats() {
+		for id, gs := range gamerStates {
+			reportOnChan(&gs.beMSGChan, waitResult(gamerStates, id, gd, GameBegunReason))
 		}
 	}
 }
@@ -169,26 +359,34 @@ func waitBegin(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescri
 func isGameBegun(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
 	defer close(cmd.rez)
 
-	_, err := getGamerStateAndChecks(gamerStates, cmd.id, gd.gameOver)
+	_, err := getGamerStateAndChecks(gamerStates, "isGameBegun", cmd.id, gd.gameOver)
 	if err != nil {
 		cmd.rez <- err
 		return
 	}
 
-	cmd.rez <- len(gamerStates) == 2
+	cmd.rez <- len(gamerStates) == gd.cfg.seats()
 }
 
 // waitTurn implements concurrently safe processing of querry of
 // WaitTurn function
 func waitTurn(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
-	gs, err := getGamerStateAndChecks(gamerStates, cmd.id, gd.gameOver)
+	gs, err := getGamerStateAndChecks(gamerStates, "waitTurn", cmd.id, gd.gameOver)
 	if err != nil {
-		cmd.rez <- err
+		cmd.rez <- waitError(gamerStates, cmd.id, gd, err)
 		close(cmd.rez)
 		return
 	}
 
-	if isMyTurnCalc(gd.currentTurn, gs.Colour) {
+	if gd.counting {
+		cmd.rez <- waitResult(gamerStates, cmd.id, gd, CountingReason)
+		close(cmd.rez)
+		return
+	}
+
+	// the turn of paused game begins on resumption.
+	if isGamersTurn(gd.currentTurn, gs, gd.cfg) && !gd.paused {
+		cmd.rez <- waitResult(gamerStates, cmd.id, gd, TurnBegunReason)
 		close(cmd.rez)
 		return
 	}
@@ -197,18 +395,33 @@ func waitTurn(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescrip
 	gs.turnMSGChan = cmd.rez
 }
 
+// cancelWait drops the awaiting of the gamer with id cancelled by the context.
+// The channel of the cancelled query is closed and forgotten, so
+// nothing is delivered to it later
+func cancelWait(gamerStates map[int]*GamerState, cmd *gameCommand) {
+	gs, ok := gamerStates[cmd.id]
+	if ok == false {
+		return
+	}
+	for _, ch := range []*chan<- interface{}{&gs.beMSGChan, &gs.turnMSGChan, &gs.rematchMSGChan} {
+		if *ch == cmd.waiter {
+			reportOnChan(ch, nil)
+		}
+	}
+}
+
 // isMyTurn implements concurrently safe processing of querry of
 // IsMyTurn function
 func isMyTurn(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
 	defer close(cmd.rez)
 
-	gs, err := getGamerStateAndChecks(gamerStates, cmd.id, gd.gameOver)
+	gs, err := getGamerStateAndChecks(gamerStates, "isMyTurn", cmd.id, gd.gameOver)
 	if err != nil {
 		cmd.rez <- err
 		return
 	}
 
-	cmd.rez <- isMyTurnCalc(gd.currentTurn, gs.Colour)
+	cmd.rez <- isGamersTurn(gd.currentTurn, gs, gd.cfg)
 }
 
 // makeTurn implements concurrently safe processing of querry of
@@ -217,50 +430,690 @@ func isMyTurn(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescrip
 func makeTurn(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) int {
 	defer close(cmd.rez)
 
-	gs, err := getGamerStateAndChecks(gamerStates, cmd.id, gd.gameOver)
+	gs, err := getGamerStateAndChecks(gamerStates, "makeTurn", cmd.id, gd.gameOver)
 	if err != nil {
 		cmd.rez <- err
 		return 0
 	}
-	if !isMyTurnCalc(gd.currentTurn, gs.Colour) {
-		cmd.rez <- fmt.Errorf("failed to makeTurn for gamer with id %d: %w", cmd.id, ErrNotYourTurn)
+	if gd.counting {
+		cmd.rez <- opError("makeTurn", cmd.id, ErrCounting)
+		return 0
+	}
+	if gd.paused {
+		cmd.rez <- opError("makeTurn", cmd.id, ErrPaused)
+		return 0
+	}
+	if !isGamersTurn(gd.currentTurn, gs, gd.cfg) {
+		cmd.rez <- opError("makeTurn", cmd.id, ErrNotYourTurn)
 		return 0
 	}
 
-	if err := gd.master.Move(gs.Colour, cmd.turn); err != nil {
-		cmd.rez <- fmt.Errorf("failed to makeTurn for gamer with id %d: %w: %s", cmd.id, ErrWrongTurn, err)
+	colour := movingColour(gs, gd)
+	if err := gd.master.Move(colour, cmd.turn); err != nil {
+		cmd.rez <- opError("makeTurn", cmd.id, &wrongTurnError{err: err})
 		return 0
 	}
+	gd.undoMoves = 0
+	recordMove(gd, cmd.id, gs, igame.PlaceMove, cmd.turn, cmd.meta)
+	move := *cmd.turn
+	publishMove(gamerStates, gd, GameEvent{Kind: MoveMadeEvent, ID: cmd.id, Colour: colour, Move: &move})
 
-	reportOnTurnChange(gamerStates, gd.currentTurn)
+	reportOnTurnChange(gamerStates, gd.currentTurn, gd)
 
 	return 1
 }
 
+// pass implements concurrently safe processing of querry of
+// Pass function
+// return 1 on success pass, else - 0
+func pass(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) int {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "pass", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return 0
+	}
+	if gd.counting {
+		cmd.rez <- opError("pass", cmd.id, ErrCounting)
+		return 0
+	}
+	if gd.paused {
+		cmd.rez <- opError("pass", cmd.id, ErrPaused)
+		return 0
+	}
+	if !isGamersTurn(gd.currentTurn, gs, gd.cfg) {
+		cmd.rez <- opError("pass", cmd.id, ErrNotYourTurn)
+		return 0
+	}
+
+	if err := passTurn(gamerStates, cmd.id, gs, gd); err != nil {
+		cmd.rez <- opError("pass", cmd.id, &wrongTurnError{err: err})
+		return 0
+	}
+
+	return 1
+}
+
+// passTurn passes the turn of the gamer with id and informs other gamers.
+// The turn counter is not changed.
+func passTurn(gamerStates map[int]*GamerState, id int, gs *GamerState, gd *gmaeDescriptor) error {
+	colour := movingColour(gs, gd)
+	if err := gd.master.Pass(colour); err != nil {
+		return err
+	}
+	gd.undoMoves = 0
+	recordMove(gd, id, gs, igame.PassMove, nil, nil)
+	publishMove(gamerStates, gd, GameEvent{Kind: PassEvent, ID: id, Colour: colour})
+
+	// two passes in a row can finish the game.
+	if state := gd.master.State(); state.GameOver {
+		if state.Termination == igame.TwoPasses && canCount(gd.master) {
+			gd.counting = true
+			reportOnCounting(gamerStates, gd)
+			return nil
+		}
+		gd.gameOver = true
+		reportOnGameOver(gamerStates, gd)
+		return nil
+	}
+	reportOnTurnChange(gamerStates, gd.currentTurn, gd)
+
+	return nil
+}
+
+// resign implements concurrently safe processing of querry of
+// Resign function
+func resign(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "resign", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	colour := movingColour(gs, gd)
+	if err := gd.master.Resign(colour); err != nil {
+		cmd.rez <- opError("resign", cmd.id, err)
+		return
+	}
+	recordMove(gd, cmd.id, gs, igame.ResignMove, nil, nil)
+	publishMove(gamerStates, gd, GameEvent{Kind: ResignEvent, ID: cmd.id, Colour: colour})
+
+	gd.gameOver = true
+	reportOnGameOver(gamerStates, gd)
+}
+
+// canCount checks that the master supports counting phase
+func canCount(master igame.Master) bool {
+	_, isScorer := master.(igame.Scorer)
+	_, isUndoer := master.(igame.Undoer)
+	return isScorer && isUndoer
+}
+
+// checkCounting checks that the gamer with id can take part in counting
+func checkCounting(gamerStates map[int]*GamerState, op string, cmd *gameCommand, gd *gmaeDescriptor) error {
+	if _, err := getGamerStateAndChecks(gamerStates, op, cmd.id, gd.gameOver); err != nil {
+		return err
+	}
+	if !gd.counting {
+		return opError(op, cmd.id, ErrNotCounting)
+	}
+	return nil
+}
+
+// markDead implements concurrently safe processing of querry of
+// MarkDead function
+func markDead(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if err := checkCounting(gamerStates, "markDead", cmd, gd); err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	dead := append(append([]igame.TurnData(nil), gd.dead...), *cmd.turn)
+	if _, err := gd.master.(igame.Scorer).FinalScore(dead); err != nil {
+		cmd.rez <- opError("markDead", cmd.id, err)
+		return
+	}
+	gd.dead = dead
+	gd.accepted = nil
+}
+
+// acceptScore implements concurrently safe processing of querry of
+// AcceptScore function
+func acceptScore(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if err := checkCounting(gamerStates, "acceptScore", cmd, gd); err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	if gd.accepted == nil {
+		gd.accepted = make(map[int]bool, 2)
+	}
+	gd.accepted[cmd.id] = true
+	if len(gd.accepted) < len(gamerStates) {
+		return
+	}
+
+	result, err := gd.master.(igame.Scorer).FinalScore(gd.dead)
+	if err != nil {
+		cmd.rez <- opError("acceptScore", cmd.id, err)
+		return
+	}
+	gd.result = result
+	gd.counting = false
+	gd.gameOver = true
+	reportOnGameOver(gamerStates, gd)
+}
+
+// resumePlay implements concurrently safe processing of querry of
+// ResumePlay function
+// return -1 on success resumption, else - 0
+func resumePlay(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) int {
+	defer close(cmd.rez)
+
+	if err := checkCounting(gamerStates, "resumePlay", cmd, gd); err != nil {
+		cmd.rez <- err
+		return 0
+	}
+
+	if err := undoMove(gd); err != nil {
+		cmd.rez <- opError("resumePlay", cmd.id, err)
+		return 0
+	}
+	gd.counting = false
+	gd.dead = nil
+	gd.accepted = nil
+	gd.rejections = 0
+
+	// the turn returns to the gamer passed last.
+	reportOnTurnChange(gamerStates, gd.currentTurn-2, gd)
+	return -1
+}
+
+// movesOf returns the number of moves made by the gamer playing by colour
+// since the first turn of the game
+func movesOf(firstTurn, currentTurn int, colour igame.ChipColour) int {
+	if colour == igame.Black {
+		return (currentTurn+1)/2 - (firstTurn+1)/2
+	}
+	return currentTurn/2 - firstTurn/2
+}
+
+// requestUndo implements concurrently safe processing of querry of
+// RequestUndo function
+func requestUndo(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "requestUndo", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	if _, ok := gd.master.(igame.Undoer); !ok || gd.counting || movesOf(gd.cfg.firstTurn(), gd.currentTurn, gs.Colour) == 0 {
+		cmd.rez <- opError("requestUndo", cmd.id, ErrUndoNotAllowed)
+		return
+	}
+
+	gd.undoRequester = cmd.id
+	gd.undoMoves = 1
+	if isMyTurnCalc(gd.currentTurn, gs.Colour) {
+		gd.undoMoves = 2
+	}
+}
+
+// answerUndo implements concurrently safe processing of querry of
+// AnswerUndo function
+// return minus number of reverted moves
+func answerUndo(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) int {
+	defer close(cmd.rez)
+
+	if _, err := getGamerStateAndChecks(gamerStates, "answerUndo", cmd.id, gd.gameOver); err != nil {
+		cmd.rez <- err
+		return 0
+	}
+	if gd.undoMoves == 0 || gd.undoRequester == cmd.id {
+		cmd.rez <- opError("answerUndo", cmd.id, ErrNoUndoRequest)
+		return 0
+	}
+
+	undoMoves := gd.undoMoves
+	gd.undoMoves = 0
+	if !cmd.accept {
+		return 0
+	}
+
+	for i := 0; i < undoMoves; i++ {
+		if err := undoMove(gd); err != nil {
+			cmd.rez <- opError("answerUndo", cmd.id, err)
+			return -i
+		}
+	}
+
+	// the turn returns to the requesting gamer.
+	reportOnTurnChange(gamerStates, gd.currentTurn-undoMoves-1, gd)
+	return -undoMoves
+}
+
+// consentRequest is a request of a gamer awaiting the consent of the other gamer
+type consentRequest struct {
+	made bool // the request is made
+	id   int  // id of the gamer made the request
+}
+
+// consent registers the request of the gamer with id to req,
+// it returns true if the other gamer requested the same before
+func consent(req *consentRequest, id int) bool {
+	if req.made && req.id != id {
+		*req = consentRequest{}
+		return true
+	}
+	*req = consentRequest{made: true, id: id}
+	return false
+}
+
+// pause implements concurrently safe processing of querry of
+// Pause function
+func pause(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "pause", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	if gd.paused || !consent(&gd.pauseRequest, cmd.id) {
+		return
+	}
+
+	gd.paused = true
+	gd.resumeRequest = consentRequest{}
+	publish(gd.subscribers, GameEvent{Kind: PausedEvent, ID: cmd.id, Colour: gs.Colour})
+	for id, gs := range gamerStates {
+		reportOnChan(&gs.turnMSGChan, waitResult(gamerStates, id, gd, PausedReason))
+	}
+}
+
+// resume implements concurrently safe processing of querry of
+// Resume function
+func resume(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "resume", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	if !gd.paused {
+		cmd.rez <- opError("resume", cmd.id, ErrNotPaused)
+		return
+	}
+	if !consent(&gd.resumeRequest, cmd.id) {
+		return
+	}
+
+	gd.paused = false
+	gd.pauseRequest = consentRequest{}
+	publish(gd.subscribers, GameEvent{Kind: ResumedEvent, ID: cmd.id, Colour: gs.Colour})
+	reportOnTurnChange(gamerStates, gd.currentTurn-1, gd)
+}
+
+// offerAbort implements concurrently safe processing of querry of
+// OfferAbort function
+func offerAbort(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, err := getGamerStateAndChecks(gamerStates, "offerAbort", cmd.id, gd.gameOver); err != nil {
+		cmd.rez <- err
+		return
+	}
+	gd.hasAbortOffer, gd.abortOfferer = true, cmd.id
+}
+
+// acceptAbort implements concurrently safe processing of querry of
+// AcceptAbort function
+func acceptAbort(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, err := getGamerStateAndChecks(gamerStates, "acceptAbort", cmd.id, gd.gameOver); err != nil {
+		cmd.rez <- err
+		return
+	}
+	if !gd.hasAbortOffer || gd.abortOfferer == cmd.id {
+		cmd.rez <- opError("acceptAbort", cmd.id, ErrNoAbortOffer)
+		return
+	}
+
+	gd.hasAbortOffer = false
+	gd.result = &igame.Result{Winner: igame.NoColour, Method: igame.AbortMethod}
+	gd.counting = false
+	gd.gameOver = true
+	reportOnGameOver(gamerStates, gd)
+}
+
+// checkRematch checks that the gamer with id can negotiate a rematch
+func checkRematch(gamerStates map[int]*GamerState, op string, cmd *gameCommand, gd *gmaeDescriptor) (*GamerState, error) {
+	gs, ok := gamerStates[cmd.id]
+	if ok == false {
+		return nil, opError(op, cmd.id, ErrUnknownID)
+	}
+	if gd.gameOver == false {
+		return nil, opError(op, cmd.id, ErrGameNotOver)
+	}
+	if len(gamerStates) < gd.cfg.seats() {
+		return nil, opError(op, cmd.id, ErrOtherGamerLeft)
+	}
+	if gd.cfg.injected || gd.cfg.rengo || gd.cfg.teaching {
+		return nil, opError(op, cmd.id, ErrRematchNotAllowed)
+	}
+	return gs, nil
+}
+
+// offerRematch implements concurrently safe processing of querry of
+// OfferRematch function
+func offerRematch(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	gs, err := checkRematch(gamerStates, "offerRematch", cmd, gd)
+	if err != nil {
+		cmd.rez <- err
+		close(cmd.rez)
+		return
+	}
+
+	//put chanel to report on acceptance of the rematch in safe place.
+	reportOnChan(&gs.rematchMSGChan, ErrCancellation)
+	gs.rematchMSGChan = cmd.rez
+}
+
+// acceptRematch implements concurrently safe processing of querry of
+// AcceptRematch function
+func acceptRematch(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := checkRematch(gamerStates, "acceptRematch", cmd, gd)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	var offerer *Gamer
+	var offererState *GamerState
+	for id, other := range gamerStates {
+		if id != cmd.id && other.rematchMSGChan != nil {
+			offerer, offererState = &Gamer{ID: id, Name: other.Name}, other
+		}
+	}
+	if offerer == nil {
+		cmd.rez <- opError("acceptRematch", cmd.id, ErrNoRematchOffer)
+		return
+	}
+
+	// colours are swapped, so the accepting gamer gets the colour of the offering one.
+	cfg := *gd.cfg
+	cfg.firstColour = offererState.Colour
+	// the rematch is a new game, it isn't written to the store of this one
+	// and isn't moderated by the administrator of this one.
+	cfg.store, cfg.admin = nil, nil
+	if cfg.random != nil {
+		// the source can't be shared by games running concurrently.
+		cfg.random = rand.New(rand.NewSource(cfg.random.Int63()))
+	}
+	rematch, err := newGame(gd.master.Size(), &cfg)
+	if err == nil {
+		if err = rematch.Join(&Gamer{ID: cmd.id, Name: gs.Name}); err == nil {
+			err = rematch.Join(offerer)
+		}
+		if err != nil {
+			rematch.End()
+		}
+	}
+	if err != nil {
+		err = opError("acceptRematch", cmd.id, err)
+		reportOnChan(&offererState.rematchMSGChan, err)
+		cmd.rez <- err
+		return
+	}
+
+	reportOnChan(&offererState.rematchMSGChan, rematch)
+	cmd.rez <- rematch
+}
+
+// subscribe implements concurrently safe processing of querry of
+// Subscribe function
+func subscribe(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("subscribe", cmd.id, ErrUnknownID)
+		return
+	}
+	cmd.sub.id = cmd.id
+	gd.subscribers[cmd.sub] = true
+	if cmd.sub.deltas {
+		cmd.sub.resync(gamerStates, gd)
+	}
+}
+
+// unsubscribe implements concurrently safe processing of
+// cancellation of a subscription
+func unsubscribe(cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if gd.subscribers[cmd.sub] {
+		delete(gd.subscribers, cmd.sub)
+		cmd.sub.close()
+	}
+}
+
+// watch implements concurrently safe processing of querry of
+// Watch function
+func watch(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.gamer.ID, gd); ok == true {
+		cmd.rez <- opError("watch", cmd.gamer.ID, ErrAlreadyJoined)
+		return
+	}
+
+	gCpy := *cmd.gamer
+	gd.spectators[gCpy.ID] = &gCpy
+	publishSpectators(gd, SpectatorJoinedEvent, gCpy.ID)
+}
+
+// stopWatching implements concurrently safe processing of querry of
+// StopWatching function
+func stopWatching(cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := gd.spectators[cmd.id]; ok == false {
+		cmd.rez <- opError("stopWatching", cmd.id, ErrUnknownID)
+		return
+	}
+	delete(gd.spectators, cmd.id)
+	publishSpectators(gd, SpectatorLeftEvent, cmd.id)
+}
+
+// spectators implements concurrently safe processing of querry of
+// Spectators function
+func spectators(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("spectators", cmd.id, ErrUnknownID)
+		return
+	}
+	cmd.rez <- len(gd.spectators)
+}
+
+// say implements concurrently safe processing of querry of
+// Say function
+func say(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	msg := ChatMessage{ID: cmd.id, Text: cmd.text}
+	if gs, ok := gamerStates[cmd.id]; ok == true {
+		msg.Name = gs.Name
+	} else if spectator, ok := gd.spectators[cmd.id]; ok == true {
+		if gd.cfg.muteSpectators {
+			cmd.rez <- opError("say", cmd.id, ErrMuted)
+			return
+		}
+		msg.Name = spectator.Name
+	} else {
+		cmd.rez <- opError("say", cmd.id, ErrUnknownID)
+		return
+	}
+
+	gd.chat = append(gd.chat, msg)
+	publish(gd.subscribers, GameEvent{Kind: ChatEvent, ID: cmd.id, Message: &msg})
+}
+
+// chatHistory implements concurrently safe processing of querry of
+// ChatHistory function
+func chatHistory(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("chatHistory", cmd.id, ErrUnknownID)
+		return
+	}
+
+	messages := make([]ChatMessage, len(gd.chat))
+	copy(messages, gd.chat)
+	cmd.rez <- messages
+}
+
+// checkNegotiation checks that the gamer with id can decide on the pie rule
+func checkNegotiation(gamerStates map[int]*GamerState, op string, cmd *gameCommand, gd *gmaeDescriptor) error {
+	gs, err := getGamerStateAndChecks(gamerStates, op, cmd.id, gd.gameOver)
+	if err != nil {
+		return err
+	}
+	if !gd.pieRule || gd.pieDecided || gd.currentTurn != 1 {
+		return opError(op, cmd.id, ErrNoNegotiation)
+	}
+	if !isGamersTurn(gd.currentTurn, gs, gd.cfg) {
+		return opError(op, cmd.id, ErrNotYourTurn)
+	}
+	return nil
+}
+
+// swap implements concurrently safe processing of querry of
+// Swap function
+func swap(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if err := checkNegotiation(gamerStates, "swap", cmd, gd); err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	for _, gs := range gamerStates {
+		gs.Colour = igame.ChipColour(3 - int(gs.Colour))
+	}
+	gd.players[igame.Black], gd.players[igame.White] = gd.players[igame.White], gd.players[igame.Black]
+	gd.pieDecided = true
+
+	// the turn stays white's one, but now it's other gamer's turn.
+	for id, gs := range gamerStates {
+		if isGamersTurn(gd.currentTurn, gs, gd.cfg) {
+			reportOnChan(&gs.turnMSGChan, waitResult(gamerStates, id, gd, TurnBegunReason))
+		}
+	}
+}
+
+// setKomi implements concurrently safe processing of querry of
+// SetKomi function
+func setKomi(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if err := checkNegotiation(gamerStates, "setKomi", cmd, gd); err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	setter, ok := gd.master.(igame.KomiSetter)
+	if !ok {
+		cmd.rez <- opError("setKomi", cmd.id, ErrNoNegotiation)
+		return
+	}
+	if err := setter.SetKomi(cmd.komi); err != nil {
+		cmd.rez <- opError("setKomi", cmd.id, err)
+		return
+	}
+	gd.pieDecided = true
+}
+
 // leaveGame implements concurrently safe processing of querry of
 // LeaveGame function
-func leaveGame(gamerStates map[int]*GamerState, cmd *gameCommand) bool {
+func leaveGame(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) bool {
 	defer close(cmd.rez)
 
 	// this action may be called only for joined players.
-	_, ok := gamerStates[cmd.id]
+	gs, ok := gamerStates[cmd.id]
+	if ok == false && gd.left[cmd.id] {
+		cmd.rez <- opError("leaveGame", cmd.id, ErrAlreadyLeft)
+		return false
+	}
 	if ok == false {
-		cmd.rez <- fmt.Errorf("failed to leaveGame for gamer with id %d: %w", cmd.id, ErrUnknownID)
+		cmd.rez <- opError("leaveGame", cmd.id, ErrUnknownID)
 		return false
 	}
+	gd.left[cmd.id] = true
+	publish(gd.subscribers, GameEvent{Kind: LeftEvent, ID: cmd.id, Colour: gs.Colour})
 
 	// report to other player's, if they are awaiting somesthing, that other player left the game.
-	for _, gs := range gamerStates {
-		reportOnChan(&gs.beMSGChan, ErrOtherGamerLeft)
-		reportOnChan(&gs.turnMSGChan, ErrOtherGamerLeft)
+	for id, gs := range gamerStates {
+		left := waitResult(gamerStates, id, gd, OpponentLeftReason)
+		reportOnChan(&gs.beMSGChan, left)
+		reportOnChan(&gs.turnMSGChan, left)
+		reportOnChan(&gs.rematchMSGChan, ErrOtherGamerLeft)
 	}
 
+	endVacation(gs, gd.cfg)
+	reconnect(gs)
 	delete(gamerStates, cmd.id)
+
+	// the game in progress is won by the remaining gamer of other colour by forfeit.
+	if gd.gameOver == false {
+		for _, other := range gamerStates {
+			if other.Colour != gs.Colour {
+				gd.result = &igame.Result{Winner: other.Colour, Method: igame.ForfeitMethod}
+			}
+		}
+	}
 	return true
 }
 
 //helpers
 
+// opError creates the error of op performed by the gamer with id caused by err
+func opError(op string, id int, err error) error {
+	return &OperationError{Op: op, GamerID: id, Err: err}
+}
+
+// wrongTurnError wraps an error of the Master on a turn,
+// so both ErrWrongTurn and the original error can be checked by errors.Is and errors.As
+type wrongTurnError struct {
+	err error
+}
+
+func (e *wrongTurnError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrWrongTurn, e.err)
+}
+
+func (e *wrongTurnError) Is(target error) bool {
+	return target == ErrWrongTurn
+}
+
+func (e *wrongTurnError) Unwrap() error {
+	return e.err
+}
+
 // reportOnChan passes deferred data if needed
 func reportOnChan(ch *chan<- interface{}, val interface{}) {
 	if *ch != nil {
@@ -272,54 +1125,171 @@ func reportOnChan(ch *chan<- interface{}, val interface{}) {
 	}
 }
 
-func getGamerStateAndChecks(gamerStates map[int]*GamerState, id int, gameOver bool) (gs *GamerState, err error) {
+// getGamerStateAndChecks returns the state of the gamer with id performing op
+// if the gamer is joined to the game in progress
+func getGamerStateAndChecks(gamerStates map[int]*GamerState, op string, id int, gameOver bool) (gs *GamerState, err error) {
 	gs, ok := gamerStates[id]
 	if ok == false {
-		return nil, fmt.Errorf("failed to makeTurn for gamer with id %d: %w", id, ErrUnknownID)
+		return nil, opError(op, id, ErrUnknownID)
 	}
 
 	if gameOver == true {
-		return nil, ErrGameOver
+		return nil, opError(op, id, ErrGameOver)
 	}
 	return gs, nil
 }
 
+// viewerColour returns the colour of the gamer with id, NoColour for a spectator,
+// and false if the gamer is neither a gamer nor a spectator of the game
+func viewerColour(gamerStates map[int]*GamerState, id int, gd *gmaeDescriptor) (igame.ChipColour, bool) {
+	if gs, ok := gamerStates[id]; ok == true {
+		return gs.Colour, true
+	}
+	if _, ok := gd.spectators[id]; ok == true {
+		return igame.NoColour, true
+	}
+	return igame.NoColour, false
+}
+
 func isMyTurnCalc(currentTurn int, col igame.ChipColour) bool {
 	return (currentTurn%2 == 0 && col == igame.Black) || (currentTurn%2 == 1 && col == igame.White)
 }
 
-func reportOnTurnChange(gamerStates map[int]*GamerState, currentTurn int) {
-	for _, gs := range gamerStates {
-		if isMyTurnCalc(currentTurn+1, gs.Colour) {
-			reportOnChan(&gs.turnMSGChan, nil)
+func reportOnTurnChange(gamerStates map[int]*GamerState, currentTurn int, gd *gmaeDescriptor) {
+	for id, gs := range gamerStates {
+		if isGamersTurn(currentTurn+1, gs, gd.cfg) {
+			reportOnChan(&gs.turnMSGChan, waitResult(gamerStates, id, gd, TurnBegunReason))
 		}
 	}
 }
 
-type gmaeDescriptor struct {
-	gameOver    bool
-	currentTurn int
-	master      igame.Master
+// reportOnCounting informs gamers awaiting a turn that the game is in counting phase
+func reportOnCounting(gamerStates map[int]*GamerState, gd *gmaeDescriptor) {
+	for id, gs := range gamerStates {
+		reportOnChan(&gs.turnMSGChan, waitResult(gamerStates, id, gd, CountingReason))
+	}
 }
 
-// run processes commads for thread safe operations on Game.
-func (g Game) run(master igame.Master) {
-	rand.Seed(time.Now().UnixNano())
+// reportOnGameOver informs all awaiting gamers that the game is over
+func reportOnGameOver(gamerStates map[int]*GamerState, gd *gmaeDescriptor) {
+	for id, gs := range gamerStates {
+		over := waitResult(gamerStates, id, gd, GameOverReason)
+		reportOnChan(&gs.beMSGChan, over)
+		reportOnChan(&gs.turnMSGChan, over)
+	}
+}
+
+// waitResult describes the finish of awaiting by the gamer with id for the reason
+func waitResult(gamerStates map[int]*GamerState, id int, gd *gmaeDescriptor, reason WaitReason) *WaitResult {
+	rez := &WaitResult{Reason: reason}
+	if reason == GameBegunReason {
+		rez.Nigiri = gd.nigiri.copy()
+	}
+	for opponentID, gs := range gamerStates {
+		if opponentID != id && gs.Colour != gamerStates[id].Colour {
+			rez.OpponentID, rez.OpponentName, rez.OpponentColour = opponentID, gs.Name, gs.Colour
+		}
+	}
+
+	rez.Move = stateFor(gd, gamerStates[id].Colour).LastMove
+	return rez
+}
+
+// waitError returns the reply on awaiting failed with err:
+// the end of the game is described by the WaitResult, other errors are returned as is
+func waitError(gamerStates map[int]*GamerState, id int, gd *gmaeDescriptor, err error) interface{} {
+	if errors.Is(err, ErrGameOver) {
+		return waitResult(gamerStates, id, gd, GameOverReason)
+	}
+	return err
+}
+
+type gmaeDescriptor struct {
+	gameOver        bool
+	currentTurn     int
+	master          igame.Master
+	pieRule         bool // the pie rule is used
+	pieDecided      bool // the white gamer swapped or set komi
+	book            igame.OpeningBook
+	result          *igame.Result    // result of the game decided outside of the master
+	counting        bool             // the game is in counting phase
+	dead            []igame.TurnData // positions of dead chips marked in counting phase
+	accepted        map[int]bool     // ids of gamers accepted the score in counting phase
+	undoRequester   int              // id of the gamer requested an undo
+	undoMoves       int              // number of moves to revert on the undo request, 0 if there is no request
+	cfg             *config          // settings of the game to create a rematch
+	subscribers     map[*subscription]bool
+	spectators      map[int]*Gamer              // spectators of the game by id
+	chat            []ChatMessage               // history of the game chat
+	clock           clock                       // the running clock of the game
+	history         []HistoryMove               // moves made in the game
+	players         map[igame.ChipColour]string // names of gamers by colour
+	paused          bool                        // the game is paused by agreement of gamers
+	pauseRequest    consentRequest              // request of a pause
+	resumeRequest   consentRequest              // request of a resumption
+	hasAbortOffer   bool                        // a gamer offered to abort the game
+	abortOfferer    int                         // id of the gamer offered to abort the game
+	nigiri          *Nigiri                     // the nigiri determined colours, nil if there was no nigiri
+	begun           time.Time                   // time both gamers joined the game
+	ended           time.Time                   // time the game is over
+	activity        time.Time                   // time of the last command of a gamer
+	inactivityTimer Timer                       // timer to report inactivity of gamers
+	abandoned       bool                        // the game is destroyed because of inactivity of gamers
+	proposal        *Settings                   // settings proposed before the first move, nil if there is no proposal
+	proposer        int                         // id of the gamer proposed the settings
+	review          *review                     // review of the finished game, nil until it's started
+	rejections      int                         // number of rejections of the score in counting phase
+	left            map[int]bool                // ids of gamers left the game
+}
 
-	gamerStates := make(map[int]*GamerState)
-	gd := &gmaeDescriptor{master: master}
+// newDescriptor creates the descriptor of a new game played on master with settings of cfg
+func newDescriptor(master igame.Master, cfg *config) *gmaeDescriptor {
+	return &gmaeDescriptor{master: master, pieRule: cfg.pieRule, book: cfg.book, cfg: cfg, currentTurn: cfg.firstTurn(),
+		subscribers: make(map[*subscription]bool), spectators: make(map[int]*Gamer),
+		players: make(map[igame.ChipColour]string), left: make(map[int]bool)}
+}
 
-	go func(g Game) {
-		for cmd := range g {
+// run processes commads for thread safe operations on Game.
+func (g *Game) run(gamerStates map[int]*GamerState, gd *gmaeDescriptor) {
+	g.setConfig(gd.cfg, gd.master.Size())
+	if gd.cfg.admin != nil {
+		gd.cfg.admin.game = g
+	}
+	if gd.cfg.logger != nil {
+		sub := newSubscription()
+		gd.subscribers[sub] = true
+		go logEvents(gd.cfg, sub.out)
+	}
+	if gd.cfg.analyzer != nil {
+		sub := newSubscription()
+		sub.timings = true
+		gd.subscribers[sub] = true
+		go analyzeMoves(gd.cfg, sub.out)
+	}
+	go func(g *Game) {
+		updateClocks(g, gamerStates, gd)
+		if gd.cfg.inactivity > 0 {
+			gd.activity = gd.cfg.timeSource().Now()
+			awaitInactivity(g, gd, gd.cfg.inactivity)
+		}
+		for destroyed := false; !destroyed; {
+			cmd := <-g.cmds
+			for _, stale := range g.takeStale() {
+				cancelWait(gamerStates, stale)
+			}
+			noteActivity(gamerStates, cmd, gd)
+			wasOver := gd.gameOver
 			switch cmd.act {
 			case endCMD:
-				close(g)
+				destroyed = true
 				close(cmd.rez)
+			case inactiveCMD:
+				destroyed = inactive(g, cmd, gd)
 
 			case joinCMD:
 				join(&gamerStates, cmd, gd)
 			case gamerStateCMD:
-				gamerState(gamerStates, cmd)
+				gamerState(gamerStates, cmd, gd)
 			case gameFieldSize:
 				fieldSize(gamerStates, cmd, gd)
 			case gameStateCMD:
@@ -334,16 +1304,144 @@ func (g Game) run(master igame.Master) {
 				isGameBegun(gamerStates, cmd, gd)
 			case makeTurnCMD:
 				gd.currentTurn += makeTurn(gamerStates, cmd, gd)
+			case passCMD:
+				gd.currentTurn += pass(gamerStates, cmd, gd)
+			case resignCMD:
+				resign(gamerStates, cmd, gd)
 			case leaveCMD:
-				gd.gameOver = leaveGame(gamerStates, cmd)
+				gd.gameOver = leaveGame(gamerStates, cmd, gd) || gd.gameOver
+			case swapCMD:
+				swap(gamerStates, cmd, gd)
+			case setKomiCMD:
+				setKomi(gamerStates, cmd, gd)
+			case bookMovesCMD:
+				bookMoves(gamerStates, cmd, gd)
+			case resultCMD:
+				gameResult(gamerStates, cmd, gd)
+			case markDeadCMD:
+				markDead(gamerStates, cmd, gd)
+			case acceptScoreCMD:
+				acceptScore(gamerStates, cmd, gd)
+			case resumePlayCMD:
+				gd.currentTurn += resumePlay(gamerStates, cmd, gd)
+			case requestUndoCMD:
+				requestUndo(gamerStates, cmd, gd)
+			case answerUndoCMD:
+				gd.currentTurn += answerUndo(gamerStates, cmd, gd)
+			case offerRematchCMD:
+				offerRematch(gamerStates, cmd, gd)
+			case acceptRematchCMD:
+				acceptRematch(gamerStates, cmd, gd)
+			case subscribeCMD:
+				subscribe(gamerStates, cmd, gd)
+			case unsubscribeCMD:
+				unsubscribe(cmd, gd)
+			case watchCMD:
+				watch(gamerStates, cmd, gd)
+			case stopWatchingCMD:
+				stopWatching(cmd, gd)
+			case spectatorsCMD:
+				spectators(gamerStates, cmd, gd)
+			case sayCMD:
+				say(gamerStates, cmd, gd)
+			case chatHistoryCMD:
+				chatHistory(gamerStates, cmd, gd)
+			case timeoutCMD:
+				gd.currentTurn += timeout(gamerStates, cmd, gd)
+			case startVacationCMD:
+				startVacation(g, gamerStates, cmd, gd)
+			case endVacationCMD:
+				stopVacation(gamerStates, cmd, gd)
+			case clocksCMD:
+				clocks(gamerStates, cmd, gd)
+			case historyCMD:
+				history(gamerStates, cmd, gd)
+			case sgfCMD:
+				gameRecord(gamerStates, cmd, gd)
+			case snapshotCMD:
+				snapshot(gamerStates, cmd, gd)
+			case disconnectCMD:
+				disconnect(g, gamerStates, cmd, gd)
+			case rejoinCMD:
+				rejoin(gamerStates, cmd, gd)
+			case statsCMD:
+				stats(gamerStates, cmd, gd)
+			case statusCMD:
+				status(gamerStates, cmd, gd)
+			case scoreCMD:
+				score(gamerStates, cmd, gd)
+			case proposeSettingsCMD:
+				proposeSettings(gamerStates, cmd, gd)
+			case answerSettingsCMD:
+				answerSettings(gamerStates, cmd, gd)
+				// the field and settings may be replaced on acceptance.
+				g.setConfig(gd.cfg, gd.master.Size())
+			case settingsCMD:
+				gameSettings(gamerStates, cmd, gd)
+			case gamersCMD:
+				gamers(gamerStates, cmd, gd)
+			case reviewPositionCMD:
+				reviewPosition(gamerStates, cmd, gd)
+			case reviewGotoCMD:
+				reviewGoto(gamerStates, cmd, gd)
+			case reviewMoveCMD:
+				reviewMove(gamerStates, cmd, gd)
+			case rejectScoreCMD:
+				rejectScore(gamerStates, cmd, gd)
+			case forceEndCMD:
+				forceEnd(gamerStates, cmd, gd)
+			case resyncCMD:
+				resync(gamerStates, cmd, gd)
+			case callbackCMD:
+				addCallback(cmd, gd)
+			case offerAbortCMD:
+				offerAbort(gamerStates, cmd, gd)
+			case acceptAbortCMD:
+				acceptAbort(gamerStates, cmd, gd)
+			case pauseCMD:
+				pause(gamerStates, cmd, gd)
+			case resumeCMD:
+				resume(gamerStates, cmd, gd)
+			case graceOverCMD:
+				gd.gameOver = graceOver(gamerStates, cmd, gd) || gd.gameOver
+			case vacationOverCMD:
+				vacationOver(gamerStates, cmd, gd)
 			}
+			updateClocks(g, gamerStates, gd)
+			if gd.gameOver && !wasOver {
+				gd.ended = gd.cfg.timeSource().Now()
+				publish(gd.subscribers, GameEvent{Kind: GameOverEvent, Result: finalResult(gd)})
+			}
+			storeSnapshot(gamerStates, cmd, gd)
 			if gd.gameOver && len(gamerStates) == 0 {
-				close(g)
+				destroyed = true
 			}
 		}
+		// repeated Leave of gamers left the game is answered by the handle.
+		g.setLeft(gd.left)
+		close(g.done)
+		reason, err := GameDestroyedReason, ErrGameDestroyed
+		if gd.abandoned {
+			reason, err = AbandonedReason, ErrAbandoned
+		}
+		for id, gs := range gamerStates {
+			destroyed := waitResult(gamerStates, id, gd, reason)
+			reportOnChan(&gs.beMSGChan, destroyed)
+			reportOnChan(&gs.turnMSGChan, destroyed)
+			reportOnChan(&gs.rematchMSGChan, err)
+		}
+		for sub := range gd.subscribers {
+			sub.close()
+		}
+		if gd.clock.running {
+			gd.clock.timer.Stop()
+		}
+		if gd.inactivityTimer != nil {
+			gd.inactivityTimer.Stop()
+		}
 		for _, gs := range gamerStates {
-			reportOnChan(&gs.beMSGChan, ErrGameDestroyed)
-			reportOnChan(&gs.turnMSGChan, ErrGameDestroyed)
+			endVacation(gs, gd.cfg)
+			reconnect(gs)
 		}
 	}(g)
 	return
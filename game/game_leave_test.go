@@ -21,6 +21,8 @@ import (
 	"errors"
 	"testing"
 	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
 )
 
 var leaveTests = []struct {
@@ -29,8 +31,12 @@ var leaveTests = []struct {
 	want     error
 }{
 	{caseName: "first", gamer: validGamers[0], want: nil},
+	{caseName: "first again", gamer: validGamers[0], want: ErrAlreadyLeft},
 	{caseName: "not joined", gamer: invalidGamer, want: ErrUnknownID},
 	{caseName: "second", gamer: validGamers[1], want: nil},
+	{caseName: "second again after destroy", gamer: validGamers[1], want: ErrAlreadyLeft},
+	{caseName: "first again after destroy", gamer: validGamers[0], want: ErrAlreadyLeft},
+	{caseName: "not joined after destroy", gamer: invalidGamer, want: ErrGameDestroyed},
 }
 
 // TestLeave tests the leaving of game procedure.
@@ -54,6 +60,44 @@ func TestLeave(t *testing.T) {
 	}
 }
 
+// TestResultLeave checks that the game left by a gamer is won by the remaining one.
+func TestResultLeave(t *testing.T) {
+	game, err := NewGame(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+	gamers := copyGamers(validGamers)
+
+	arg := commonArgs{
+		t:      t,
+		game:   game,
+		gamers: gamers}
+	joinGamers(&arg)
+
+	if _, err := game.Result(gamers[1].ID); !errors.Is(err, ErrGameNotOver) {
+		t.Errorf("Unexpected Result err of the game in progress:\nwant: %v\ngot: %v", ErrGameNotOver, err)
+	}
+	if err := game.Leave(gamers[0].ID); err != nil {
+		t.Fatalf("Unexpected Leave err: %v", err)
+	}
+
+	gs, err := game.GamerState(gamers[1].ID)
+	if err != nil {
+		t.Fatalf("Unexpected GamerState err: %v", err)
+	}
+	result, err := game.Result(gamers[1].ID)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if result.Winner != gs.Colour || result.Method != igame.ForfeitMethod {
+		t.Errorf("Unexpected Result after leave:\nwant: win of %v by forfeit\ngot: %v", gs.Colour, result)
+	}
+	if _, err := game.Result(gamers[0].ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Result err for left gamer:\nwant: %v\ngot: %v", ErrUnknownID, err)
+	}
+}
+
 // TestBeginSuccess checks if game with all gamers on the board
 // finishes awaiting rapidly on leave.
 func TestBeginLeave(t *testing.T) {
@@ -81,7 +125,7 @@ func TestBeginLeave(t *testing.T) {
 }
 
 // TestLeaveEnd tests game.End after game leave
-// and closing of Game object as chanel.
+// and destruction of the Game object.
 func TestLeaveEnd(t *testing.T) {
 	gamers := copyGamers(validGamers)[:1]
 	game, err := NewGame(usualSize, usualKomi)
@@ -90,7 +134,7 @@ func TestLeaveEnd(t *testing.T) {
 	}
 
 	defer func() {
-		want := ErrResourceNotAvailable
+		want := ErrGameDestroyed
 		if err := game.End(); !errors.Is(err, want) {
 			t.Errorf("Unexpected End err:\nwant: %v,\ngot: %v", want, err)
 		}
@@ -110,7 +154,7 @@ func TestLeaveEnd(t *testing.T) {
 
 // TestLeaveBeginTurn tests game with all gamers on the board
 // should finish awaiting of turn with error
-// if Game object is closed as chanel.
+// if Game object is destroyed.
 func TestLeaveBeginTurn(t *testing.T) {
 	gamers := copyGamers(validGamers)
 	game, err := NewGame(usualSize, usualKomi)
@@ -0,0 +1,71 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// countingMaster is the field which counts moves made on it
+type countingMaster struct {
+	*field.Field
+	moves int
+}
+
+func (m *countingMaster) Move(colour igame.ChipColour, td *igame.TurnData) error {
+	m.moves++
+	return m.Field.Move(colour, td)
+}
+
+// TestNewGameWithMaster checks that moves are made on the injected master.
+func TestNewGameWithMaster(t *testing.T) {
+	f, err := field.New(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected field.New err: %v", err)
+	}
+	master := &countingMaster{Field: f}
+	game, err := NewGameWithMaster(master)
+	if err != nil {
+		t.Fatalf("Unexpected NewGameWithMaster err: %v", err)
+	}
+	defer game.End()
+	gamers := copyGamers(validGamers)
+	joinGamers(&commonArgs{t: t, game: game, gamers: gamers})
+	black, white := gamers[0].ID, gamers[1].ID
+	if gs, _ := game.GamerState(black); gs.Colour != igame.Black {
+		black, white = white, black
+	}
+
+	if err := game.MakeTurn(black, &igame.TurnData{X: 5, Y: 5}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if master.moves != 1 {
+		t.Errorf("Unexpected number of moves made on the master:\nwant: 1,\ngot: %d", master.moves)
+	}
+
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	if _, err := game.OfferRematch(context.Background(), black); !errors.Is(err, ErrRematchNotAllowed) {
+		t.Errorf("Unexpected OfferRematch err:\nwant: %v,\ngot: %v", ErrRematchNotAllowed, err)
+	}
+}
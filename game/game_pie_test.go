@@ -0,0 +1,98 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// pieGame creates the game with joined gamers where black made the first move,
+// and returns ids of black and white gamers
+func pieGame(t *testing.T, opts ...Option) (game *Game, black, white int) {
+	gamers := copyGamers(validGamers)
+	game, err := NewGame(usualSize, usualKomi, opts...)
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	joinGamers(&commonArgs{t: t, game: game, gamers: gamers})
+
+	for _, g := range gamers {
+		gs, err := game.GamerState(g.ID)
+		if err != nil {
+			t.Fatalf("Unexpected GamerState err: %v", err)
+		}
+		if gs.Colour == igame.Black {
+			black = g.ID
+		} else {
+			white = g.ID
+		}
+	}
+
+	if err := game.MakeTurn(black, &igame.TurnData{X: 5, Y: 5}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	return game, black, white
+}
+
+func TestSwap(t *testing.T) {
+	game, black, white := pieGame(t, WithPieRule())
+	defer game.End()
+
+	if err := game.Swap(black); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("Unexpected Swap err by black:\nwant: %v,\ngot: %v.", ErrNotYourTurn, err)
+	}
+	if err := game.Swap(white); err != nil {
+		t.Fatalf("Unexpected Swap err: %v", err)
+	}
+
+	if gs, _ := game.GamerState(white); gs.Colour != igame.Black {
+		t.Errorf("Unexpected colour of swapped gamer:\nwant: %v,\ngot: %v.", igame.ChipColour(igame.Black), gs.Colour)
+	}
+	if igt, _ := game.IsMyTurn(black); igt != true {
+		t.Errorf("Unexpected IsMyTurn of former black gamer after Swap:\nwant: true,\ngot: %v.", igt)
+	}
+	if err := game.Swap(black); !errors.Is(err, ErrNoNegotiation) {
+		t.Errorf("Unexpected second Swap err:\nwant: %v,\ngot: %v.", ErrNoNegotiation, err)
+	}
+}
+
+func TestSetKomi(t *testing.T) {
+	game, _, white := pieGame(t, WithPieRule())
+	defer game.End()
+
+	if err := game.SetKomi(white, 6.5); err != nil {
+		t.Fatalf("Unexpected SetKomi err: %v", err)
+	}
+	if state, _ := game.GameState(white); state.Komi != 6.5 {
+		t.Errorf("Unexpected komi after SetKomi:\nwant: 6.5,\ngot: %v.", state.Komi)
+	}
+	if err := game.Swap(white); !errors.Is(err, ErrNoNegotiation) {
+		t.Errorf("Unexpected Swap err after SetKomi:\nwant: %v,\ngot: %v.", ErrNoNegotiation, err)
+	}
+}
+
+func TestSwapWithoutPieRule(t *testing.T) {
+	game, _, white := pieGame(t)
+	defer game.End()
+
+	if err := game.Swap(white); !errors.Is(err, ErrNoNegotiation) {
+		t.Errorf("Unexpected Swap err without pie rule:\nwant: %v,\ngot: %v.", ErrNoNegotiation, err)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+type rematchRez struct {
+	game *Game
+	err  error
+}
+
+func TestRematch(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	if _, err := game.AcceptRematch(white); !errors.Is(err, ErrGameNotOver) {
+		t.Errorf("Unexpected AcceptRematch err of the game in progress:\nwant: %v,\ngot: %v.", ErrGameNotOver, err)
+	}
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	if _, err := game.AcceptRematch(white); !errors.Is(err, ErrNoRematchOffer) {
+		t.Errorf("Unexpected AcceptRematch err without offer:\nwant: %v,\ngot: %v.", ErrNoRematchOffer, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold)
+	defer cancel()
+	ch := make(chan rematchRez)
+	go func() {
+		rematch, err := game.OfferRematch(ctx, black)
+		ch <- rematchRez{game: rematch, err: err}
+	}()
+
+	time.Sleep(rtDurationThreshold / 2)
+	rematch, err := game.AcceptRematch(white)
+	if err != nil {
+		t.Fatalf("Unexpected AcceptRematch err: %v", err)
+	}
+	defer rematch.End()
+
+	offered := <-ch
+	if offered.err != nil || offered.game != rematch {
+		t.Fatalf("Unexpected OfferRematch result:\nwant: %v,\ngot: %v, err: %v.", rematch, offered.game, offered.err)
+	}
+	for id, want := range map[int]igame.ChipColour{black: igame.White, white: igame.Black} {
+		gs, err := rematch.GamerState(id)
+		if err != nil {
+			t.Fatalf("Unexpected GamerState err: %v", err)
+		}
+		if gs.Colour != want {
+			t.Errorf("Unexpected colour of gamer with id %d in rematch:\nwant: %v,\ngot: %v.", id, want, gs.Colour)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestScore checks the estimate of the game in progress.
+func TestScore(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	spectator := &Gamer{Name: "Watcher", ID: 4}
+	if err := game.Watch(spectator); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+
+	for _, id := range []int{black, white, spectator.ID} {
+		estimate, err := game.Score(id)
+		if err != nil {
+			t.Fatalf("Unexpected Score err for gamer with id %d: %v", id, err)
+		}
+		if estimate.Leader != igame.Black {
+			t.Errorf("Unexpected leader of Score for gamer with id %d:\nwant: %v,\ngot: %v", id, igame.Black, estimate.Leader)
+		}
+	}
+
+	if _, err := game.Score(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Score err of unknown gamer:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
+
+// TestScoreNotSupported checks the score of the game
+// played on the master which can't estimate it.
+func TestScoreNotSupported(t *testing.T) {
+	f, err := field.New(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected field.New err: %v", err)
+	}
+	game, err := NewGameWithMaster(struct{ igame.Master }{f})
+	if err != nil {
+		t.Fatalf("Unexpected NewGameWithMaster err: %v", err)
+	}
+	defer game.End()
+
+	gamer := *validGamers[0]
+	if err := game.Join(&gamer); err != nil {
+		t.Fatalf("Unexpected Join err: %v", err)
+	}
+	if _, err := game.Score(gamer.ID); !errors.Is(err, ErrNoEstimate) {
+		t.Errorf("Unexpected Score err:\nwant: %v,\ngot: %v", ErrNoEstimate, err)
+	}
+}
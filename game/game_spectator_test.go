@@ -0,0 +1,113 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestWatch(t *testing.T) {
+	game, black, _ := pieGame(t)
+	defer game.End()
+	spectator := &Gamer{Name: "Watcher", ID: 4}
+
+	if err := game.Watch(&Gamer{ID: black}); !errors.Is(err, ErrAlreadyJoined) {
+		t.Errorf("Unexpected Watch err by gamer:\nwant: %v,\ngot: %v", ErrAlreadyJoined, err)
+	}
+	if err := game.Watch(spectator); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+	if err := game.Watch(spectator); !errors.Is(err, ErrAlreadyJoined) {
+		t.Errorf("Unexpected second Watch err:\nwant: %v,\ngot: %v", ErrAlreadyJoined, err)
+	}
+	if n, err := game.Spectators(black); err != nil || n != 1 {
+		t.Errorf("Unexpected Spectators:\nwant: 1,\ngot: %v, err: %v", n, err)
+	}
+
+	state, err := game.GameState(spectator.ID)
+	if err != nil {
+		t.Fatalf("Unexpected GameState err of spectator: %v", err)
+	}
+	if len(state.ChipsOnBoard[igame.Black]) != 1 {
+		t.Errorf("Unexpected chips on board for spectator:\nwant: 1 black chip,\ngot: %v", state.ChipsOnBoard)
+	}
+	if err := game.Pass(spectator.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Pass err of spectator:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+
+	events, cancel := game.Subscribe(spectator.ID)
+	defer cancel()
+	if err := game.Resign(black); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	if ev := nextEvent(t, events); ev.Kind != ResignEvent {
+		t.Errorf("Unexpected event of spectator:\nwant: %v,\ngot: %v", ResignEvent, ev.Kind)
+	}
+
+	if err := game.StopWatching(spectator.ID); err != nil {
+		t.Fatalf("Unexpected StopWatching err: %v", err)
+	}
+	if _, err := game.GameState(spectator.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected GameState err after StopWatching:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
+
+// TestSpectatorPresence checks the number and the list of spectators shown to gamers.
+func TestSpectatorPresence(t *testing.T) {
+	tests := []struct {
+		caseName string
+		opts     []Option
+		wantID   int
+		wantList map[int]string
+	}{
+		{caseName: "number only", wantID: 0, wantList: nil},
+		{caseName: "list", opts: []Option{WithSpectatorList()}, wantID: 4, wantList: map[int]string{4: "Watcher"}},
+	}
+	for _, test := range tests {
+		t.Run(test.caseName, func(t *testing.T) {
+			game, black, _ := pieGame(t, test.opts...)
+			defer game.End()
+			events, cancel := game.Subscribe(black)
+			defer cancel()
+
+			if err := game.Watch(&Gamer{Name: "Watcher", ID: 4}); err != nil {
+				t.Fatalf("Unexpected Watch err: %v", err)
+			}
+			if ev := nextEvent(t, events); ev.Kind != SpectatorJoinedEvent || ev.ID != test.wantID || ev.Watchers != 1 {
+				t.Errorf("Unexpected event of Watch: %+v", ev)
+			}
+			status, err := game.Status(black)
+			if err != nil {
+				t.Fatalf("Unexpected Status err: %v", err)
+			}
+			if status.Watchers != 1 || !reflect.DeepEqual(status.Watching, test.wantList) {
+				t.Errorf("Unexpected spectators of Status:\nwant: 1 %v,\ngot: %d %v", test.wantList, status.Watchers, status.Watching)
+			}
+
+			if err := game.StopWatching(4); err != nil {
+				t.Fatalf("Unexpected StopWatching err: %v", err)
+			}
+			if ev := nextEvent(t, events); ev.Kind != SpectatorLeftEvent || ev.ID != test.wantID || ev.Watchers != 0 {
+				t.Errorf("Unexpected event of StopWatching: %+v", ev)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package game
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -48,7 +49,9 @@ var joinTests = []struct {
 	want     error
 }{
 	{caseName: "first", gamer: validGamers[0], want: nil},
+	{caseName: "first again", gamer: validGamers[0], want: ErrAlreadyJoined},
 	{caseName: "second", gamer: validGamers[1], want: nil},
+	{caseName: "second again", gamer: validGamers[1], want: ErrAlreadyJoined},
 	{caseName: "third", gamer: invalidGamer, want: ErrNoPlace},
 }
 
@@ -85,6 +88,28 @@ func TestCreation(t *testing.T) {
 	defer game.End()
 }
 
+// TestDestroyed tests that operations on the destroyed game
+// fail with ErrGameDestroyed
+func TestDestroyed(t *testing.T) {
+	game, black, _ := pieGame(t)
+	if err := game.End(); err != nil {
+		t.Fatalf("Unexpected End err: %v", err)
+	}
+
+	if _, err := game.IsGameBegun(black); !errors.Is(err, ErrGameDestroyed) {
+		t.Errorf("Unexpected IsGameBegun err:\nwant: %v,\ngot: %v", ErrGameDestroyed, err)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); !errors.Is(err, ErrGameDestroyed) {
+		t.Errorf("Unexpected MakeTurn err:\nwant: %v,\ngot: %v", ErrGameDestroyed, err)
+	}
+	if _, err := game.WaitState(black); !errors.Is(err, ErrGameDestroyed) {
+		t.Errorf("Unexpected WaitState err:\nwant: %v,\ngot: %v", ErrGameDestroyed, err)
+	}
+	if err := game.End(); !errors.Is(err, ErrGameDestroyed) {
+		t.Errorf("Unexpected End err:\nwant: %v,\ngot: %v", ErrGameDestroyed, err)
+	}
+}
+
 // TestJoin tests joining of gamers to a game
 func TestJoin(t *testing.T) {
 	game, err := NewGame(usualSize, usualKomi)
@@ -120,11 +145,11 @@ func TestEnd(t *testing.T) {
 	stateChan := asyncGameEnd(game)
 
 	// End function should be the pretty fast action
-	// with closing of game the object as chanel.
+	// with destruction of the game object.
 	select {
 	case ok := <-stateChan:
 		if ok == true {
-			t.Fatalf("Unexpected game.End() result:\nwant: closed GamersPool object as chanel,\ngot: chanel alive")
+			t.Fatalf("Unexpected game.End() result:\nwant: destroyed Game object,\ngot: Game alive")
 		}
 	case <-time.After(fastDurationThreshold):
 		t.Fatalf("Unexpected game.End():\nwant: return earler than %v duration,\ngot: return after %v duration", fastDurationThreshold, fastDurationThreshold)
@@ -329,3 +354,102 @@ func TestGamerBeginForeign(t *testing.T) {
 		dur:  rtDurationThreshold}
 	checkWaitingNegative(&argCheck)
 }
+
+// TestQueryUnknownType checks that a reply of unexpected type
+// is reported as ErrUnknownTypeReturned
+func TestQueryUnknownType(t *testing.T) {
+	game := newGameHandle()
+	go func() {
+		cmd := <-game.cmds
+		cmd.rez <- "unexpected"
+		close(cmd.rez)
+	}()
+
+	_, err := query[int](context.Background(), game, &gameCommand{act: gameFieldSize})
+	if !errors.Is(err, ErrUnknownTypeReturned) {
+		t.Errorf("Unexpected err:\nwant: %v,\ngot: %v", ErrUnknownTypeReturned, err)
+	}
+}
+
+// TestRandReproducible checks that games with sources of the same seed
+// assign the same colours
+func TestRandReproducible(t *testing.T) {
+	colourOf := func(seed int64) igame.ChipColour {
+		game, err := NewGame(usualSize, usualKomi, WithRand(rand.New(rand.NewSource(seed))))
+		if err != nil {
+			t.Fatalf("Unexpected err on NewGame: %v", err)
+		}
+		defer game.End()
+		joinGamers(&commonArgs{t: t, game: game, gamers: copyGamers(validGamers)[:1]})
+
+		gs, err := game.GamerState(validGamers[0].ID)
+		if err != nil {
+			t.Fatalf("Unexpected GamerState err: %v", err)
+		}
+		return gs.Colour
+	}
+
+	for seed := int64(0); seed < 8; seed++ {
+		if first, second := colourOf(seed), colourOf(seed); first != second {
+			t.Errorf("Unexpected colours of games with seed %d: %v and %v", seed, first, second)
+		}
+	}
+}
+
+// TestOperationError checks the operation and the gamer reported by errors of the game
+func TestOperationError(t *testing.T) {
+	game, black, _ := pieGame(t)
+	defer game.End()
+
+	tests := []struct {
+		caseName string
+		op       string
+		id       int
+		err      error
+		want     error
+	}{
+		{caseName: "unknown gamer",
+			op: "isMyTurn", id: invalidGamer.ID, want: ErrUnknownID,
+			err: errorOf(game.IsMyTurn(invalidGamer.ID))},
+		{caseName: "not my turn",
+			op: "makeTurn", id: black, want: ErrNotYourTurn,
+			err: game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.caseName, func(t *testing.T) {
+			var opErr *OperationError
+			if !errors.As(test.err, &opErr) {
+				t.Fatalf("Unexpected err:\nwant: *OperationError,\ngot: %T", test.err)
+			}
+			if opErr.Op != test.op || opErr.GamerID != test.id || !errors.Is(opErr, test.want) {
+				t.Errorf("Unexpected OperationError:\nwant: %s by %d caused by %v,\ngot: %s by %d caused by %v",
+					test.op, test.id, test.want, opErr.Op, opErr.GamerID, opErr.Err)
+			}
+		})
+	}
+}
+
+// TestCancelledWait checks that awaitings cancelled by the context are forgotten by the game.
+func TestCancelledWait(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold/4)
+	defer cancel()
+	if err := game.WaitTurn(ctx, black); !errors.Is(err, ErrCancellation) {
+		t.Errorf("Unexpected WaitTurn err on timeout:\nwant: %v,\ngot: %v", ErrCancellation, err)
+	}
+
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), rtDurationThreshold/4)
+	defer cancel()
+	if _, err := game.OfferRematch(ctx, black); !errors.Is(err, ErrCancellation) {
+		t.Errorf("Unexpected OfferRematch err on timeout:\nwant: %v,\ngot: %v", ErrCancellation, err)
+	}
+	if _, err := game.AcceptRematch(white); !errors.Is(err, ErrNoRematchOffer) {
+		t.Errorf("Unexpected AcceptRematch err of the cancelled offer:\nwant: %v,\ngot: %v", ErrNoRematchOffer, err)
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/yagoggame/gomaster/game/field"
 	"github.com/yagoggame/gomaster/game/igame"
 )
 
@@ -150,6 +152,8 @@ var MakeTurnTests = []struct {
 	want     error
 }{
 	{caseName: "wrong turn", move: &igame.TurnData{X: 0, Y: 1}, want: ErrWrongTurn},
+	{caseName: "no position", move: nil, want: ErrInvalidTurn},
+	{caseName: "out of field", move: &igame.TurnData{X: 1, Y: usualSize + 1}, want: ErrInvalidTurn},
 	{caseName: "good turn", move: &igame.TurnData{X: 1, Y: 1}, want: nil},
 	{caseName: "not your turn", move: &igame.TurnData{X: 1, Y: 1}, want: ErrNotYourTurn},
 }
@@ -193,3 +197,240 @@ func TestMakeTurnFailures(t *testing.T) {
 		}
 	}
 }
+
+// TestMakeTurnMoveError checks that error of the field is available from MakeTurn's error.
+func TestMakeTurnMoveError(t *testing.T) {
+	gamers := copyGamers(validGamers)
+	game, err := NewGame(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: err")
+	}
+	defer game.End()
+
+	arg := commonArgs{
+		t:      t,
+		game:   game,
+		gamers: gamers}
+	joinGamers(&arg)
+
+	first, second := gamers[0].ID, gamers[1].ID
+	if igt, _ := game.IsMyTurn(first); igt != true {
+		first, second = second, first
+	}
+	if err := game.MakeTurn(first, &igame.TurnData{X: 1, Y: 1}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	err = game.MakeTurn(second, &igame.TurnData{X: 1, Y: 1})
+
+	var moveErr *field.MoveError
+	if !errors.Is(err, ErrWrongTurn) || !errors.As(err, &moveErr) || moveErr.Reason != field.ErrOccupied {
+		t.Errorf("Unexpected MakeTurn err:\nwant: %v with *field.MoveError,\ngot: %v", ErrWrongTurn, err)
+	}
+}
+
+func TestGameStateOneColour(t *testing.T) {
+	gamers := copyGamers(validGamers)
+	game, err := NewGame(usualSize, usualKomi, WithFieldOptions(field.WithOneColour()))
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+
+	arg := commonArgs{
+		t:      t,
+		game:   game,
+		gamers: gamers}
+	joinGamers(&arg)
+
+	for _, g := range gamers {
+		if igt, _ := game.IsMyTurn(g.ID); igt == true {
+			if err := game.MakeTurn(g.ID, &igame.TurnData{X: 1, Y: 1}); err != nil {
+				t.Fatalf("Unexpected MakeTurn err: %v", err)
+			}
+			break
+		}
+	}
+
+	for _, g := range gamers {
+		state, err := game.GameState(g.ID)
+		if err != nil {
+			t.Fatalf("Unexpected GameState err: %v", err)
+		}
+		if len(state.ChipsOnBoard[igame.NoColour]) != 1 || len(state.ChipsOnBoard[igame.Black]) != 0 {
+			t.Errorf("Unexpected chips on board in one-colour game:\nwant: one chip of NoColour,\ngot: %v.", state.ChipsOnBoard)
+		}
+	}
+}
+
+// TestPass checks that two passes in a row finish the play
+// and release the awaiting gamer with ErrCounting.
+func TestPass(t *testing.T) {
+	gamers := copyGamers(validGamers)
+	game, err := NewGame(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+
+	arg := commonArgs{
+		t:      t,
+		game:   game,
+		gamers: gamers}
+	joinGamers(&arg)
+
+	black, white := gamers[0].ID, gamers[1].ID
+	if igt, _ := game.IsMyTurn(black); igt != true {
+		black, white = white, black
+	}
+
+	if err := game.Pass(white); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("Unexpected Pass err out of turn:\nwant: %v,\ngot: %v", ErrNotYourTurn, err)
+	}
+	if err := game.Pass(black); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold)
+	defer cancel()
+	ch := make(chan error)
+	go waitTurnRoutine(&waitGameRoutineParam{ctx: ctx, game: game, gamer: &Gamer{ID: black}, ch: ch})
+
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	if err := <-ch; !errors.Is(err, ErrCounting) {
+		t.Errorf("Unexpected WaitTurn err after two passes:\nwant: %v,\ngot: %v", ErrCounting, err)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 1, Y: 1}); !errors.Is(err, ErrCounting) {
+		t.Errorf("Unexpected MakeTurn err after two passes:\nwant: %v,\ngot: %v", ErrCounting, err)
+	}
+}
+
+// TestResign checks that resignation finishes the game with the win of the opponent
+// and releases the awaiting gamer with ErrGameOver.
+func TestResign(t *testing.T) {
+	gamers := copyGamers(validGamers)
+	game, err := NewGame(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+
+	arg := commonArgs{
+		t:      t,
+		game:   game,
+		gamers: gamers}
+	joinGamers(&arg)
+
+	black, white := gamers[0].ID, gamers[1].ID
+	if igt, _ := game.IsMyTurn(black); igt != true {
+		black, white = white, black
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold)
+	defer cancel()
+	ch := make(chan error)
+	go waitTurnRoutine(&waitGameRoutineParam{ctx: ctx, game: game, gamer: &Gamer{ID: white}, ch: ch})
+
+	if err := game.Resign(black); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	if err := <-ch; !errors.Is(err, ErrGameOver) {
+		t.Errorf("Unexpected WaitTurn err after resignation:\nwant: %v,\ngot: %v", ErrGameOver, err)
+	}
+	if err := game.Resign(white); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Unexpected second Resign err:\nwant: %v,\ngot: %v", ErrGameOver, err)
+	}
+
+	state, err := game.GameState(white)
+	if err != nil {
+		t.Fatalf("Unexpected GameState err: %v", err)
+	}
+	if state.Result == nil || state.Result.Winner != igame.White || state.Result.Method != igame.ResignMethod {
+		t.Errorf("Unexpected result after resignation:\nwant: win of white by resignation,\ngot: %v", state.Result)
+	}
+	if result, err := game.Result(black); err != nil || result.Winner != igame.White {
+		t.Errorf("Unexpected Result after resignation:\nwant: win of white,\ngot: %v, err: %v", result, err)
+	}
+}
+
+// TestWaitMove checks that WaitMove returns the move of the other gamer.
+func TestWaitMove(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold)
+	defer cancel()
+
+	move, err := game.WaitMove(ctx, white)
+	if err != nil {
+		t.Fatalf("Unexpected WaitMove err: %v", err)
+	}
+	want := igame.Move{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 5, Y: 5}}
+	if move == nil || *move != want {
+		t.Errorf("Unexpected WaitMove move:\nwant: %v,\ngot: %v", want, move)
+	}
+
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	move, err = game.WaitMove(ctx, black)
+	if !errors.Is(err, ErrGameOver) || move == nil || move.Kind != igame.ResignMove {
+		t.Errorf("Unexpected WaitMove after resignation:\nwant: resignation with %v,\ngot: %v, err: %v", ErrGameOver, move, err)
+	}
+}
+
+// TestContextCancelled checks that a cancelled context aborts a query.
+func TestContextCancelled(t *testing.T) {
+	game, black, _ := pieGame(t)
+	defer game.End()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := game.PassContext(ctx, black); !errors.Is(err, ErrCancellation) {
+		t.Errorf("Unexpected PassContext err:\nwant: %v,\ngot: %v", ErrCancellation, err)
+	}
+	if _, err := game.GameStateContext(ctx, black); !errors.Is(err, ErrCancellation) {
+		t.Errorf("Unexpected GameStateContext err:\nwant: %v,\ngot: %v", ErrCancellation, err)
+	}
+	if _, err := game.GameState(black); err != nil {
+		t.Errorf("Unexpected GameState err after cancellation: %v", err)
+	}
+}
+
+// TestWaitTurnResult checks that WaitTurnResult describes
+// why awaiting finished and who is the opponent.
+func TestWaitTurnResult(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold)
+	defer cancel()
+
+	rez, err := game.WaitTurnResult(ctx, white)
+	if err != nil {
+		t.Fatalf("Unexpected WaitTurnResult err: %v", err)
+	}
+	if rez.Reason != TurnBegunReason || rez.OpponentID != black || rez.OpponentColour != igame.Black || rez.Move == nil {
+		t.Errorf("Unexpected WaitTurnResult result: %+v", rez)
+	}
+
+	ch := make(chan *WaitResult)
+	go func() {
+		rez, err := game.WaitTurnResult(ctx, black)
+		if err != nil {
+			t.Errorf("Unexpected WaitTurnResult err: %v", err)
+		}
+		ch <- rez
+	}()
+	time.Sleep(rtDurationThreshold / 10)
+
+	if err := game.Leave(white); err != nil {
+		t.Fatalf("Unexpected Leave err: %v", err)
+	}
+	rez = <-ch
+	if rez == nil || rez.Reason != OpponentLeftReason || rez.OpponentID != white || !errors.Is(rez.Err(), ErrOtherGamerLeft) {
+		t.Errorf("Unexpected WaitTurnResult result after leaving: %+v", rez)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+func chipsOnBoard(t *testing.T, game *Game, id int) int {
+	state, err := game.GameState(id)
+	if err != nil {
+		t.Fatalf("Unexpected GameState err: %v", err)
+	}
+	return len(state.ChipsOnBoard[igame.Black]) + len(state.ChipsOnBoard[igame.White])
+}
+
+func TestUndoLastMove(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	if err := game.RequestUndo(white); !errors.Is(err, ErrUndoNotAllowed) {
+		t.Errorf("Unexpected RequestUndo err without moves:\nwant: %v,\ngot: %v.", ErrUndoNotAllowed, err)
+	}
+	if err := game.RequestUndo(black); err != nil {
+		t.Fatalf("Unexpected RequestUndo err: %v", err)
+	}
+	if err := game.AnswerUndo(black, true); !errors.Is(err, ErrNoUndoRequest) {
+		t.Errorf("Unexpected AnswerUndo err by requester:\nwant: %v,\ngot: %v.", ErrNoUndoRequest, err)
+	}
+	if err := game.AnswerUndo(white, false); err != nil {
+		t.Fatalf("Unexpected AnswerUndo err: %v", err)
+	}
+	if err := game.AnswerUndo(white, true); !errors.Is(err, ErrNoUndoRequest) {
+		t.Errorf("Unexpected AnswerUndo err on declined request:\nwant: %v,\ngot: %v.", ErrNoUndoRequest, err)
+	}
+
+	if err := game.RequestUndo(black); err != nil {
+		t.Fatalf("Unexpected RequestUndo err: %v", err)
+	}
+	if err := game.AnswerUndo(white, true); err != nil {
+		t.Fatalf("Unexpected AnswerUndo err: %v", err)
+	}
+	if igt, _ := game.IsMyTurn(black); igt != true {
+		t.Errorf("Unexpected IsMyTurn of black gamer after undo:\nwant: true,\ngot: %v.", igt)
+	}
+	if n := chipsOnBoard(t, game, black); n != 0 {
+		t.Errorf("Unexpected number of chips on board after undo:\nwant: 0,\ngot: %v.", n)
+	}
+}
+
+func TestUndoTwoMoves(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if err := game.RequestUndo(black); err != nil {
+		t.Fatalf("Unexpected RequestUndo err: %v", err)
+	}
+	if err := game.AnswerUndo(white, true); err != nil {
+		t.Fatalf("Unexpected AnswerUndo err: %v", err)
+	}
+	if igt, _ := game.IsMyTurn(black); igt != true {
+		t.Errorf("Unexpected IsMyTurn of black gamer after undo:\nwant: true,\ngot: %v.", igt)
+	}
+	if n := chipsOnBoard(t, game, white); n != 0 {
+		t.Errorf("Unexpected number of chips on board after undo:\nwant: 0,\ngot: %v.", n)
+	}
+}
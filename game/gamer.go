@@ -20,9 +20,10 @@ import "fmt"
 
 // Gamer is a struct assigned to each gamer
 type Gamer struct {
-	Name   string //the name of a player. may be the same for different player
-	ID     int    //unique id of a gamer
-	inGame Game   //gamer in pool may be vacant (InPlay is nil) or joined to this game
+	Name   string  //the name of a player. may be the same for different player
+	ID     int     //unique id of a gamer
+	Rating float64 //rating of a gamer on the Glicko scale, filled by the pool
+	inGame *Game   //gamer in pool may be vacant (InPlay is nil) or joined to this game
 }
 
 // New produces the new gamer
@@ -39,11 +40,11 @@ func (g *Gamer) String() string {
 }
 
 // GetGame returns the game of this gamer
-func (g *Gamer) GetGame() Game {
+func (g *Gamer) GetGame() *Game {
 	return g.inGame
 }
 
 // SetGame sets the game of this gamer
-func (g *Gamer) SetGame(game Game) {
+func (g *Gamer) SetGame(game *Game) {
 	g.inGame = game
 }
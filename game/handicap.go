@@ -0,0 +1,72 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// handicapKomi is the komi of handicap games
+const handicapKomi = 0.5
+
+// firstTurn returns the number of the first turn of the game:
+// white moves first in the game with handicap chips
+func (cfg *config) firstTurn() int {
+	if cfg.handicap >= 2 {
+		return 1
+	}
+	return 0
+}
+
+// setuper is implemented by Masters which allow to put chips before the first move
+type setuper interface {
+	Setup(placements []igame.Placement) error
+}
+
+// firster is implemented by Masters which track the colour making the first move
+type firster interface {
+	SetFirst(colour igame.ChipColour) error
+}
+
+// setupHandicap puts handicap chips of black on the field of master
+func setupHandicap(master igame.Master, handicap int) error {
+	if handicap < 2 {
+		return nil
+	}
+	points := igame.HandicapPoints(master.Size(), handicap)
+	if points == nil {
+		return fmt.Errorf("%w: %d on the field of size %d", ErrHandicap, handicap, master.Size())
+	}
+	field, ok := master.(setuper)
+	if !ok {
+		return fmt.Errorf("%w: the master can't put handicap chips", ErrHandicap)
+	}
+
+	placements := make([]igame.Placement, len(points))
+	for i, td := range points {
+		placements[i] = igame.Placement{Colour: igame.Black, Position: td}
+	}
+	if err := field.Setup(placements); err != nil {
+		return err
+	}
+	if field, ok := master.(firster); ok {
+		return field.SetFirst(igame.White)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestHandicap checks that handicap chips are put on the field,
+// komi is adjusted and white moves first.
+func TestHandicap(t *testing.T) {
+	game, err := NewGame(usualSize, usualKomi, WithHandicap(3))
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+	gamers := copyGamers(validGamers)
+	joinGamers(&commonArgs{t: t, game: game, gamers: gamers})
+
+	var black, white int
+	for _, g := range gamers {
+		gs, err := game.GamerState(g.ID)
+		if err != nil {
+			t.Fatalf("Unexpected GamerState err: %v", err)
+		}
+		if gs.Colour == igame.Black {
+			black = g.ID
+		} else {
+			white = g.ID
+		}
+	}
+
+	state, err := game.GameState(black)
+	if err != nil {
+		t.Fatalf("Unexpected GameState err: %v", err)
+	}
+	if state.Handicap != 3 || state.Komi != handicapKomi || len(state.ChipsOnBoard[igame.Black]) != 3 {
+		t.Errorf("Unexpected state of handicap game: handicap %d, komi %v, black chips %v",
+			state.Handicap, state.Komi, state.ChipsOnBoard[igame.Black])
+	}
+	if igt, err := game.IsMyTurn(white); err != nil || igt != true {
+		t.Errorf("Unexpected IsMyTurn of white: %v, err: %v", igt, err)
+	}
+	if err := game.MakeTurn(white, &igame.TurnData{X: 5, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if err := game.RequestUndo(black); !errors.Is(err, ErrUndoNotAllowed) {
+		t.Errorf("Unexpected RequestUndo err of black without moves:\nwant: %v,\ngot: %v", ErrUndoNotAllowed, err)
+	}
+}
+
+// TestHandicapTooBig checks that the game isn't created with handicap
+// exceeding star points of the field.
+func TestHandicapTooBig(t *testing.T) {
+	if _, err := NewGame(usualSize, usualKomi, WithHandicap(6)); !errors.Is(err, ErrHandicap) {
+		t.Errorf("Unexpected NewGame err:\nwant: %v,\ngot: %v", ErrHandicap, err)
+	}
+}
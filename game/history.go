@@ -0,0 +1,135 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+	"github.com/yagoggame/gomaster/game/sgf"
+)
+
+// HistoryMove describes a move of the game history
+type HistoryMove struct {
+	igame.Move
+	ID        int               // id of the gamer made the move
+	Name      string            // name of the gamer made the move
+	Time      time.Time         // time the move was made
+	Remaining time.Duration     // remaining time of the gamer at the moment of the move, increment excluded
+	Meta      map[string]string // metadata reported by the client with the move, nil if none
+}
+
+// recorder is implemented by Masters which provide the game record
+type recorder interface {
+	Record() *sgf.Record
+}
+
+// recordMove appends the move made by the gamer with id to the game history
+func recordMove(gd *gmaeDescriptor, id int, gs *GamerState, kind igame.MoveKind, td *igame.TurnData, meta map[string]string) {
+	move := HistoryMove{
+		Move: igame.Move{Colour: movingColour(gs, gd), Kind: kind},
+		ID:   id,
+		Name: gs.Name,
+		Time: gd.cfg.timeSource().Now(),
+		Meta: meta,
+	}
+	if gd.cfg.timeControl() {
+		move.Remaining = gs.Remaining - gd.spent(id)
+	}
+	if td != nil {
+		move.Position = *td
+	}
+	gd.history = append(gd.history, move)
+	storeMove(gd, move)
+}
+
+// undoMove reverts the last move on the field and removes it from the game history
+func undoMove(gd *gmaeDescriptor) error {
+	if err := gd.master.(igame.Undoer).Undo(); err != nil {
+		return err
+	}
+	if len(gd.history) > 0 {
+		gd.history = gd.history[:len(gd.history)-1]
+	}
+	return nil
+}
+
+// history implements concurrently safe processing of querry of
+// History function
+func history(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	colour, ok := viewerColour(gamerStates, cmd.id, gd)
+	if ok == false {
+		cmd.rez <- opError("history", cmd.id, ErrUnknownID)
+		return
+	}
+
+	rez := make([]HistoryMove, len(gd.history))
+	copy(rez, gd.history)
+	for i := range rez {
+		if hidden(gd, colour, rez[i].Colour) {
+			rez[i].Position = igame.TurnData{}
+		}
+	}
+	cmd.rez <- rez
+}
+
+// gameRecord implements concurrently safe processing of querry of
+// SGF function
+func gameRecord(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	colour, ok := viewerColour(gamerStates, cmd.id, gd)
+	if ok == false {
+		cmd.rez <- opError("gameRecord", cmd.id, ErrUnknownID)
+		return
+	}
+	if hidden(gd, colour, igame.NoColour) {
+		cmd.rez <- opError("gameRecord", cmd.id, ErrBlind)
+		return
+	}
+
+	var rec *sgf.Record
+	if r, ok := gd.master.(recorder); ok {
+		rec = r.Record()
+	} else {
+		rec = &sgf.Record{Size: gd.master.Size(), Komi: gd.master.State().Komi}
+		for _, move := range gd.history {
+			rec.Moves = append(rec.Moves, move.Move)
+		}
+	}
+	rec.PlayerBlack = gd.players[igame.Black]
+	rec.PlayerWhite = gd.players[igame.White]
+	rec.Handicap = gd.cfg.handicap
+	rec.TimeLimit = gd.cfg.mainTime
+	rec.Overtime = overtime(gd.cfg)
+	rec.Result = sgf.FormatResult(finalResult(gd))
+	cmd.rez <- rec.String()
+}
+
+// overtime describes the time added to the main time by cfg in SGF OT property
+func overtime(cfg *config) string {
+	switch {
+	case cfg.increment > 0:
+		return fmt.Sprintf("Fischer %v", cfg.increment)
+	case cfg.perMove > 0:
+		return fmt.Sprintf("%v per move", cfg.perMove)
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestHistory checks that moves are recorded in order they were made
+// and moves reverted by undo are removed from the history.
+func TestHistory(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if err := game.RequestUndo(black); err != nil {
+		t.Fatalf("Unexpected RequestUndo err: %v", err)
+	}
+	if err := game.AnswerUndo(white, true); err != nil {
+		t.Fatalf("Unexpected AnswerUndo err: %v", err)
+	}
+	if err := game.Resign(black); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+
+	moves, err := game.History(white)
+	if err != nil {
+		t.Fatalf("Unexpected History err: %v", err)
+	}
+	want := []igame.Move{
+		{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 5, Y: 5}},
+		{Colour: igame.White, Kind: igame.PassMove},
+		{Colour: igame.Black, Kind: igame.ResignMove},
+	}
+	ids := []int{black, white, black}
+	if len(moves) != len(want) {
+		t.Fatalf("Unexpected History length:\nwant: %d,\ngot: %d: %v", len(want), len(moves), moves)
+	}
+	for i := range want {
+		if moves[i].Move != want[i] || moves[i].ID != ids[i] {
+			t.Errorf("Unexpected move %d:\nwant: %v by %d,\ngot: %v by %d", i, want[i], ids[i], moves[i].Move, moves[i].ID)
+		}
+		if i > 0 && moves[i].Time.Before(moves[i-1].Time) {
+			t.Errorf("Unexpected time of move %d: %v before %v", i, moves[i].Time, moves[i-1].Time)
+		}
+	}
+
+	if _, err := game.History(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected History err for unknown gamer:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
+
+// TestSGF checks that the game record contains names of gamers,
+// time control settings, result and moves of the game.
+func TestSGF(t *testing.T) {
+	game, black, white := pieGame(t, WithFischerTime(time.Minute, 10*time.Second))
+	defer game.End()
+
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	record, err := game.SGF(black)
+	if err != nil {
+		t.Fatalf("Unexpected SGF err: %v", err)
+	}
+
+	blackState, _ := game.GamerState(black)
+	whiteState, _ := game.GamerState(white)
+	for _, want := range []string{"SZ[9]", "PB[" + blackState.Name + "]", "PW[" + whiteState.Name + "]",
+		"TM[60]", "OT[Fischer 10s]", "RE[B+R]", ";B[ee]"} {
+		if !strings.Contains(record, want) {
+			t.Errorf("Unexpected SGF record: %s not found in %s", want, record)
+		}
+	}
+}
+
+// TestHistoryRemaining checks that moves are recorded with remaining time of the gamer.
+func TestHistoryRemaining(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, _, white := pieGame(t, WithAbsoluteTime(time.Minute), WithTimeSource(ft))
+	defer game.End()
+
+	ft.advance(15 * time.Second)
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	moves, err := game.History(white)
+	if err != nil {
+		t.Fatalf("Unexpected History err: %v", err)
+	}
+	if len(moves) != 2 || moves[1].Remaining != 45*time.Second || !moves[1].Time.Equal(ft.Now()) {
+		t.Errorf("Unexpected History: %+v", moves)
+	}
+}
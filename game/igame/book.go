@@ -0,0 +1,30 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame
+
+// BookMove is a move suggested by an opening book
+type BookMove struct {
+	Colour   ChipColour
+	Position TurnData
+	Weight   int    // relative frequency or preference of the move
+	Name     string // name of the opening or joseki, may be empty
+}
+
+// OpeningBook provides moves known for positions identified by Zobrist hash
+type OpeningBook interface {
+	Lookup(hash uint64) []BookMove
+}
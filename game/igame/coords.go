@@ -0,0 +1,63 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrCoordinate is an error of conversion of wrong coordinate
+var ErrCoordinate = errors.New("wrong coordinate")
+
+// columnLetters holds letters of columns, "I" is skipped by convention
+const columnLetters = "ABCDEFGHJKLMNOPQRSTUVWXYZ"
+
+// ColumnLetter returns the letter of x'th column
+// or an empty string if x is out of range
+func ColumnLetter(x int) string {
+	if x < 1 || x > len(columnLetters) {
+		return ""
+	}
+	return columnLetters[x-1 : x]
+}
+
+// FormatCoordinate converts td to the conventional notation like "D4"
+// for the field of size. Rows are counted from the bottom of the field.
+func FormatCoordinate(td TurnData, size int) (string, error) {
+	if size < 1 || size > len(columnLetters) || td.X < 1 || td.Y < 1 || td.X > size || td.Y > size {
+		return "", fmt.Errorf("%w: %v on field of size %d", ErrCoordinate, td, size)
+	}
+	return ColumnLetter(td.X) + strconv.Itoa(size-td.Y+1), nil
+}
+
+// ParseCoordinate converts the conventional notation like "D4"
+// to TurnData for the field of size.
+func ParseCoordinate(s string, size int) (TurnData, error) {
+	if len(s) < 2 || size < 1 || size > len(columnLetters) {
+		return TurnData{}, fmt.Errorf("%w: %q on field of size %d", ErrCoordinate, s, size)
+	}
+
+	x := strings.IndexByte(columnLetters, strings.ToUpper(s[:1])[0]) + 1
+	row, err := strconv.Atoi(s[1:])
+	if x < 1 || x > size || err != nil || row < 1 || row > size {
+		return TurnData{}, fmt.Errorf("%w: %q on field of size %d", ErrCoordinate, s, size)
+	}
+	return TurnData{X: x, Y: size - row + 1}, nil
+}
@@ -0,0 +1,83 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/igame"
+)
+
+var coordinateTests = []struct {
+	name  string
+	coord string
+	size  int
+	td    TurnData
+	want  error
+}{
+	{name: "19 lower left", coord: "A1", size: 19, td: TurnData{X: 1, Y: 19}},
+	{name: "19 star", coord: "D4", size: 19, td: TurnData{X: 4, Y: 16}},
+	{name: "19 upper right", coord: "T19", size: 19, td: TurnData{X: 19, Y: 1}},
+	{name: "19 Q16", coord: "Q16", size: 19, td: TurnData{X: 16, Y: 4}},
+	{name: "9 J9", coord: "J9", size: 9, td: TurnData{X: 9, Y: 1}},
+	{name: "letter I", coord: "I5", size: 19, want: ErrCoordinate},
+	{name: "column out of range", coord: "K5", size: 9, want: ErrCoordinate},
+	{name: "row out of range", coord: "A10", size: 9, want: ErrCoordinate},
+	{name: "row zero", coord: "A0", size: 9, want: ErrCoordinate},
+	{name: "malformed", coord: "AA", size: 9, want: ErrCoordinate},
+}
+
+func TestParseCoordinate(t *testing.T) {
+	for _, test := range coordinateTests {
+		t.Run(test.name, func(t *testing.T) {
+			td, err := ParseCoordinate(test.coord, test.size)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("Unexpected ParseCoordinate err:\nwant: %v,\ngot: %v", test.want, err)
+			}
+			if err == nil && td != test.td {
+				t.Errorf("Unexpected ParseCoordinate result:\nwant: %v,\ngot: %v", test.td, td)
+			}
+		})
+	}
+
+	if td, err := ParseCoordinate("q16", 19); err != nil || td != (TurnData{X: 16, Y: 4}) {
+		t.Errorf("Unexpected ParseCoordinate result for lower case:\nwant: %v,\ngot: %v, %v", TurnData{X: 16, Y: 4}, td, err)
+	}
+}
+
+func TestFormatCoordinate(t *testing.T) {
+	for _, test := range coordinateTests {
+		if test.want != nil {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			coord, err := FormatCoordinate(test.td, test.size)
+			if err != nil {
+				t.Fatalf("Unexpected FormatCoordinate err: %v", err)
+			}
+			if coord != test.coord {
+				t.Errorf("Unexpected FormatCoordinate result:\nwant: %s,\ngot: %s", test.coord, coord)
+			}
+		})
+	}
+
+	want := ErrCoordinate
+	if _, err := FormatCoordinate(TurnData{X: 10, Y: 1}, 9); !errors.Is(err, want) {
+		t.Errorf("Unexpected FormatCoordinate err:\nwant: %v,\ngot: %v", want, err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame
+
+// StateDiff describes changes between two states of the field
+type StateDiff struct {
+	Added    map[ChipColour][]*TurnData // chips appeared on the field
+	Removed  map[ChipColour][]*TurnData // chips gone from the field
+	Scores   map[ChipColour]float64     // changes of scores
+	GameOver bool                       // game over flag of the new state
+	KoPoint  *TurnData                  // ko point of the new state
+	LastMove *Move                      // last move of the new state
+	Hash     uint64                     // hash of the new state
+}
+
+// Diff returns changes made to the field between states from and to.
+// Nil from is treated as a state of the empty field.
+func Diff(from, to *FieldState) *StateDiff {
+	if from == nil {
+		from = &FieldState{}
+	}
+	diff := &StateDiff{
+		Added:    make(map[ChipColour][]*TurnData, 2),
+		Removed:  make(map[ChipColour][]*TurnData, 2),
+		Scores:   make(map[ChipColour]float64, 2),
+		GameOver: to.GameOver,
+		KoPoint:  to.KoPoint,
+		LastMove: to.LastMove,
+		Hash:     to.Hash,
+	}
+
+	for _, colour := range []ChipColour{Black, White} {
+		diff.Added[colour] = subtractPositions(to.ChipsOnBoard[colour], from.ChipsOnBoard[colour])
+		diff.Removed[colour] = subtractPositions(from.ChipsOnBoard[colour], to.ChipsOnBoard[colour])
+		diff.Scores[colour] = to.Scores[colour] - from.Scores[colour]
+	}
+	return diff
+}
+
+// IsEmpty returns true if no chips are added or removed and scores are the same.
+func (diff *StateDiff) IsEmpty() bool {
+	for _, colour := range []ChipColour{Black, White} {
+		if len(diff.Added[colour]) > 0 || len(diff.Removed[colour]) > 0 || diff.Scores[colour] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// subtractPositions returns positions of a which are not in b
+func subtractPositions(a, b []*TurnData) []*TurnData {
+	present := make(map[TurnData]bool, len(b))
+	for _, td := range b {
+		present[*td] = true
+	}
+
+	rest := make([]*TurnData, 0)
+	for _, td := range a {
+		if !present[*td] {
+			tdCpy := *td
+			rest = append(rest, &tdCpy)
+		}
+	}
+	return rest
+}
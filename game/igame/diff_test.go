@@ -0,0 +1,67 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestDiff(t *testing.T) {
+	from := &FieldState{
+		ChipsOnBoard: map[ChipColour][]*TurnData{
+			Black: {{X: 1, Y: 1}, {X: 2, Y: 2}},
+			White: {{X: 3, Y: 3}},
+		},
+		Scores: map[ChipColour]float64{Black: 1, White: 0.5},
+	}
+	to := &FieldState{
+		ChipsOnBoard: map[ChipColour][]*TurnData{
+			Black: {{X: 2, Y: 2}},
+			White: {{X: 1, Y: 1}, {X: 3, Y: 3}},
+		},
+		Scores:   map[ChipColour]float64{Black: 1, White: 2.5},
+		KoPoint:  &TurnData{X: 4, Y: 4},
+		LastMove: &Move{Colour: White, Kind: PlaceMove, Position: TurnData{X: 1, Y: 1}},
+		Hash:     42,
+	}
+
+	diff := Diff(from, to)
+	want := &StateDiff{
+		Added:    map[ChipColour][]*TurnData{Black: {}, White: {{X: 1, Y: 1}}},
+		Removed:  map[ChipColour][]*TurnData{Black: {{X: 1, Y: 1}}, White: {}},
+		Scores:   map[ChipColour]float64{Black: 0, White: 2},
+		KoPoint:  to.KoPoint,
+		LastMove: to.LastMove,
+		Hash:     42,
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Unexpected Diff result:\nwant: %v,\ngot: %v", want, diff)
+	}
+	if diff.IsEmpty() {
+		t.Errorf("Unexpected IsEmpty result:\nwant: false,\ngot: true")
+	}
+
+	if diff := Diff(to, to); !diff.IsEmpty() {
+		t.Errorf("Unexpected Diff of the same states:\nwant: empty,\ngot: %v", diff)
+	}
+	if diff := Diff(nil, to); len(diff.Added[White]) != 2 || len(diff.Added[Black]) != 1 {
+		t.Errorf("Unexpected Diff from nil state: %v", diff)
+	}
+}
@@ -31,20 +31,105 @@ type TurnData struct {
 	X, Y int
 }
 
+// MoveKind provides datatype of kinds of moves
+type MoveKind int
+
+// Set of kinds of moves
+const (
+	PlaceMove  MoveKind = iota // a chip is put on the field
+	PassMove                   // a gamer passes
+	ResignMove                 // a gamer resigns
+)
+
+// Move describes a single move made by a gamer
+type Move struct {
+	Colour   ChipColour
+	Kind     MoveKind
+	Position TurnData // position of a chip, meaningful for PlaceMove only
+}
+
+// Placement describes a chip put on the field during setup of a position
+type Placement struct {
+	Colour   ChipColour
+	Position TurnData
+}
+
 // FieldState describes the game state on the field
 type FieldState struct {
 	GameOver           bool
+	Termination        Termination // reason of the game end, NotTerminated if the game is in progress
+	Result             *Result     // outcome of the finished game, nil if the game is in progress
 	ChipsInCup         map[ChipColour]int
-	ChipsCuptured      map[ChipColour]int
+	ChipsCuptured      map[ChipColour]int // chips of colour lost to the opponent
+	Prisoners          map[ChipColour]int // chips taken by colour, pass stones included if the ruleset uses them
 	PointsUnderControl map[ChipColour][]*TurnData
 	Komi               float64
 	Scores             map[ChipColour]float64
 	ChipsOnBoard       map[ChipColour][]*TurnData
+	KoPoint            *TurnData // position forbidden by ko rule, nil if there is no ko
+	LastMove           *Move     // the most recent move, nil if no moves made yet
+	Hash               uint64    // Zobrist hash of the position of chips on the field
+	Handicap           int       // number of handicap chips of black, 0 if the game has no handicap
+}
+
+// Estimate holds an approximate evaluation of the game in progress
+type Estimate struct {
+	Territory map[ChipColour][]*TurnData // empty points estimated to be controlled by colour
+	Scores    map[ChipColour]float64     // estimated area scores, komi included
+	Leader    ChipColour                 // colour ahead, NoColour on equal scores
+	Margin    float64                    // difference of scores of the leader and the other colour
 }
 
 // Master interface wraps functions to work with game field and it's state
 type Master interface {
 	Move(colour ChipColour, td *TurnData) error
+	Pass(colour ChipColour) error
+	Resign(colour ChipColour) error
 	Size() int
 	State() *FieldState
 }
+
+// Viewer is implemented by Masters which show the state
+// to gamers differently from the full state
+type Viewer interface {
+	StateFor(viewer ChipColour) *FieldState
+}
+
+// KomiSetter is implemented by Masters which allow to negotiate komi
+type KomiSetter interface {
+	SetKomi(komi float64) error
+}
+
+// Scorer is implemented by Masters which count the final score
+// with chains of dead chips removed
+type Scorer interface {
+	FinalScore(deadGroups []TurnData) (*Result, error)
+}
+
+// Estimator is implemented by Masters which evaluate the game in progress
+type Estimator interface {
+	Estimate() *Estimate
+}
+
+// RulesetReporter is implemented by Masters which tell the ruleset of the game
+type RulesetReporter interface {
+	Ruleset() Ruleset
+}
+
+// Referee adjudicates dead chips when gamers can't agree on them in counting phase
+type Referee interface {
+	DeadChips(state *FieldState) ([]TurnData, error)
+}
+
+// RefereeFunc is an adapter to use a function as the Referee
+type RefereeFunc func(state *FieldState) ([]TurnData, error)
+
+// DeadChips calls f(state).
+func (f RefereeFunc) DeadChips(state *FieldState) ([]TurnData, error) {
+	return f(state)
+}
+
+// Undoer is implemented by Masters which allow to revert the last move
+type Undoer interface {
+	Undo() error
+}
@@ -0,0 +1,143 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownName is an error of parsing unknown name of enumerated value
+var ErrUnknownName = errors.New("unknown name")
+
+// names of enumerated values, indexed by value
+var (
+	colourNames      = []string{"none", "black", "white"}
+	moveKindNames    = []string{"place", "pass", "resign"}
+	terminationNames = []string{"none", "no chips left", "two passes", "resignation", "no legal moves", "first capture"}
+	methodNames      = []string{"score", "resign", "timeout", "forfeit", "capture", "abort", "referee"}
+	rulesetNames     = []string{"japanese", "chinese", "aga", "new zealand"}
+)
+
+// String provides compatibility with Stringer interface.
+func (c ChipColour) String() string {
+	return nameOf(int(c), colourNames, "ChipColour")
+}
+
+// MarshalText provides compatibility with encoding.TextMarshaler interface,
+// so maps keyed by ChipColour are marshaled to JSON with stable keys.
+func (c ChipColour) MarshalText() ([]byte, error) {
+	return marshalName(int(c), colourNames, "colour")
+}
+
+// UnmarshalText provides compatibility with encoding.TextUnmarshaler interface.
+func (c *ChipColour) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, colourNames, "colour")
+	*c = ChipColour(v)
+	return err
+}
+
+// String provides compatibility with Stringer interface.
+func (k MoveKind) String() string {
+	return nameOf(int(k), moveKindNames, "MoveKind")
+}
+
+// MarshalText provides compatibility with encoding.TextMarshaler interface.
+func (k MoveKind) MarshalText() ([]byte, error) {
+	return marshalName(int(k), moveKindNames, "move kind")
+}
+
+// UnmarshalText provides compatibility with encoding.TextUnmarshaler interface.
+func (k *MoveKind) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, moveKindNames, "move kind")
+	*k = MoveKind(v)
+	return err
+}
+
+// String provides compatibility with Stringer interface.
+func (t Termination) String() string {
+	return nameOf(int(t), terminationNames, "Termination")
+}
+
+// MarshalText provides compatibility with encoding.TextMarshaler interface.
+func (t Termination) MarshalText() ([]byte, error) {
+	return marshalName(int(t), terminationNames, "termination")
+}
+
+// UnmarshalText provides compatibility with encoding.TextUnmarshaler interface.
+func (t *Termination) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, terminationNames, "termination")
+	*t = Termination(v)
+	return err
+}
+
+// String provides compatibility with Stringer interface.
+func (m ResultMethod) String() string {
+	return nameOf(int(m), methodNames, "ResultMethod")
+}
+
+// MarshalText provides compatibility with encoding.TextMarshaler interface.
+func (m ResultMethod) MarshalText() ([]byte, error) {
+	return marshalName(int(m), methodNames, "result method")
+}
+
+// UnmarshalText provides compatibility with encoding.TextUnmarshaler interface.
+func (m *ResultMethod) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, methodNames, "result method")
+	*m = ResultMethod(v)
+	return err
+}
+
+// String provides compatibility with Stringer interface.
+func (r Ruleset) String() string {
+	return nameOf(int(r), rulesetNames, "Ruleset")
+}
+
+// MarshalText provides compatibility with encoding.TextMarshaler interface.
+func (r Ruleset) MarshalText() ([]byte, error) {
+	return marshalName(int(r), rulesetNames, "ruleset")
+}
+
+// UnmarshalText provides compatibility with encoding.TextUnmarshaler interface.
+func (r *Ruleset) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, rulesetNames, "ruleset")
+	*r = Ruleset(v)
+	return err
+}
+
+func nameOf(v int, names []string, typeName string) string {
+	if v < 0 || v >= len(names) {
+		return fmt.Sprintf("%s(%d)", typeName, v)
+	}
+	return names[v]
+}
+
+func marshalName(v int, names []string, what string) ([]byte, error) {
+	if v < 0 || v >= len(names) {
+		return nil, fmt.Errorf("failed to marshal %s %d: %w", what, v, ErrUnknownName)
+	}
+	return []byte(names[v]), nil
+}
+
+func unmarshalName(text []byte, names []string, what string) (int, error) {
+	for v, name := range names {
+		if name == string(text) {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("failed to unmarshal %s %q: %w", what, text, ErrUnknownName)
+}
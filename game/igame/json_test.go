@@ -0,0 +1,95 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/igame"
+)
+
+var stateJSONTests = []struct {
+	name  string
+	state *FieldState
+}{
+	{
+		name:  "empty",
+		state: &FieldState{},
+	},
+	{
+		name: "full",
+		state: &FieldState{
+			GameOver:           true,
+			Termination:        TwoPasses,
+			Result:             &Result{Winner: White, Margin: 4.5, Method: ScoreMethod, Scores: map[ChipColour]float64{Black: 2, White: 6.5}},
+			ChipsInCup:         map[ChipColour]int{Black: 180, White: 180},
+			ChipsCuptured:      map[ChipColour]int{Black: 0, White: 1},
+			Prisoners:          map[ChipColour]int{Black: 1, White: 0},
+			PointsUnderControl: map[ChipColour][]*TurnData{Black: {{X: 1, Y: 1}}, White: {}},
+			Komi:               6.5,
+			Scores:             map[ChipColour]float64{Black: 2, White: 6.5},
+			ChipsOnBoard:       map[ChipColour][]*TurnData{Black: {{X: 2, Y: 2}}, White: {}},
+			KoPoint:            &TurnData{X: 3, Y: 2},
+			LastMove:           &Move{Colour: White, Kind: PassMove},
+		},
+	},
+}
+
+func TestFieldStateJSON(t *testing.T) {
+	for _, test := range stateJSONTests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.state)
+			if err != nil {
+				t.Fatalf("Unexpected Marshal err: %v", err)
+			}
+			if test.state.ChipsInCup != nil && !strings.Contains(string(data), `"black":180`) {
+				t.Errorf("Unexpected JSON keys:\nwant: \"black\" key,\ngot: %s", data)
+			}
+
+			state := &FieldState{}
+			if err := json.Unmarshal(data, state); err != nil {
+				t.Fatalf("Unexpected Unmarshal err: %v", err)
+			}
+			if !reflect.DeepEqual(state, test.state) {
+				t.Errorf("Unexpected round trip result:\nwant: %v,\ngot: %v", test.state, state)
+			}
+		})
+	}
+}
+
+func TestRulesetText(t *testing.T) {
+	data, err := NewZealandRules.MarshalText()
+	if err != nil || string(data) != "new zealand" {
+		t.Fatalf("Unexpected MarshalText: %q, err: %v", data, err)
+	}
+	var ruleset Ruleset
+	if err := ruleset.UnmarshalText(data); err != nil || ruleset != NewZealandRules {
+		t.Errorf("Unexpected UnmarshalText: %v, err: %v", ruleset, err)
+	}
+}
+
+func TestChipColourUnmarshalUnknown(t *testing.T) {
+	var colour ChipColour
+	want := ErrUnknownName
+	if err := colour.UnmarshalText([]byte("red")); !errors.Is(err, want) {
+		t.Errorf("Unexpected UnmarshalText err:\nwant: %v,\ngot: %v", want, err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame
+
+// Ruleset provides datatype of sets of game rules
+type Ruleset int
+
+// Set of supported rulesets
+const (
+	JapaneseRules   Ruleset = iota // territory scoring
+	ChineseRules                   // area scoring
+	AGARules                       // territory scoring with pass stones, white passes last
+	NewZealandRules                // area scoring, suicide allowed
+)
+
+// DefaultKomi returns the komi customary for ruleset, 0 for unknown rulesets
+func DefaultKomi(ruleset Ruleset) float64 {
+	switch ruleset {
+	case JapaneseRules:
+		return 6.5
+	case ChineseRules, AGARules:
+		return 7.5
+	case NewZealandRules:
+		return 7
+	}
+	return 0
+}
+
+// Termination provides datatype of reasons of the game end
+type Termination int
+
+// Set of reasons of the game end
+const (
+	NotTerminated Termination = iota // the game is in progress
+	NoChipsLeft                      // a colour has no chips left in the cup
+	TwoPasses                        // both gamers passed one after another
+	Resignation                      // a gamer resigned
+	NoLegalMoves                     // the colour to move has no legal moves
+	FirstCapture                     // chips are captured in capture go
+)
+
+// ResultMethod provides datatype of ways the game is decided
+type ResultMethod int
+
+// Set of ways the game is decided
+const (
+	ScoreMethod   ResultMethod = iota // by counting of scores
+	ResignMethod                      // by resignation of the loser
+	TimeoutMethod                     // by running out of time of the loser
+	ForfeitMethod                     // by forfeit of the loser
+	CaptureMethod                     // by the first capture in capture go
+	AbortMethod                       // aborted by agreement of gamers, there is no winner
+	RefereeMethod                     // by counting of scores with dead chips adjudicated by the referee
+)
+
+// Result describes the decided outcome of a game
+type Result struct {
+	Winner ChipColour             // NoColour on equal scores if jigo is a draw
+	Jigo   bool                   // scores are equal, the game is drawn unless the winner is set by a tie-break
+	Margin float64                // difference of scores of the winner and the loser, 0 if not decided by score
+	Method ResultMethod           // the way the game is decided
+	Scores map[ChipColour]float64 // final scores, komi included, nil if not decided by score
+}
@@ -0,0 +1,38 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame_test
+
+import (
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestDefaultKomi(t *testing.T) {
+	tests := map[Ruleset]float64{
+		JapaneseRules:   6.5,
+		ChineseRules:    7.5,
+		AGARules:        7.5,
+		NewZealandRules: 7,
+		Ruleset(-1):     0,
+	}
+	for ruleset, want := range tests {
+		if got := DefaultKomi(ruleset); got != want {
+			t.Errorf("Unexpected DefaultKomi(%d):\nwant: %v,\ngot: %v.", ruleset, want, got)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame
+
+// StarPoints returns positions of star points (hoshi) for the field of size.
+// Fields smaller than 7x7 have no star points.
+func StarPoints(size int) []TurnData {
+	if size < 7 {
+		return []TurnData{}
+	}
+
+	edge := starEdge(size)
+	lines := []int{edge, size - edge + 1}
+	if size%2 == 1 && size >= 15 {
+		lines = []int{edge, size/2 + 1, size - edge + 1}
+	}
+
+	points := make([]TurnData, 0, len(lines)*len(lines)+1)
+	for _, x := range lines {
+		for _, y := range lines {
+			points = append(points, TurnData{X: x, Y: y})
+		}
+	}
+	if size%2 == 1 && len(lines) == 2 {
+		points = append(points, TurnData{X: size/2 + 1, Y: size/2 + 1})
+	}
+	return points
+}
+
+// starEdge returns the line of star points nearest to the edge of the field of size
+func starEdge(size int) int {
+	if size >= 13 {
+		return 4
+	}
+	return 3
+}
+
+// HandicapPoints returns positions of handicap chips in the traditional order
+// for the field of size. Handicap less than 2 needs no chips.
+// It returns nil if the field has not enough star points for handicap.
+func HandicapPoints(size, handicap int) []TurnData {
+	if handicap < 2 {
+		return []TurnData{}
+	}
+
+	points := StarPoints(size)
+	if handicap > len(points) {
+		return nil
+	}
+
+	lo, hi, mid := starEdge(size), size-starEdge(size)+1, size/2+1
+	corners := []TurnData{{X: hi, Y: lo}, {X: lo, Y: hi}, {X: hi, Y: hi}, {X: lo, Y: lo}}
+	sides := []TurnData{{X: lo, Y: mid}, {X: hi, Y: mid}, {X: mid, Y: lo}, {X: mid, Y: hi}}
+	center := TurnData{X: mid, Y: mid}
+
+	switch {
+	case handicap <= 4:
+		return corners[:handicap]
+	case handicap%2 == 1:
+		// odd handicap takes the center and the even number of other points.
+		return append(HandicapPoints(size, handicap-1), center)
+	}
+	return append(corners, sides[:handicap-4]...)
+}
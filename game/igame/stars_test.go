@@ -0,0 +1,78 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/igame"
+)
+
+var starPointsTests = []struct {
+	name string
+	size int
+	want []TurnData
+}{
+	{name: "5x5", size: 5, want: []TurnData{}},
+	{name: "9x9", size: 9, want: []TurnData{{X: 3, Y: 3}, {X: 3, Y: 7}, {X: 7, Y: 3}, {X: 7, Y: 7}, {X: 5, Y: 5}}},
+	{name: "19x19", size: 19, want: []TurnData{
+		{X: 4, Y: 4}, {X: 4, Y: 10}, {X: 4, Y: 16},
+		{X: 10, Y: 4}, {X: 10, Y: 10}, {X: 10, Y: 16},
+		{X: 16, Y: 4}, {X: 16, Y: 10}, {X: 16, Y: 16},
+	}},
+}
+
+func TestStarPoints(t *testing.T) {
+	for _, test := range starPointsTests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := StarPoints(test.size); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Unexpected StarPoints():\nwant: %v,\ngot: %v.", test.want, got)
+			}
+		})
+	}
+}
+
+var handicapPointsTests = []struct {
+	name     string
+	size     int
+	handicap int
+	want     []TurnData
+}{
+	{name: "no handicap", size: 19, handicap: 1, want: []TurnData{}},
+	{name: "9x9 2", size: 9, handicap: 2, want: []TurnData{{X: 7, Y: 3}, {X: 3, Y: 7}}},
+	{name: "9x9 5", size: 9, handicap: 5, want: []TurnData{{X: 7, Y: 3}, {X: 3, Y: 7}, {X: 7, Y: 7}, {X: 3, Y: 3}, {X: 5, Y: 5}}},
+	{name: "9x9 6", size: 9, handicap: 6, want: nil},
+	{name: "19x19 7", size: 19, handicap: 7, want: []TurnData{
+		{X: 16, Y: 4}, {X: 4, Y: 16}, {X: 16, Y: 16}, {X: 4, Y: 4},
+		{X: 4, Y: 10}, {X: 16, Y: 10}, {X: 10, Y: 10},
+	}},
+	{name: "19x19 8", size: 19, handicap: 8, want: []TurnData{
+		{X: 16, Y: 4}, {X: 4, Y: 16}, {X: 16, Y: 16}, {X: 4, Y: 4},
+		{X: 4, Y: 10}, {X: 16, Y: 10}, {X: 10, Y: 4}, {X: 10, Y: 16},
+	}},
+}
+
+func TestHandicapPoints(t *testing.T) {
+	for _, test := range handicapPointsTests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := HandicapPoints(test.size, test.handicap); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Unexpected HandicapPoints():\nwant: %v,\ngot: %v.", test.want, got)
+			}
+		})
+	}
+}
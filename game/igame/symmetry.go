@@ -0,0 +1,71 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame
+
+// Symmetry provides datatype of rotations and reflections of the field
+type Symmetry int
+
+// Set of symmetries of the square field
+const (
+	Identity         Symmetry = iota // no change
+	Rotate90                         // clockwise rotation by 90 degrees
+	Rotate180                        // rotation by 180 degrees
+	Rotate270                        // clockwise rotation by 270 degrees
+	FlipHorizontal                   // reflection of columns
+	FlipVertical                     // reflection of rows
+	FlipDiagonal                     // reflection over the main diagonal
+	FlipAntiDiagonal                 // reflection over the anti-diagonal
+)
+
+// Symmetries lists all symmetries of the square field
+var Symmetries = []Symmetry{
+	Identity, Rotate90, Rotate180, Rotate270,
+	FlipHorizontal, FlipVertical, FlipDiagonal, FlipAntiDiagonal,
+}
+
+// Apply returns position td of the field of size transformed by the symmetry
+func (s Symmetry) Apply(td TurnData, size int) TurnData {
+	x, y := td.X, td.Y
+	switch s {
+	case Rotate90:
+		return TurnData{X: size + 1 - y, Y: x}
+	case Rotate180:
+		return TurnData{X: size + 1 - x, Y: size + 1 - y}
+	case Rotate270:
+		return TurnData{X: y, Y: size + 1 - x}
+	case FlipHorizontal:
+		return TurnData{X: size + 1 - x, Y: y}
+	case FlipVertical:
+		return TurnData{X: x, Y: size + 1 - y}
+	case FlipDiagonal:
+		return TurnData{X: y, Y: x}
+	case FlipAntiDiagonal:
+		return TurnData{X: size + 1 - y, Y: size + 1 - x}
+	}
+	return td
+}
+
+// Inverse returns the symmetry reverting s
+func (s Symmetry) Inverse() Symmetry {
+	switch s {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	}
+	return s
+}
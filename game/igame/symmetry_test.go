@@ -0,0 +1,45 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package igame_test
+
+import (
+	"testing"
+
+	. "github.com/yagoggame/gomaster/game/igame"
+)
+
+func TestSymmetryApply(t *testing.T) {
+	td := TurnData{X: 2, Y: 1}
+	tests := map[Symmetry]TurnData{
+		Identity:         {X: 2, Y: 1},
+		Rotate90:         {X: 9, Y: 2},
+		Rotate180:        {X: 8, Y: 9},
+		Rotate270:        {X: 1, Y: 8},
+		FlipHorizontal:   {X: 8, Y: 1},
+		FlipVertical:     {X: 2, Y: 9},
+		FlipDiagonal:     {X: 1, Y: 2},
+		FlipAntiDiagonal: {X: 9, Y: 8},
+	}
+	for s, want := range tests {
+		if got := s.Apply(td, 9); got != want {
+			t.Errorf("Unexpected Apply() of %v:\nwant: %v,\ngot: %v.", s, want, got)
+		}
+		if got := s.Inverse().Apply(want, 9); got != td {
+			t.Errorf("Unexpected Inverse() of %v:\nwant: %v,\ngot: %v.", s, td, got)
+		}
+	}
+}
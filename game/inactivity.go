@@ -0,0 +1,68 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"time"
+)
+
+// inactive reports to the game that gamers could stop issuing commands
+func (g *Game) inactive() error {
+	return errorOf(g.request(context.Background(), &gameCommand{act: inactiveCMD}))
+}
+
+// internal is a set of actions reported by the game itself,
+// they are not activity of gamers
+var internal = map[gameAction]bool{
+	timeoutCMD:      true,
+	vacationOverCMD: true,
+	graceOverCMD:    true,
+	inactiveCMD:     true,
+}
+
+// noteActivity notes the time of cmd issued by a gamer of the game
+func noteActivity(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	if gd.cfg.inactivity == 0 || internal[cmd.act] {
+		return
+	}
+	if _, ok := gamerStates[cmd.id]; ok == true || cmd.act == joinCMD {
+		gd.activity = gd.cfg.timeSource().Now()
+	}
+}
+
+// awaitInactivity starts the timer reporting inactivity of gamers after d
+func awaitInactivity(g *Game, gd *gmaeDescriptor, d time.Duration) {
+	gd.inactivityTimer = gd.cfg.timeSource().AfterFunc(d, func() {
+		g.inactive()
+	})
+}
+
+// inactive implements concurrently safe processing of
+// running out of the inactivity timeout.
+// It returns true if gamers issued no commands for the timeout, so the game is abandoned.
+func inactive(g *Game, cmd *gameCommand, gd *gmaeDescriptor) bool {
+	defer close(cmd.rez)
+
+	if left := gd.cfg.inactivity - gd.cfg.since(gd.activity); left > 0 {
+		awaitInactivity(g, gd, left)
+		return false
+	}
+	gd.abandoned = true
+	publish(gd.subscribers, GameEvent{Kind: AbandonedEvent})
+	return true
+}
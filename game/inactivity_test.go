@@ -0,0 +1,67 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestInactivityTimeout checks that the game is destroyed
+// when gamers issue no commands for the timeout.
+func TestInactivityTimeout(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, black, white := pieGame(t, WithInactivityTimeout(time.Minute), WithTimeSource(ft))
+	defer game.End()
+
+	events, cancel := game.Subscribe(black)
+	defer cancel()
+	rez := make(chan *WaitResult, 1)
+	go func() {
+		result, _ := game.WaitTurnResult(context.Background(), black)
+		rez <- result
+	}()
+	time.Sleep(rtDurationThreshold / 10)
+
+	ft.advance(40 * time.Second)
+	if _, err := game.IsMyTurn(white); err != nil {
+		t.Fatalf("Unexpected IsMyTurn err: %v", err)
+	}
+	ft.advance(40 * time.Second)
+	// Snapshot is not a command of a gamer, it doesn't prolong the game.
+	if _, err := game.Snapshot(); err != nil {
+		t.Fatalf("Unexpected Snapshot err of the active game: %v", err)
+	}
+	ft.advance(20 * time.Second)
+	if _, err := game.Snapshot(); !errors.Is(err, ErrGameDestroyed) {
+		t.Errorf("Unexpected Snapshot err of the abandoned game:\nwant: %v,\ngot: %v", ErrGameDestroyed, err)
+	}
+
+	select {
+	case result := <-rez:
+		if result == nil || result.Reason != AbandonedReason || !errors.Is(result.Err(), ErrAbandoned) {
+			t.Errorf("Unexpected WaitTurnResult:\nwant: %v,\ngot: %+v", AbandonedReason, result)
+		}
+	case <-time.After(rtDurationThreshold):
+		t.Fatalf("Unexpected timeout of WaitTurnResult")
+	}
+	if ev := nextEvent(t, events); ev.Kind != AbandonedEvent {
+		t.Errorf("Unexpected event:\nwant: %v,\ngot: %+v", AbandonedEvent, ev)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Logger receives structured records of the game.
+// keyvals are alternating keys and values like arguments of slog.Logger.Info,
+// so a slog.Logger can be passed as is.
+type Logger interface {
+	Info(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// eventNames are names of kinds of events, indexed by kind
+var eventNames = []string{"joined", "begun", "move made", "pass", "resign", "left", "game over", "chat",
+	"disconnected", "rejoined", "paused", "resumed", "store failed", "abandoned",
+	"settings proposed", "settings changed", "review", "score rejected", "force ended", "resync",
+	"spectator joined", "spectator left"}
+
+// String provides compatibility with Stringer interface.
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventNames) {
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+	return eventNames[k]
+}
+
+// logEvents logs events of the game until the game is destroyed
+func logEvents(cfg *config, events <-chan GameEvent) {
+	for ev := range events {
+		keyvals := []interface{}{"game", cfg.gameID}
+		if ev.ID != 0 {
+			keyvals = append(keyvals, "gamer", ev.ID, "colour", ev.Colour)
+		}
+		switch {
+		case ev.Move != nil:
+			keyvals = append(keyvals, "x", ev.Move.X, "y", ev.Move.Y)
+		case ev.Result != nil:
+			keyvals = append(keyvals, "winner", ev.Result.Winner, "method", ev.Result.Method)
+		case ev.Err != nil:
+			cfg.logger.Error(ev.Kind.String(), append(keyvals, "err", ev.Err)...)
+			continue
+		}
+		cfg.logger.Info(ev.Kind.String(), keyvals...)
+	}
+}
+
+// logError logs err replied by the game
+func logError(cfg *config, err error) {
+	var opErr *OperationError
+	if errors.As(err, &opErr) {
+		cfg.logger.Error("operation failed", "game", cfg.gameID, "op", opErr.Op, "gamer", opErr.GamerID, "err", opErr.Err)
+		return
+	}
+	cfg.logger.Error("operation failed", "game", cfg.gameID, "err", err)
+}
@@ -0,0 +1,106 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// logRecord is a record received by chanLogger
+type logRecord struct {
+	level   string
+	msg     string
+	keyvals []interface{}
+}
+
+// chanLogger is a Logger passing records to the channel
+type chanLogger chan logRecord
+
+func (l chanLogger) Info(msg string, keyvals ...interface{}) {
+	l <- logRecord{level: "info", msg: msg, keyvals: keyvals}
+}
+
+func (l chanLogger) Error(msg string, keyvals ...interface{}) {
+	l <- logRecord{level: "error", msg: msg, keyvals: keyvals}
+}
+
+// value returns the value of key of the record, nil if there is no key
+func (r logRecord) value(key string) interface{} {
+	for i := 0; i+1 < len(r.keyvals); i += 2 {
+		if r.keyvals[i] == key {
+			return r.keyvals[i+1]
+		}
+	}
+	return nil
+}
+
+// TestLogger checks records of events and errors of the game.
+func TestLogger(t *testing.T) {
+	logger := make(chanLogger, 100)
+	game, black, white := pieGame(t, WithLogger(logger, "g1"))
+	defer game.End()
+
+	if err := game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); !errors.Is(err, ErrNotYourTurn) {
+		t.Fatalf("Unexpected MakeTurn err:\nwant: %v,\ngot: %v", ErrNotYourTurn, err)
+	}
+	if err := game.Leave(white); err != nil {
+		t.Fatalf("Unexpected Leave err: %v", err)
+	}
+
+	wants := []logRecord{
+		{level: "info", msg: "joined", keyvals: []interface{}{"gamer", black}},
+		{level: "info", msg: "joined", keyvals: []interface{}{"gamer", white}},
+		{level: "info", msg: "begun"},
+		{level: "info", msg: "move made", keyvals: []interface{}{"gamer", black, "x", 5, "y", 5}},
+		{level: "error", msg: "operation failed", keyvals: []interface{}{"gamer", black, "op", "makeTurn"}},
+		{level: "info", msg: "left", keyvals: []interface{}{"gamer", white}},
+	}
+	// events are logged asynchronously, so errors can be logged before them.
+	got := make([]logRecord, 0, len(wants))
+	for len(got) < len(wants) {
+		select {
+		case rec := <-logger:
+			got = append(got, rec)
+		case <-time.After(rtDurationThreshold):
+			t.Fatalf("Unexpected timeout of awaiting a record, got: %v", got)
+		}
+	}
+	for _, want := range wants {
+		found := false
+		for _, rec := range got {
+			if rec.level != want.level || rec.msg != want.msg || rec.value("game") != "g1" {
+				continue
+			}
+			found = true
+			for i := 0; i+1 < len(want.keyvals); i += 2 {
+				if rec.value(want.keyvals[i].(string)) != want.keyvals[i+1] {
+					found = false
+				}
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Unexpected records:\nwant: %v,\ngot: %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// nigiriMax is the maximal number of stones grabbed on nigiri
+const nigiriMax = 30
+
+// Nigiri describes the traditional determination of colours:
+// the holder grabs a handful of stones and the guesser guesses their parity.
+// The guesser plays black if the guess is correct, else white.
+type Nigiri struct {
+	HolderID  int  // id of the gamer who grabbed stones
+	GuesserID int  // id of the gamer who guessed the parity
+	Stones    int  // number of grabbed stones
+	GuessOdd  bool // the guesser guessed the odd number of stones
+}
+
+// Correct checks that the guesser guessed the parity right
+func (n *Nigiri) Correct() bool {
+	return (n.Stones%2 == 1) == n.GuessOdd
+}
+
+// copy returns a copy of the nigiri, nil stays nil
+func (n *Nigiri) copy() *Nigiri {
+	if n == nil {
+		return nil
+	}
+	nCpy := *n
+	return &nCpy
+}
+
+// nigiri assigns colours to the gamers joined the game,
+// the gamer joined last is the guesser
+func nigiri(gamerStates map[int]*GamerState, guesserID int, gd *gmaeDescriptor) {
+	n := &Nigiri{
+		GuesserID: guesserID,
+		Stones:    gd.cfg.intn(nigiriMax) + 1,
+		GuessOdd:  gd.cfg.intn(2) == 1,
+	}
+
+	for id, gs := range gamerStates {
+		guesser := id == guesserID
+		if !guesser {
+			n.HolderID = id
+		}
+		gs.Colour = igame.White
+		if guesser == n.Correct() {
+			gs.Colour = igame.Black
+		}
+		gd.players[gs.Colour] = gs.Name
+	}
+	gd.nigiri = n
+}
@@ -0,0 +1,68 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestNigiri checks that colours are assigned by the outcome of the nigiri.
+func TestNigiri(t *testing.T) {
+	game, err := NewGame(usualSize, usualKomi, WithNigiri())
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+	gamers := copyGamers(validGamers)
+	joinGamers(&commonArgs{t: t, game: game, gamers: gamers})
+
+	ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold)
+	defer cancel()
+
+	ch := make(chan *WaitResult)
+	go func() {
+		rez, err := game.WaitBeginResult(ctx, gamers[0].ID)
+		if err != nil {
+			t.Errorf("Unexpected WaitBeginResult err: %v", err)
+		}
+		ch <- rez
+	}()
+	rez, err := game.WaitBeginResult(ctx, gamers[1].ID)
+	if err != nil {
+		t.Fatalf("Unexpected WaitBeginResult err: %v", err)
+	}
+	<-ch
+
+	n := rez.Nigiri
+	if n == nil || n.GuesserID != gamers[1].ID || n.HolderID != gamers[0].ID || n.Stones < 1 {
+		t.Fatalf("Unexpected nigiri: %+v", n)
+	}
+	gs, err := game.GamerState(n.GuesserID)
+	if err != nil {
+		t.Fatalf("Unexpected GamerState err: %v", err)
+	}
+	want := igame.ChipColour(igame.White)
+	if n.Correct() {
+		want = igame.Black
+	}
+	if gs.Colour != want {
+		t.Errorf("Unexpected colour of the guesser:\nwant: %v,\ngot: %v for %+v", want, gs.Colour, n)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// config holds settings of the Game collected from options
+type config struct {
+	komi           float64
+	fieldOptions   []field.Option
+	pieRule        bool
+	book           igame.OpeningBook
+	firstColour    igame.ChipColour // colour of the first joined gamer, random if NoColour
+	muteSpectators bool
+	listSpectators bool          // ids and names of spectators are shown by Status and events
+	mainTime       time.Duration // time budget of each gamer, 0 if the game has no time control
+	increment      time.Duration // time added to the gamer's budget after each move
+	perMove        time.Duration // time of each move not taken from the gamer's budget
+	vacation       time.Duration // time each gamer can pause the clock for
+	deadline       time.Duration // maximal time of a move, 0 if moves are not limited
+	autoPass       bool          // the move is passed on the deadline instead of forfeit
+	autoPasses     int           // number of missed deadlines of each gamer passed before forfeit if autoPass is false
+	grace          time.Duration // time a disconnected gamer can rejoin within, 0 if Disconnect isn't allowed
+	nigiri         bool          // colours are determined by nigiri
+	handicap       int           // number of handicap chips of black, 0 if the game has no handicap
+	injected       bool          // the game is played on the master passed to NewGameWithMaster
+	random         *rand.Rand    // source of random colours and nigiri, the global source if nil
+	time           TimeSource    // source of time of time controls, the time package if nil
+	store          GameStore     // store the game is written through, nil if the game isn't persisted
+	inactivity     time.Duration // time without commands of gamers the game is destroyed after, 0 if it lives until End
+	logger         Logger        // logger of events and errors of the game, nil if the game isn't logged
+	gameID         string        // id of the game the records of logger are tagged with
+	rengo          bool          // teams of two gamers play by each colour
+	teaching       bool          // the only gamer moves for both colours
+	blind          bool          // chips of the opponent are hidden from gamers
+	blindAll       bool          // all chips are hidden from gamers, if blind is true
+	referee        igame.Referee // referee of disputes on dead chips, nil if there is no referee
+	rejections     int           // number of rejected scores the referee adjudicates after
+	analyzer       MoveAnalyzer  // analyzer of timings of moves, nil if moves aren't analyzed
+	admin          *Admin        // handle of the administrator bound to the game, nil if there is none
+}
+
+// Option configures the Game on creation
+type Option func(cfg *config)
+
+// WithFieldOptions passes opts to the field of the Game
+func WithFieldOptions(opts ...field.Option) Option {
+	return func(cfg *config) {
+		cfg.fieldOptions = append(cfg.fieldOptions, opts...)
+	}
+}
+
+// WithPieRule allows the white gamer to take black's first move by Swap
+// or to set komi by SetKomi instead
+func WithPieRule() Option {
+	return func(cfg *config) {
+		cfg.pieRule = true
+	}
+}
+
+// WithMutedSpectators forbids spectators to Say in the game chat
+func WithMutedSpectators() Option {
+	return func(cfg *config) {
+		cfg.muteSpectators = true
+	}
+}
+
+// WithSpectatorList shows ids and names of spectators by Status
+// and ids of them by SpectatorJoinedEvent and SpectatorLeftEvent,
+// otherwise only the number of spectators is shown
+func WithSpectatorList() Option {
+	return func(cfg *config) {
+		cfg.listSpectators = true
+	}
+}
+
+// WithAbsoluteTime sets sudden death time control: each gamer has mainTime
+// for all moves, the gamer who runs out of time loses
+func WithAbsoluteTime(mainTime time.Duration) Option {
+	return func(cfg *config) {
+		cfg.mainTime = mainTime
+	}
+}
+
+// WithFischerTime sets Fischer time control: each gamer has mainTime
+// and increment is added to it after each move of the gamer,
+// the gamer who runs out of time loses
+func WithFischerTime(mainTime, increment time.Duration) Option {
+	return func(cfg *config) {
+		cfg.mainTime = mainTime
+		cfg.increment = increment
+	}
+}
+
+// WithCorrespondenceTime sets correspondence time control: each move has perMove limit,
+// the time over the limit is taken from the time bank of the gamer.
+// Each gamer can pause the clock by StartVacation for vacation time in total.
+// The gamer who runs out of time loses
+func WithCorrespondenceTime(perMove, bank, vacation time.Duration) Option {
+	return func(cfg *config) {
+		cfg.perMove = perMove
+		cfg.mainTime = bank
+		cfg.vacation = vacation
+	}
+}
+
+// WithMoveDeadline limits the time of each move by deadline.
+// The gamer who misses the deadline loses by forfeit,
+// or passes if autoPass is true
+func WithMoveDeadline(deadline time.Duration, autoPass bool) Option {
+	return func(cfg *config) {
+		cfg.deadline = deadline
+		cfg.autoPass = autoPass
+	}
+}
+
+// WithAutoPassLimit passes the move of the gamer who misses the deadline
+// set by WithMoveDeadline for the first n times,
+// the gamer loses by forfeit on the next missed deadline
+func WithAutoPassLimit(n int) Option {
+	return func(cfg *config) {
+		cfg.autoPasses = n
+	}
+}
+
+// WithReconnectGrace allows gamers to Disconnect from the game
+// and Rejoin it within grace, the clock of a disconnected gamer is paused.
+// The gamer who doesn't rejoin in time leaves the game
+func WithReconnectGrace(grace time.Duration) Option {
+	return func(cfg *config) {
+		cfg.grace = grace
+	}
+}
+
+// WithNigiri determines colours by nigiri when the second gamer joins the game,
+// the outcome is reported by WaitBeginResult and BegunEvent
+func WithNigiri() Option {
+	return func(cfg *config) {
+		cfg.nigiri = true
+	}
+}
+
+// WithHandicap gives black handicap chips put on star points.
+// Komi of the game with handicap is 0.5, white moves first if there are handicap chips.
+// Handicap 1 means no chips and black moves first with the reduced komi
+func WithHandicap(handicap int) Option {
+	return func(cfg *config) {
+		cfg.handicap = handicap
+	}
+}
+
+// WithRand sets the source of random numbers used to assign colours and for nigiri.
+// The game uses r exclusively, rematches use sources seeded from r
+func WithRand(r *rand.Rand) Option {
+	return func(cfg *config) {
+		cfg.random = r
+	}
+}
+
+// WithTimeSource sets the source of time used by time controls and the grace period
+func WithTimeSource(ts TimeSource) Option {
+	return func(cfg *config) {
+		cfg.time = ts
+	}
+}
+
+// WithStore sets the store the game is written through as it's played.
+// Rematches of the game are not written to store
+func WithStore(store GameStore) Option {
+	return func(cfg *config) {
+		cfg.store = store
+	}
+}
+
+// WithInactivityTimeout destroys the game if its gamers issue no commands for timeout.
+// Awaiting gamers are informed by AbandonedReason, subscribers by AbandonedEvent
+func WithInactivityTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.inactivity = timeout
+	}
+}
+
+// WithLogger logs joins, moves, leaves and other events of the game
+// and errors replied to gamers by logger tagged with gameID.
+// Rematches of the game are logged with the same gameID
+func WithLogger(logger Logger, gameID string) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+		cfg.gameID = gameID
+	}
+}
+
+// WithRengo makes the game for four gamers, two gamers play by each colour
+// and team mates move in turn. The game begins when all four gamers join.
+// Nigiri and rematch are not available in rengo
+func WithRengo() Option {
+	return func(cfg *config) {
+		cfg.rengo = true
+	}
+}
+
+// WithTeaching makes the game for the only gamer moving for both colours,
+// for teaching demos and local analysis. The game begins when the gamer joins
+// and IsMyTurn of the gamer is always true. Rengo, rematch and negotiation
+// of settings are not available in the teaching mode
+func WithTeaching() Option {
+	return func(cfg *config) {
+		cfg.teaching = true
+	}
+}
+
+// WithBlindGo hides chips of the opponent from each gamer, or all chips if all is true,
+// until the game is over. Positions of hidden moves are hidden from states, events and history,
+// the record of the game and the estimate of the score aren't available to gamers.
+// Spectators see the whole game. Rules check moves on the whole field
+func WithBlindGo(all bool) Option {
+	return func(cfg *config) {
+		cfg.blind, cfg.blindAll = true, all
+		cfg.fieldOptions = append(cfg.fieldOptions, field.WithBlind(all))
+	}
+}
+
+// WithReferee makes referee adjudicate dead chips in counting phase
+// when the score is rejected by RejectScore for rejections times
+func WithReferee(referee igame.Referee, rejections int) Option {
+	return func(cfg *config) {
+		cfg.referee, cfg.rejections = referee, rejections
+	}
+}
+
+// WithAdmin binds admin to the game, so the game can be moderated by methods of admin.
+// The Admin isn't reachable from the Game, so gamers holding the Game can't moderate it
+func WithAdmin(admin *Admin) Option {
+	return func(cfg *config) {
+		cfg.admin = admin
+	}
+}
+
+// WithMoveAnalyzer feeds analyzer with timings of moves of the game.
+// The analyzer runs outside of the goroutine of the game, so a slow analyzer doesn't delay it
+func WithMoveAnalyzer(analyzer MoveAnalyzer) Option {
+	return func(cfg *config) {
+		cfg.analyzer = analyzer
+	}
+}
+
+// WithOpeningBook sets the book of moves provided by BookMoves
+func WithOpeningBook(book igame.OpeningBook) Option {
+	return func(cfg *config) {
+		cfg.book = book
+	}
+}
+
+// intn returns a random number in [0,n) from the source of the game
+func (cfg *config) intn(n int) int {
+	if cfg.random == nil {
+		return rand.Intn(n)
+	}
+	return cfg.random.Intn(n)
+}
@@ -0,0 +1,115 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestPause checks that the game is paused and resumed by agreement of gamers.
+func TestPause(t *testing.T) {
+	game, black, white := pieGame(t, WithAbsoluteTime(time.Minute))
+	defer game.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), fastDurationThreshold)
+	defer cancel()
+
+	if err := game.Resume(white); !errors.Is(err, ErrNotPaused) {
+		t.Errorf("Unexpected Resume err of the game in progress:\nwant: %v,\ngot: %v", ErrNotPaused, err)
+	}
+	if err := game.Pause(white); err != nil {
+		t.Fatalf("Unexpected Pause err: %v", err)
+	}
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err before the agreement: %v", err)
+	}
+
+	ch := make(chan error)
+	go func() {
+		ch <- game.WaitTurn(ctx, white)
+	}()
+	time.Sleep(rtDurationThreshold / 10)
+
+	if err := game.Pause(black); err != nil {
+		t.Fatalf("Unexpected Pause err: %v", err)
+	}
+	if err := <-ch; !errors.Is(err, ErrPaused) {
+		t.Errorf("Unexpected WaitTurn err on pause:\nwant: %v,\ngot: %v", ErrPaused, err)
+	}
+
+	if err := game.MakeTurn(black, &igame.TurnData{X: 7, Y: 7}); !errors.Is(err, ErrPaused) {
+		t.Errorf("Unexpected MakeTurn err of paused game:\nwant: %v,\ngot: %v", ErrPaused, err)
+	}
+	clocks, err := game.Clocks(black)
+	if err != nil {
+		t.Fatalf("Unexpected Clocks err: %v", err)
+	}
+	if clocks[igame.Black].Running == true {
+		t.Errorf("Unexpected running clock of paused game: %+v", clocks[igame.Black])
+	}
+
+	if err := game.Resume(white); err != nil {
+		t.Fatalf("Unexpected Resume err: %v", err)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 7, Y: 7}); !errors.Is(err, ErrPaused) {
+		t.Errorf("Unexpected MakeTurn err before the agreement:\nwant: %v,\ngot: %v", ErrPaused, err)
+	}
+
+	go func() {
+		ch <- game.WaitTurn(ctx, black)
+	}()
+	time.Sleep(rtDurationThreshold / 10)
+
+	if err := game.Resume(black); err != nil {
+		t.Fatalf("Unexpected Resume err: %v", err)
+	}
+	if err := <-ch; err != nil {
+		t.Errorf("Unexpected WaitTurn err on resumption: %v", err)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 7, Y: 7}); err != nil {
+		t.Errorf("Unexpected MakeTurn err after resumption: %v", err)
+	}
+}
+
+// TestPauseZeroID checks the agreement on the pause requested by the gamer with id 0.
+func TestPauseZeroID(t *testing.T) {
+	game, zero, other := zeroGame(t)
+	defer game.End()
+
+	for _, id := range []int{zero, other} {
+		if err := game.Pause(id); err != nil {
+			t.Fatalf("Unexpected Pause err of %d: %v", id, err)
+		}
+	}
+	if err := game.MakeTurn(zero, &igame.TurnData{X: 3, Y: 3}); !errors.Is(err, ErrPaused) {
+		t.Fatalf("Unexpected MakeTurn err of paused game:\nwant: %v,\ngot: %v", ErrPaused, err)
+	}
+
+	for _, id := range []int{zero, other} {
+		if err := game.Resume(id); err != nil {
+			t.Fatalf("Unexpected Resume err of %d: %v", id, err)
+		}
+	}
+	if err := game.MakeTurn(zero, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Errorf("Unexpected MakeTurn err of resumed game: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package puzzle provides tsumego: positions with a goal solved
+// by one gamer playing against scripted or engine responses.
+package puzzle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// ErrSolved error occurs when Play is called on solved or failed puzzle
+var ErrSolved = errors.New("the puzzle is finished")
+
+// Status provides datatype of states of the puzzle
+type Status int
+
+// Set of states of the puzzle
+const (
+	Unsolved Status = iota // the goal is not reached yet
+	Solved                 // the goal is reached
+	Failed                 // the goal can't be reached anymore
+)
+
+// Goal checks the position of the puzzle.
+// final is true when the sequence of moves is over:
+// the responder passed or no moves left.
+type Goal interface {
+	Check(f *field.Field, final bool) Status
+}
+
+// Responder replies on moves of the solver
+type Responder interface {
+	// Respond returns the reply on the last move of the solver made on f, nil to pass
+	Respond(f *field.Field, move igame.TurnData) *igame.TurnData
+}
+
+// ResponderFunc is an adapter to use ordinary functions as Responder
+type ResponderFunc func(f *field.Field, move igame.TurnData) *igame.TurnData
+
+// Respond calls fn(f, move)
+func (fn ResponderFunc) Respond(f *field.Field, move igame.TurnData) *igame.TurnData {
+	return fn(f, move)
+}
+
+// Puzzle holds the position and the progress of solving
+type Puzzle struct {
+	field     *field.Field
+	toPlay    igame.ChipColour
+	goal      Goal
+	responder Responder
+	movesLeft int
+	status    Status
+}
+
+// New creates the Puzzle on a copy of position f, where the gamer playing by toPlay
+// has to reach goal in at most maxMoves moves against responder
+func New(f *field.Field, toPlay igame.ChipColour, goal Goal, responder Responder, maxMoves int) *Puzzle {
+	return &Puzzle{
+		field:     f.Clone(),
+		toPlay:    toPlay,
+		goal:      goal,
+		responder: responder,
+		movesLeft: maxMoves,
+	}
+}
+
+// Field returns a copy of the current position of the puzzle
+func (p *Puzzle) Field() *field.Field {
+	return p.field.Clone()
+}
+
+// Status returns the current state of the puzzle
+func (p *Puzzle) Status() Status {
+	return p.status
+}
+
+// Play makes the move of the solver to position td, then the reply of the responder,
+// and returns the state of the puzzle after them
+func (p *Puzzle) Play(td igame.TurnData) (Status, error) {
+	if p.status != Unsolved {
+		return p.status, ErrSolved
+	}
+	if err := p.field.Move(p.toPlay, &td); err != nil {
+		return p.status, fmt.Errorf("failed to play puzzle: %w", err)
+	}
+	p.movesLeft--
+
+	if p.status = p.goal.Check(p.field, false); p.status != Unsolved {
+		return p.status, nil
+	}
+
+	reply := p.responder.Respond(p.field.Clone(), td)
+	opponent := igame.ChipColour(3 - int(p.toPlay))
+	if reply == nil {
+		if err := p.field.Pass(opponent); err != nil {
+			return p.status, fmt.Errorf("failed to pass for responder: %w", err)
+		}
+	} else if err := p.field.Move(opponent, reply); err != nil {
+		return p.status, fmt.Errorf("failed to play reply %v: %w", *reply, err)
+	}
+
+	p.status = p.goal.Check(p.field, reply == nil || p.movesLeft <= 0)
+	return p.status, nil
+}
+
+// colourAt returns the colour of the chip at position td of f
+func colourAt(f *field.Field, td igame.TurnData) igame.ChipColour {
+	for colour, positions := range f.State().ChipsOnBoard {
+		for _, pos := range positions {
+			if *pos == td {
+				return colour
+			}
+		}
+	}
+	return igame.NoColour
+}
+
+// Capture is a goal of capturing the chain of colour containing Target
+type Capture struct {
+	Target igame.TurnData
+	Colour igame.ChipColour
+}
+
+// Check provides compatibility with Goal interface
+func (g Capture) Check(f *field.Field, final bool) Status {
+	switch {
+	case colourAt(f, g.Target) != g.Colour:
+		return Solved
+	case final:
+		return Failed
+	}
+	return Unsolved
+}
+
+// Live is a goal of keeping alive the chain of colour containing Target
+// till the end of the sequence
+type Live struct {
+	Target igame.TurnData
+	Colour igame.ChipColour
+}
+
+// Check provides compatibility with Goal interface
+func (g Live) Check(f *field.Field, final bool) Status {
+	switch {
+	case colourAt(f, g.Target) != g.Colour:
+		return Failed
+	case final:
+		return Solved
+	}
+	return Unsolved
+}
+
+// Line is a branch of the script: the reply on Move and lines following it
+type Line struct {
+	Move  igame.TurnData
+	Reply *igame.TurnData // nil to pass
+	Next  []Line
+}
+
+// Script is a Responder replying by the tree of lines.
+// Moves out of the script are replied by pass.
+type Script struct {
+	lines []Line
+}
+
+// NewScript creates the Script with lines starting from the first move of the solver
+func NewScript(lines []Line) *Script {
+	return &Script{lines: lines}
+}
+
+// Respond provides compatibility with Responder interface
+func (s *Script) Respond(f *field.Field, move igame.TurnData) *igame.TurnData {
+	for _, line := range s.lines {
+		if line.Move == move {
+			s.lines = line.Next
+			return line.Reply
+		}
+	}
+	s.lines = nil
+	return nil
+}
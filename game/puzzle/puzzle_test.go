@@ -0,0 +1,138 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package puzzle_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+	. "github.com/yagoggame/gomaster/game/puzzle"
+)
+
+// atari is a position with white chip at B4 in atari
+const atari = `
+. X . . .
+X O . . .
+. X . . .
+. . . . .
+. . . . .
+`
+
+var (
+	target   = igame.TurnData{X: 2, Y: 2}
+	escape   = igame.TurnData{X: 3, Y: 2}
+	sideMove = igame.TurnData{X: 5, Y: 5}
+)
+
+func newPuzzle(t *testing.T, toPlay igame.ChipColour, goal Goal, lines []Line, maxMoves int) *Puzzle {
+	f, err := field.ParseDiagram(atari, 0)
+	if err != nil {
+		t.Fatalf("Unexpected ParseDiagram() err: %v", err)
+	}
+	return New(f, toPlay, goal, NewScript(lines), maxMoves)
+}
+
+var puzzleTests = []struct {
+	name   string
+	toPlay igame.ChipColour
+	goal   Goal
+	lines  []Line
+	moves  []igame.TurnData
+	want   []Status
+}{
+	{
+		name:   "capture",
+		toPlay: igame.Black,
+		goal:   Capture{Target: target, Colour: igame.White},
+		moves:  []igame.TurnData{escape},
+		want:   []Status{Solved},
+	},
+	{
+		name:   "capture missed",
+		toPlay: igame.Black,
+		goal:   Capture{Target: target, Colour: igame.White},
+		lines:  []Line{{Move: sideMove, Reply: &escape}},
+		moves:  []igame.TurnData{sideMove, {X: 5, Y: 4}},
+		want:   []Status{Unsolved, Failed},
+	},
+	{
+		name:   "live",
+		toPlay: igame.White,
+		goal:   Live{Target: target, Colour: igame.White},
+		lines:  []Line{{Move: escape, Reply: &igame.TurnData{X: 4, Y: 2}, Next: []Line{{Move: igame.TurnData{X: 3, Y: 3}}}}},
+		moves:  []igame.TurnData{escape, {X: 3, Y: 3}},
+		want:   []Status{Unsolved, Solved},
+	},
+	{
+		name:   "dead",
+		toPlay: igame.White,
+		goal:   Live{Target: target, Colour: igame.White},
+		lines:  []Line{{Move: sideMove, Reply: &escape}},
+		moves:  []igame.TurnData{sideMove},
+		want:   []Status{Failed},
+	},
+}
+
+func TestPlay(t *testing.T) {
+	for _, test := range puzzleTests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newPuzzle(t, test.toPlay, test.goal, test.lines, 5)
+			for i, move := range test.moves {
+				status, err := p.Play(move)
+				if err != nil {
+					t.Fatalf("Unexpected Play() err: %v", err)
+				}
+				if status != test.want[i] || p.Status() != status {
+					t.Errorf("Unexpected status after move %d:\nwant: %v,\ngot: %v.", i+1, test.want[i], status)
+				}
+			}
+		})
+	}
+}
+
+func TestPlayFinished(t *testing.T) {
+	p := newPuzzle(t, igame.Black, Capture{Target: target, Colour: igame.White}, nil, 5)
+	if _, err := p.Play(escape); err != nil {
+		t.Fatalf("Unexpected Play() err: %v", err)
+	}
+	if _, err := p.Play(sideMove); !errors.Is(err, ErrSolved) {
+		t.Errorf("Unexpected Play() err on solved puzzle:\nwant: %v,\ngot: %v.", ErrSolved, err)
+	}
+}
+
+func TestPlayIllegal(t *testing.T) {
+	p := newPuzzle(t, igame.Black, Capture{Target: target, Colour: igame.White}, nil, 5)
+	if _, err := p.Play(target); !errors.Is(err, field.ErrOccupied) {
+		t.Errorf("Unexpected Play() err on occupied position:\nwant: %v,\ngot: %v.", field.ErrOccupied, err)
+	}
+}
+
+func TestMovesLimit(t *testing.T) {
+	stay := ResponderFunc(func(f *field.Field, move igame.TurnData) *igame.TurnData {
+		return &igame.TurnData{X: 5, Y: 1}
+	})
+	f, err := field.ParseDiagram(atari, 0)
+	if err != nil {
+		t.Fatalf("Unexpected ParseDiagram() err: %v", err)
+	}
+	p := New(f, igame.White, Live{Target: target, Colour: igame.White}, stay, 1)
+	if status, err := p.Play(escape); err != nil || status != Solved {
+		t.Errorf("Unexpected Play() result on the last move:\nwant: %v,\ngot: %v, %v.", Solved, status, err)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "context"
+
+// graceOver reports to the game that the gamer with id could run out of reconnect grace period
+func (g *Game) graceOver(id int) error {
+	return errorOf(g.request(context.Background(), &gameCommand{act: graceOverCMD, id: id}))
+}
+
+// disconnect implements concurrently safe processing of querry of
+// Disconnect function
+func disconnect(g *Game, gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "disconnect", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	if gd.cfg.grace <= 0 {
+		cmd.rez <- opError("disconnect", cmd.id, ErrNoGrace)
+		return
+	}
+	if gs.Disconnected {
+		return
+	}
+
+	gs.Disconnected = true
+	gs.disconnected = gd.cfg.timeSource().Now()
+	gs.graceTimer = gd.cfg.timeSource().AfterFunc(gd.cfg.grace, func() {
+		g.graceOver(cmd.id)
+	})
+	publish(gd.subscribers, GameEvent{Kind: DisconnectedEvent, ID: cmd.id, Colour: gs.Colour})
+}
+
+// reconnect cancels the grace period of the disconnected gamer
+func reconnect(gs *GamerState) {
+	if gs.Disconnected == false {
+		return
+	}
+	gs.graceTimer.Stop()
+	gs.Disconnected = false
+}
+
+// rejoin implements concurrently safe processing of querry of
+// Rejoin function
+func rejoin(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	gs, err := getGamerStateAndChecks(gamerStates, "rejoin", cmd.id, gd.gameOver)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	if gs.Disconnected == false {
+		cmd.rez <- opError("rejoin", cmd.id, ErrNotDisconnected)
+		return
+	}
+
+	reconnect(gs)
+	publish(gd.subscribers, GameEvent{Kind: RejoinedEvent, ID: cmd.id, Colour: gs.Colour})
+}
+
+// graceOver implements concurrently safe processing of
+// running out of reconnect grace period by the gamer with id.
+// The gamer leaves the game, so it returns true like leaveGame.
+func graceOver(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) bool {
+	gs, ok := gamerStates[cmd.id]
+	if ok == false || gs.Disconnected == false || gd.cfg.since(gs.disconnected) < gd.cfg.grace {
+		// the gamer rejoined or left the game.
+		close(cmd.rez)
+		return false
+	}
+
+	reconnect(gs)
+	return leaveGame(gamerStates, cmd, gd)
+}
@@ -0,0 +1,88 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestDisconnectNoGrace checks that Disconnect fails in the game without grace period.
+func TestDisconnectNoGrace(t *testing.T) {
+	game, _, white := pieGame(t)
+	defer game.End()
+
+	if err := game.Disconnect(white); !errors.Is(err, ErrNoGrace) {
+		t.Errorf("Unexpected Disconnect err:\nwant: %v,\ngot: %v", ErrNoGrace, err)
+	}
+	if err := game.Rejoin(white); !errors.Is(err, ErrNotDisconnected) {
+		t.Errorf("Unexpected Rejoin err:\nwant: %v,\ngot: %v", ErrNotDisconnected, err)
+	}
+}
+
+// TestRejoin checks that the clock of a disconnected gamer is paused
+// and the gamer can continue after rejoining.
+func TestRejoin(t *testing.T) {
+	game, _, white := pieGame(t, WithAbsoluteTime(time.Minute), WithReconnectGrace(rtDurationThreshold))
+	defer game.End()
+
+	if err := game.Disconnect(white); err != nil {
+		t.Fatalf("Unexpected Disconnect err: %v", err)
+	}
+	clocks, err := game.Clocks(white)
+	if err != nil {
+		t.Fatalf("Unexpected Clocks err: %v", err)
+	}
+	if clocks[igame.White].Running == true {
+		t.Errorf("Unexpected running clock of disconnected gamer: %+v", clocks[igame.White])
+	}
+
+	if err := game.Rejoin(white); err != nil {
+		t.Fatalf("Unexpected Rejoin err: %v", err)
+	}
+	time.Sleep(2 * rtDurationThreshold)
+
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Errorf("Unexpected MakeTurn err after rejoining: %v", err)
+	}
+}
+
+// TestGraceOver checks that the gamer who doesn't rejoin in time
+// loses the game by forfeit.
+func TestGraceOver(t *testing.T) {
+	game, black, white := pieGame(t, WithReconnectGrace(rtDurationThreshold))
+	defer game.End()
+
+	if err := game.Disconnect(white); err != nil {
+		t.Fatalf("Unexpected Disconnect err: %v", err)
+	}
+	time.Sleep(2 * rtDurationThreshold)
+
+	if err := game.Rejoin(white); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Rejoin err after grace period:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+	result, err := game.Result(black)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if result.Winner != igame.Black || result.Method != igame.ForfeitMethod {
+		t.Errorf("Unexpected Result after grace period:\nwant: win of black by forfeit,\ngot: %v", result)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"fmt"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// rejectScore implements concurrently safe processing of querry of
+// RejectScore function
+func rejectScore(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if err := checkCounting(gamerStates, "rejectScore", cmd, gd); err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	gd.accepted = nil
+	gd.rejections++
+	publish(gd.subscribers, GameEvent{Kind: ScoreRejectedEvent, ID: cmd.id, Colour: gamerStates[cmd.id].Colour})
+	if gd.cfg.referee == nil || gd.rejections < gd.cfg.rejections {
+		return
+	}
+
+	if err := adjudicate(gd); err != nil {
+		cmd.rez <- opError("rejectScore", cmd.id, err)
+		return
+	}
+	reportOnGameOver(gamerStates, gd)
+}
+
+// adjudicate finishes the game in counting phase with dead chips chosen by the referee
+func adjudicate(gd *gmaeDescriptor) error {
+	dead, err := gd.cfg.referee.DeadChips(gd.master.State())
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrReferee, err)
+	}
+	result, err := gd.master.(igame.Scorer).FinalScore(dead)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrReferee, err)
+	}
+	result.Method = igame.RefereeMethod
+	gd.dead = append([]igame.TurnData(nil), dead...)
+	gd.result = result
+	gd.counting = false
+	gd.gameOver = true
+	return nil
+}
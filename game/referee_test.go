@@ -0,0 +1,82 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// refereeGame creates the game in counting phase adjudicated by referee
+// after two rejections of the score, and returns ids of black and white gamers
+func refereeGame(t *testing.T, referee igame.Referee) (game *Game, black, white int) {
+	game, black, white = pieGame(t, WithReferee(referee, 2))
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	if err := game.Pass(black); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	return game, black, white
+}
+
+func TestReferee(t *testing.T) {
+	var states int
+	game, black, white := refereeGame(t, igame.RefereeFunc(func(state *igame.FieldState) ([]igame.TurnData, error) {
+		states++
+		return []igame.TurnData{{X: 5, Y: 5}}, nil
+	}))
+	defer game.End()
+
+	if err := game.RejectScore(white); err != nil {
+		t.Fatalf("Unexpected RejectScore err: %v", err)
+	}
+	if _, err := game.Result(black); !errors.Is(err, ErrGameNotOver) || states != 0 {
+		t.Errorf("Unexpected Result err after the first rejection:\nwant: %v,\ngot: %v, referee called %d times.", ErrGameNotOver, err, states)
+	}
+	if err := game.RejectScore(black); err != nil {
+		t.Fatalf("Unexpected RejectScore err: %v", err)
+	}
+
+	result, err := game.Result(black)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if result.Winner != igame.White || result.Method != igame.RefereeMethod || states != 1 {
+		t.Errorf("Unexpected Result:\nwant: win of white by the referee,\ngot: %v, referee called %d times.", result, states)
+	}
+}
+
+func TestRefereeFailure(t *testing.T) {
+	failure := errors.New("engine is down")
+	game, black, white := refereeGame(t, igame.RefereeFunc(func(state *igame.FieldState) ([]igame.TurnData, error) {
+		return nil, failure
+	}))
+	defer game.End()
+
+	if err := game.RejectScore(white); err != nil {
+		t.Fatalf("Unexpected RejectScore err: %v", err)
+	}
+	if err := game.RejectScore(black); !errors.Is(err, ErrReferee) {
+		t.Errorf("Unexpected RejectScore err of the failed referee:\nwant: %v,\ngot: %v.", ErrReferee, err)
+	}
+	if err := game.AcceptScore(black); err != nil {
+		t.Errorf("Unexpected AcceptScore err after the failure of the referee: %v", err)
+	}
+}
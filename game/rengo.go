@@ -0,0 +1,99 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// seats returns the number of gamers the game begins with
+func (cfg *config) seats() int {
+	if cfg.teaching {
+		return 1
+	}
+	if cfg.rengo {
+		return 4
+	}
+	return 2
+}
+
+// teamSize returns the number of gamers playing by each colour
+func (cfg *config) teamSize() int {
+	if cfg.rengo && !cfg.teaching {
+		return 2
+	}
+	return 1
+}
+
+// isGamersTurn checks that turn is the turn of the gamer with state gs.
+// Team mates of rengo move in turn, the gamer with Seat 0 moves first.
+// The gamer of the teaching mode moves on every turn
+func isGamersTurn(turn int, gs *GamerState, cfg *config) bool {
+	if cfg.teaching {
+		return true
+	}
+	return isMyTurnCalc(turn, gs.Colour) && (!cfg.rengo || gs.Seat == turn/2%2)
+}
+
+// takeSeat chooses the colour and the seat in the team for the joining gamer.
+// colour is the requested team, NoColour if any team fits, then the team
+// with less gamers is chosen or random one for the first gamer.
+// It returns false if there is no free seat in the requested team.
+func takeSeat(gamerStates map[int]*GamerState, colour, random igame.ChipColour, cfg *config) (igame.ChipColour, int, bool) {
+	members := make(map[igame.ChipColour]int, 2)
+	for _, gs := range gamerStates {
+		members[gs.Colour]++
+	}
+
+	if colour == igame.NoColour {
+		colour = random
+		if cfg.firstColour != igame.NoColour {
+			colour = cfg.firstColour
+		}
+		switch {
+		case members[igame.Black] < members[igame.White]:
+			colour = igame.Black
+		case members[igame.White] < members[igame.Black]:
+			colour = igame.White
+		case len(gamerStates) > 0:
+			// teams of rengo are equal, the joining gamer opens the next seats.
+			colour = igame.Black
+		}
+	}
+	for seat := 0; seat < cfg.teamSize(); seat++ {
+		if !seatTaken(gamerStates, colour, seat) {
+			return colour, seat, true
+		}
+	}
+	return igame.NoColour, 0, false
+}
+
+// seatTaken checks that the seat of the colour team is taken by some gamer
+func seatTaken(gamerStates map[int]*GamerState, colour igame.ChipColour, seat int) bool {
+	for _, gs := range gamerStates {
+		if gs.Colour == colour && gs.Seat == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// nextTurnOf returns the next turn of colour from currentTurn on
+func nextTurnOf(currentTurn int, colour igame.ChipColour) int {
+	if isMyTurnCalc(currentTurn, colour) {
+		return currentTurn
+	}
+	return currentTurn + 1
+}
@@ -0,0 +1,72 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestRengo checks joining of four gamers and the order of their moves.
+func TestRengo(t *testing.T) {
+	game, err := NewGame(usualSize, usualKomi, WithRengo())
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+
+	gamers := []*Gamer{
+		{Name: "Black0", ID: 1}, {Name: "White0", ID: 2},
+		{Name: "Black1", ID: 3}, {Name: "White1", ID: 4},
+	}
+	colours := []igame.ChipColour{igame.Black, igame.White, igame.Black}
+	for i, colour := range colours {
+		if err := game.JoinTeam(gamers[i], colour); err != nil {
+			t.Fatalf("Unexpected JoinTeam err of %s: %v", gamers[i].Name, err)
+		}
+	}
+	if err := game.JoinTeam(&Gamer{Name: "Black2", ID: 5}, igame.Black); !errors.Is(err, ErrNoPlace) {
+		t.Errorf("Unexpected JoinTeam err to the full team:\nwant: %v,\ngot: %v", ErrNoPlace, err)
+	}
+	if igb, err := game.IsGameBegun(gamers[0].ID); err != nil || igb != false {
+		t.Errorf("Unexpected IsGameBegun with three gamers: %v, err: %v", igb, err)
+	}
+	if err := game.Join(gamers[3]); err != nil {
+		t.Fatalf("Unexpected Join err of %s: %v", gamers[3].Name, err)
+	}
+	if igb, err := game.IsGameBegun(gamers[0].ID); err != nil || igb != true {
+		t.Errorf("Unexpected IsGameBegun with four gamers: %v, err: %v", igb, err)
+	}
+	if err := game.Join(&Gamer{Name: "Late", ID: 6}); !errors.Is(err, ErrNoPlace) {
+		t.Errorf("Unexpected Join err to the full game:\nwant: %v,\ngot: %v", ErrNoPlace, err)
+	}
+
+	for i, gamer := range gamers {
+		mate := gamers[(i+2)%len(gamers)]
+		if err := game.MakeTurn(mate.ID, &igame.TurnData{X: i + 1, Y: 1}); !errors.Is(err, ErrNotYourTurn) {
+			t.Errorf("Unexpected MakeTurn err of %s out of turn:\nwant: %v,\ngot: %v", mate.Name, ErrNotYourTurn, err)
+		}
+		if err := game.MakeTurn(gamer.ID, &igame.TurnData{X: i + 1, Y: 1}); err != nil {
+			t.Fatalf("Unexpected MakeTurn err of %s: %v", gamer.Name, err)
+		}
+	}
+	if igt, err := game.IsMyTurn(gamers[0].ID); err != nil || igt != true {
+		t.Errorf("Unexpected IsMyTurn of %s after the round: %v, err: %v", gamers[0].Name, igt, err)
+	}
+}
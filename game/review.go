@@ -0,0 +1,204 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// ReviewPosition describes the position of the cursor of the review
+type ReviewPosition struct {
+	Path       []int             // variations chosen from the initial position to the cursor, 0 is the main line
+	Move       *igame.Move       // the move leading to the position, nil for the initial position
+	State      *igame.FieldState // state of the field in the position
+	Variations int               // number of continuations of the position
+}
+
+// reviewNode is a position of the tree of variations of the review
+type reviewNode struct {
+	move     *igame.Move  // the move leading to the position, nil for the initial position
+	field    *field.Field // the field in the position
+	parent   *reviewNode
+	children []*reviewNode // continuations of the position, the first one is the main line
+}
+
+// review is the tree of variations of the finished game with the cursor shared by reviewers
+type review struct {
+	root   *reviewNode
+	cursor *reviewNode
+}
+
+// play returns the continuation of node by move,
+// the new variation is branched on a clone of the field if there is no such continuation
+func (node *reviewNode) play(move igame.Move) (*reviewNode, error) {
+	for _, child := range node.children {
+		if *child.move == move {
+			return child, nil
+		}
+	}
+
+	field := node.field.Clone()
+	var err error
+	switch move.Kind {
+	case igame.PlaceMove:
+		position := move.Position
+		err = field.Move(move.Colour, &position)
+	case igame.PassMove:
+		err = field.Pass(move.Colour)
+	}
+	if err != nil {
+		return nil, err
+	}
+	child := &reviewNode{move: &move, field: field, parent: node}
+	node.children = append(node.children, child)
+	return child, nil
+}
+
+// toMove returns the colour to move in the position of node of the game with cfg
+func (node *reviewNode) toMove(cfg *config) igame.ChipColour {
+	if node.move != nil {
+		return igame.ChipColour(3 - int(node.move.Colour))
+	}
+	if cfg.firstTurn() > 0 {
+		return igame.White
+	}
+	return igame.Black
+}
+
+// position describes the position of node
+func (node *reviewNode) position() *ReviewPosition {
+	pos := &ReviewPosition{State: node.field.State(), Variations: len(node.children)}
+	if node.move != nil {
+		move := *node.move
+		pos.Move = &move
+	}
+	for ; node.parent != nil; node = node.parent {
+		for i, child := range node.parent.children {
+			if child == node {
+				pos.Path = append([]int{i}, pos.Path...)
+			}
+		}
+	}
+	return pos
+}
+
+// startReview returns the review of the game,
+// the main line is made of the history on the first call
+func startReview(gd *gmaeDescriptor) (*review, error) {
+	if gd.review != nil {
+		return gd.review, nil
+	}
+
+	initial, err := field.New(gd.master.Size(), gd.master.State().Komi, gd.cfg.fieldOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if err := setupHandicap(initial, gd.cfg.handicap); err != nil {
+		return nil, err
+	}
+	root := &reviewNode{field: initial}
+	node := root
+	for _, move := range gd.history {
+		if move.Kind == igame.ResignMove {
+			// the resignation doesn't change the position.
+			continue
+		}
+		if node, err = node.play(move.Move); err != nil {
+			return nil, err
+		}
+	}
+	gd.review = &review{root: root, cursor: root}
+	return gd.review, nil
+}
+
+// checkReview checks that the gamer or the spectator with id can review the game
+func checkReview(gamerStates map[int]*GamerState, op string, cmd *gameCommand, gd *gmaeDescriptor) (*review, error) {
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		return nil, opError(op, cmd.id, ErrUnknownID)
+	}
+	if !gd.gameOver || gd.cfg.injected {
+		return nil, opError(op, cmd.id, ErrNoReview)
+	}
+	rv, err := startReview(gd)
+	if err != nil {
+		return nil, opError(op, cmd.id, err)
+	}
+	return rv, nil
+}
+
+// moveCursor moves the cursor of the review to node and informs reviewers
+func moveCursor(gamerStates map[int]*GamerState, rv *review, node *reviewNode, cmd *gameCommand, gd *gmaeDescriptor) {
+	rv.cursor = node
+	colour, _ := viewerColour(gamerStates, cmd.id, gd)
+	publish(gd.subscribers, GameEvent{Kind: ReviewEvent, ID: cmd.id, Colour: colour, Review: node.position()})
+}
+
+// reviewPosition implements concurrently safe processing of querry of
+// ReviewPosition function
+func reviewPosition(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	rv, err := checkReview(gamerStates, "reviewPosition", cmd, gd)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	cmd.rez <- rv.cursor.position()
+}
+
+// reviewGoto implements concurrently safe processing of querry of
+// ReviewGoto function
+func reviewGoto(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	rv, err := checkReview(gamerStates, "reviewGoto", cmd, gd)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	node := rv.root
+	for _, i := range cmd.path {
+		if i < 0 || i >= len(node.children) {
+			cmd.rez <- opError("reviewGoto", cmd.id, ErrNoVariation)
+			return
+		}
+		node = node.children[i]
+	}
+	moveCursor(gamerStates, rv, node, cmd, gd)
+}
+
+// reviewMove implements concurrently safe processing of querry of
+// ReviewMove function
+func reviewMove(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	rv, err := checkReview(gamerStates, "reviewMove", cmd, gd)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+
+	move := igame.Move{Colour: rv.cursor.toMove(gd.cfg), Kind: igame.PlaceMove, Position: *cmd.turn}
+	node, err := rv.cursor.play(move)
+	if err != nil {
+		cmd.rez <- opError("reviewMove", cmd.id, &wrongTurnError{err: err})
+		return
+	}
+	moveCursor(gamerStates, rv, node, cmd, gd)
+}
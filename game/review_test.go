@@ -0,0 +1,117 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestReview checks navigation through the finished game and branching of variations.
+func TestReview(t *testing.T) {
+	game, black, white := pieGame(t)
+	defer game.End()
+	watcher := &Gamer{Name: "Watcher", ID: 4}
+	if err := game.Watch(watcher); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+
+	if _, err := game.ReviewPosition(black); !errors.Is(err, ErrNoReview) {
+		t.Errorf("Unexpected ReviewPosition err of the game in progress:\nwant: %v,\ngot: %v", ErrNoReview, err)
+	}
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 7, Y: 7}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+
+	pos, err := game.ReviewPosition(watcher.ID)
+	if err != nil {
+		t.Fatalf("Unexpected ReviewPosition err: %v", err)
+	}
+	if len(pos.Path) != 0 || pos.Move != nil || pos.Variations != 1 || len(pos.State.ChipsOnBoard[igame.Black]) != 0 {
+		t.Errorf("Unexpected initial position of the review: %+v", pos)
+	}
+
+	events, cancel := game.Subscribe(watcher.ID)
+	defer cancel()
+	if err := game.ReviewGoto(black, []int{0, 0}); err != nil {
+		t.Fatalf("Unexpected ReviewGoto err: %v", err)
+	}
+	ev := nextEvent(t, events)
+	want := &igame.Move{Colour: igame.White, Kind: igame.PlaceMove, Position: igame.TurnData{X: 3, Y: 3}}
+	if ev.Kind != ReviewEvent || ev.ID != black || !reflect.DeepEqual(ev.Review.Move, want) {
+		t.Errorf("Unexpected event of ReviewGoto:\nwant: %v of %v,\ngot: %+v", ReviewEvent, want, ev)
+	}
+
+	if err := game.ReviewMove(white, &igame.TurnData{X: 5, Y: 5}); !errors.Is(err, ErrWrongTurn) {
+		t.Errorf("Unexpected ReviewMove err on occupied position:\nwant: %v,\ngot: %v", ErrWrongTurn, err)
+	}
+	if err := game.ReviewMove(white, &igame.TurnData{X: 4, Y: 4}); err != nil {
+		t.Fatalf("Unexpected ReviewMove err: %v", err)
+	}
+	if ev := nextEvent(t, events); ev.Kind != ReviewEvent || !reflect.DeepEqual(ev.Review.Path, []int{0, 0, 1}) || ev.Review.Move.Colour != igame.Black {
+		t.Errorf("Unexpected event of ReviewMove: %+v", ev.Review)
+	}
+
+	if err := game.ReviewGoto(watcher.ID, []int{0, 0, 0}); err != nil {
+		t.Fatalf("Unexpected ReviewGoto err: %v", err)
+	}
+	nextEvent(t, events)
+	pos, err = game.ReviewPosition(black)
+	if err != nil {
+		t.Fatalf("Unexpected ReviewPosition err: %v", err)
+	}
+	want = &igame.Move{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 7, Y: 7}}
+	if !reflect.DeepEqual(pos.Move, want) || pos.Variations != 0 || len(pos.State.ChipsOnBoard[igame.Black]) != 2 {
+		t.Errorf("Unexpected position at the end of the main line: %+v", pos)
+	}
+
+	if err := game.ReviewGoto(black, []int{0, 5}); !errors.Is(err, ErrNoVariation) {
+		t.Errorf("Unexpected ReviewGoto err of missing variation:\nwant: %v,\ngot: %v", ErrNoVariation, err)
+	}
+	if _, err := game.ReviewPosition(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected ReviewPosition err of foreign gamer:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
+
+// TestReviewHandicapOne checks that black moves first in the review of the game with handicap 1.
+func TestReviewHandicapOne(t *testing.T) {
+	game, black, white := pieGame(t, WithHandicap(1))
+	defer game.End()
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+
+	if err := game.ReviewMove(black, &igame.TurnData{X: 5, Y: 5}); err != nil {
+		t.Fatalf("Unexpected ReviewMove err: %v", err)
+	}
+	pos, err := game.ReviewPosition(black)
+	if err != nil {
+		t.Fatalf("Unexpected ReviewPosition err: %v", err)
+	}
+	if !reflect.DeepEqual(pos.Path, []int{0}) || pos.Move == nil || pos.Move.Colour != igame.Black {
+		t.Errorf("Unexpected position after the first move of the main line: %+v", pos)
+	}
+}
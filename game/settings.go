@@ -0,0 +1,166 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"time"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Settings are settings of the game gamers can renegotiate before the first move
+type Settings struct {
+	Komi      float64       // komi of the game, ignored if the game has handicap chips
+	Handicap  int           // number of handicap chips of black, 0 if the game has no handicap
+	MainTime  time.Duration // time budget of each gamer, 0 if the game has no time control
+	Increment time.Duration // time added to the gamer's budget after each move
+	PerMove   time.Duration // time of each move not taken from the gamer's budget
+}
+
+// Configuration describes the effective configuration of the game
+type Configuration struct {
+	Size     int
+	Ruleset  igame.Ruleset // ruleset of the field, JapaneseRules if the master doesn't tell it
+	Settings               // settings of the game, changes by renegotiation and SetKomi included
+	Deadline time.Duration // time limit of each move, 0 if moves are not limited
+	Vacation time.Duration // vacation time of each gamer at the beginning of the game
+	PieRule  bool
+	Rengo    bool
+	Teaching bool
+}
+
+// settled returns the settings of the game and the new field prepared for them
+func settled(gd *gmaeDescriptor, settings *Settings) (*config, igame.Master, error) {
+	cfg := *gd.cfg
+	cfg.komi, cfg.handicap = settings.Komi, settings.Handicap
+	if cfg.handicap > 0 {
+		cfg.komi = handicapKomi
+	}
+	cfg.mainTime, cfg.increment, cfg.perMove = settings.MainTime, settings.Increment, settings.PerMove
+
+	master, err := field.New(gd.master.Size(), cfg.komi, cfg.fieldOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := setupHandicap(master, cfg.handicap); err != nil {
+		return nil, nil, err
+	}
+	return &cfg, master, nil
+}
+
+// checkSettings checks that the gamer with id can negotiate settings of the game
+func checkSettings(gamerStates map[int]*GamerState, op string, cmd *gameCommand, gd *gmaeDescriptor) error {
+	if _, err := getGamerStateAndChecks(gamerStates, op, cmd.id, gd.gameOver); err != nil {
+		return err
+	}
+	if len(gamerStates) < gd.cfg.seats() || len(gd.history) > 0 || gd.cfg.injected || gd.cfg.teaching {
+		return opError(op, cmd.id, ErrNoNegotiation)
+	}
+	return nil
+}
+
+// gameSettings implements concurrently safe processing of querry of
+// Settings function
+func gameSettings(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("settings", cmd.id, ErrUnknownID)
+		return
+	}
+
+	conf := &Configuration{
+		Size: gd.master.Size(),
+		Settings: Settings{
+			Komi:      gd.master.State().Komi,
+			Handicap:  gd.cfg.handicap,
+			MainTime:  gd.cfg.mainTime,
+			Increment: gd.cfg.increment,
+			PerMove:   gd.cfg.perMove,
+		},
+		Deadline: gd.cfg.deadline,
+		Vacation: gd.cfg.vacation,
+		PieRule:  gd.pieRule,
+		Rengo:    gd.cfg.rengo,
+		Teaching: gd.cfg.teaching,
+	}
+	if reporter, ok := gd.master.(igame.RulesetReporter); ok == true {
+		conf.Ruleset = reporter.Ruleset()
+	}
+	cmd.rez <- conf
+}
+
+// proposeSettings implements concurrently safe processing of querry of
+// ProposeSettings function
+func proposeSettings(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if err := checkSettings(gamerStates, "proposeSettings", cmd, gd); err != nil {
+		cmd.rez <- err
+		return
+	}
+	if _, _, err := settled(gd, cmd.settings); err != nil {
+		cmd.rez <- opError("proposeSettings", cmd.id, err)
+		return
+	}
+
+	gd.proposal, gd.proposer = cmd.settings, cmd.id
+	publish(gd.subscribers, GameEvent{Kind: SettingsProposedEvent, ID: cmd.id, Colour: gamerStates[cmd.id].Colour, Settings: cmd.settings})
+}
+
+// answerSettings implements concurrently safe processing of querry of
+// AnswerSettings function.
+// The field and clocks of the game are replaced on acceptance.
+func answerSettings(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if err := checkSettings(gamerStates, "answerSettings", cmd, gd); err != nil {
+		cmd.rez <- err
+		return
+	}
+	if gd.proposal == nil || gd.proposer == cmd.id {
+		cmd.rez <- opError("answerSettings", cmd.id, ErrNoSettingsProposal)
+		return
+	}
+
+	settings := gd.proposal
+	gd.proposal, gd.proposer = nil, 0
+	if !cmd.accept {
+		return
+	}
+	cfg, master, err := settled(gd, settings)
+	if err != nil {
+		cmd.rez <- opError("answerSettings", cmd.id, err)
+		return
+	}
+
+	// clocks are restarted with the new time control.
+	if gd.clock.running {
+		gd.clock.timer.Stop()
+	}
+	gd.clock = clock{}
+	for _, gs := range gamerStates {
+		gs.Remaining = cfg.mainTime
+	}
+	gd.cfg, gd.master = cfg, master
+	gd.currentTurn = cfg.firstTurn()
+
+	publish(gd.subscribers, GameEvent{Kind: SettingsChangedEvent, ID: cmd.id, Colour: gamerStates[cmd.id].Colour, Settings: settings})
+	// white moves first if handicap chips are put.
+	reportOnTurnChange(gamerStates, gd.currentTurn-1, gd)
+}
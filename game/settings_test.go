@@ -0,0 +1,130 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestSettings checks renegotiation of settings before the first move.
+func TestSettings(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, err := NewGame(usualSize, usualKomi, WithTimeSource(ft))
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+	gamers := copyGamers(validGamers)
+	joinGamers(&commonArgs{t: t, game: game, gamers: gamers})
+	black, white := gamers[0].ID, gamers[1].ID
+	if igt, _ := game.IsMyTurn(black); igt != true {
+		black, white = white, black
+	}
+
+	settings := &Settings{Handicap: 2, MainTime: time.Minute}
+	if err := game.ProposeSettings(black, nil); !errors.Is(err, ErrNoSettings) {
+		t.Errorf("Unexpected ProposeSettings err without settings:\nwant: %v,\ngot: %v", ErrNoSettings, err)
+	}
+	if err := game.ProposeSettings(black, &Settings{Handicap: 20}); !errors.Is(err, ErrHandicap) {
+		t.Errorf("Unexpected ProposeSettings err:\nwant: %v,\ngot: %v", ErrHandicap, err)
+	}
+	if err := game.ProposeSettings(black, settings); err != nil {
+		t.Fatalf("Unexpected ProposeSettings err: %v", err)
+	}
+	if err := game.AnswerSettings(black, true); !errors.Is(err, ErrNoSettingsProposal) {
+		t.Errorf("Unexpected AnswerSettings err by proposer:\nwant: %v,\ngot: %v", ErrNoSettingsProposal, err)
+	}
+	if err := game.AnswerSettings(white, false); err != nil {
+		t.Fatalf("Unexpected AnswerSettings err: %v", err)
+	}
+	if err := game.AnswerSettings(white, true); !errors.Is(err, ErrNoSettingsProposal) {
+		t.Errorf("Unexpected AnswerSettings err after decline:\nwant: %v,\ngot: %v", ErrNoSettingsProposal, err)
+	}
+
+	if err := game.ProposeSettings(black, settings); err != nil {
+		t.Fatalf("Unexpected ProposeSettings err: %v", err)
+	}
+	ft.advance(10 * time.Second)
+	if err := game.AnswerSettings(white, true); err != nil {
+		t.Fatalf("Unexpected AnswerSettings err: %v", err)
+	}
+
+	if igt, err := game.IsMyTurn(white); err != nil || igt != true {
+		t.Errorf("Unexpected IsMyTurn of white after handicap: %v, err: %v", igt, err)
+	}
+	state, err := game.GameState(black)
+	if err != nil {
+		t.Fatalf("Unexpected GameState err: %v", err)
+	}
+	if state.Handicap != 2 || state.Komi != handicapKomi || len(state.ChipsOnBoard[igame.Black]) != 2 {
+		t.Errorf("Unexpected GameState after settings: %+v", state)
+	}
+	ft.advance(10 * time.Second)
+	if gs, err := game.GamerState(white); err != nil || gs.Remaining != 50*time.Second {
+		t.Errorf("Unexpected remaining time of white:\nwant: %v,\ngot: %v, err: %v", 50*time.Second, gs.Remaining, err)
+	}
+
+	if err := game.MakeTurn(white, &igame.TurnData{X: usualSize + 1, Y: 5}); !errors.Is(err, ErrInvalidTurn) {
+		t.Errorf("Unexpected MakeTurn err out of the field after settings:\nwant: %v,\ngot: %v", ErrInvalidTurn, err)
+	}
+	if err := game.MakeTurn(white, &igame.TurnData{X: 5, Y: 5}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	if err := game.ProposeSettings(black, settings); !errors.Is(err, ErrNoNegotiation) {
+		t.Errorf("Unexpected ProposeSettings err after the first move:\nwant: %v,\ngot: %v", ErrNoNegotiation, err)
+	}
+}
+
+// TestGameSettings checks the effective configuration of the game shown to gamers and spectators.
+func TestGameSettings(t *testing.T) {
+	game, err := NewGame(usualSize, usualKomi, WithAbsoluteTime(time.Minute), WithPieRule(),
+		WithFieldOptions(field.WithRuleset(igame.ChineseRules)))
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+	gamers := copyGamers(validGamers)
+	joinGamers(&commonArgs{t: t, game: game, gamers: gamers})
+	watcher := &Gamer{Name: "Watcher", ID: 4}
+	if err := game.Watch(watcher); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+
+	want := &Configuration{
+		Size:     usualSize,
+		Ruleset:  igame.ChineseRules,
+		Settings: Settings{Komi: usualKomi, MainTime: time.Minute},
+		PieRule:  true,
+	}
+	for _, id := range []int{gamers[0].ID, watcher.ID} {
+		conf, err := game.Settings(id)
+		if err != nil {
+			t.Fatalf("Unexpected Settings err of %d: %v", id, err)
+		}
+		if *conf != *want {
+			t.Errorf("Unexpected Settings of %d:\nwant: %+v,\ngot: %+v", id, want, conf)
+		}
+	}
+	if _, err := game.Settings(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Settings err of foreign gamer:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package sgf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+var (
+	// ErrSyntax is an error of parsing malformed SGF data
+	ErrSyntax = errors.New("sgf syntax error")
+	// ErrProperty is an error of parsing property with wrong value
+	ErrProperty = errors.New("wrong sgf property value")
+)
+
+// property is a parsed SGF property with all it's values
+type property struct {
+	ident  string
+	values []string
+}
+
+// parser holds state of parsing
+type parser struct {
+	data []byte
+	pos  int
+}
+
+// Parse reads SGF data from r and returns the record of the main variation.
+func Parse(r io.Reader) (*Record, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{data: data}
+	nodes, err := p.gameTree()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &Record{}
+	for _, node := range nodes {
+		for _, prop := range node {
+			if err := rec.apply(prop); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rec, nil
+}
+
+// apply sets data of the record described by property prop
+func (rec *Record) apply(prop property) (err error) {
+	value := prop.values[0]
+	switch prop.ident {
+	case "SZ":
+		rec.Size, err = strconv.Atoi(value)
+	case "KM":
+		rec.Komi, err = strconv.ParseFloat(value, 64)
+	case "HA":
+		rec.Handicap, err = strconv.Atoi(value)
+	case "PB":
+		rec.PlayerBlack = value
+	case "PW":
+		rec.PlayerWhite = value
+	case "TM":
+		var seconds float64
+		seconds, err = strconv.ParseFloat(value, 64)
+		rec.TimeLimit = time.Duration(seconds * float64(time.Second))
+	case "OT":
+		rec.Overtime = value
+	case "RE":
+		rec.Result = value
+	case "AB", "AW":
+		err = rec.addSetup(prop)
+	case "B", "W":
+		var move igame.Move
+		move, err = rec.move(prop.ident, value)
+		rec.Moves = append(rec.Moves, move)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s[%s]: %s", ErrProperty, prop.ident, value, err)
+	}
+	return nil
+}
+
+// addSetup appends chips of AB or AW property to setup of the record
+func (rec *Record) addSetup(prop property) error {
+	colour := igame.ChipColour(igame.Black)
+	if prop.ident == "AW" {
+		colour = igame.White
+	}
+	for _, value := range prop.values {
+		td, err := parsePoint(value)
+		if err != nil {
+			return err
+		}
+		rec.Setup = append(rec.Setup, igame.Placement{Colour: colour, Position: td})
+	}
+	return nil
+}
+
+// move converts a value of B or W property to a move
+func (rec *Record) move(ident, value string) (igame.Move, error) {
+	move := igame.Move{Colour: igame.Black, Kind: igame.PassMove}
+	if ident == "W" {
+		move.Colour = igame.White
+	}
+	if value == "" || (value == "tt" && rec.Size <= 19) {
+		return move, nil
+	}
+
+	td, err := parsePoint(value)
+	if err != nil {
+		return move, err
+	}
+	move.Kind = igame.PlaceMove
+	move.Position = td
+	return move, nil
+}
+
+// parsePoint converts SGF notation of a position to TurnData
+func parsePoint(value string) (igame.TurnData, error) {
+	if len(value) != 2 || value[0] < 'a' || value[0] > 'z' || value[1] < 'a' || value[1] > 'z' {
+		return igame.TurnData{}, errors.New("malformed point")
+	}
+	return igame.TurnData{X: int(value[0]-'a') + 1, Y: int(value[1]-'a') + 1}, nil
+}
+
+// gameTree parses a game tree and returns nodes of it's main variation
+func (p *parser) gameTree() ([][]property, error) {
+	if !p.consume('(') {
+		return nil, p.errorf("'(' expected")
+	}
+
+	nodes := make([][]property, 0)
+	for p.peek() == ';' {
+		p.pos++
+		node, err := p.node()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 0 {
+		return nil, p.errorf("node expected")
+	}
+
+	for i := 0; p.peek() == '('; i++ {
+		variation, err := p.gameTree()
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			nodes = append(nodes, variation...)
+		}
+	}
+
+	if !p.consume(')') {
+		return nil, p.errorf("')' expected")
+	}
+	return nodes, nil
+}
+
+// node parses properties of one node
+func (p *parser) node() ([]property, error) {
+	props := make([]property, 0)
+	for {
+		c := p.peek()
+		if c < 'A' || c > 'Z' {
+			return props, nil
+		}
+
+		start := p.pos
+		for c := p.peek(); c >= 'A' && c <= 'Z'; c = p.peek() {
+			p.pos++
+		}
+		prop := property{ident: string(p.data[start:p.pos])}
+
+		for p.peek() == '[' {
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			prop.values = append(prop.values, value)
+		}
+		if len(prop.values) == 0 {
+			return nil, p.errorf("value of %s expected", prop.ident)
+		}
+		props = append(props, prop)
+	}
+}
+
+// value parses one property value with escaping
+func (p *parser) value() (string, error) {
+	p.pos++ // '['
+	var b strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case ']':
+			return b.String(), nil
+		case '\\':
+			if p.pos < len(p.data) {
+				b.WriteByte(p.data[p.pos])
+				p.pos++
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated value")
+}
+
+// peek skips white spaces and returns the next byte, or 0 at the end of data
+func (p *parser) peek() byte {
+	for p.pos < len(p.data) && strings.IndexByte(" \t\r\n", p.data[p.pos]) >= 0 {
+		p.pos++
+	}
+	if p.pos >= len(p.data) {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *parser) consume(c byte) bool {
+	if p.peek() != c {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w at offset %d: %s", ErrSyntax, p.pos, fmt.Sprintf(format, args...))
+}
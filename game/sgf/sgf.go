@@ -0,0 +1,170 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package sgf provides reading and writing of game records in Smart Game Format
+package sgf
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Record holds data of one game record
+type Record struct {
+	Size        int
+	Komi        float64
+	Handicap    int
+	PlayerBlack string
+	PlayerWhite string
+	TimeLimit   time.Duration // main time of each gamer, 0 if the game has no time control
+	Overtime    string        // description of overtime, empty if there is no overtime
+	Result      string        // result in SGF notation ("B+R", "W+3.5", "0"), empty if unknown
+	Setup       []igame.Placement
+	Moves       []igame.Move
+}
+
+// Write writes the record to w in SGF format.
+func (rec *Record) Write(w io.Writer) error {
+	_, err := io.WriteString(w, rec.String())
+	return err
+}
+
+// String provides compatibility with Stringer interface.
+func (rec *Record) String() string {
+	var b strings.Builder
+
+	b.WriteString("(;FF[4]GM[1]CA[UTF-8]")
+	writeProperty(&b, "SZ", strconv.Itoa(rec.Size))
+	writeProperty(&b, "KM", strconv.FormatFloat(rec.Komi, 'f', -1, 64))
+	if rec.Handicap > 0 {
+		writeProperty(&b, "HA", strconv.Itoa(rec.Handicap))
+	}
+	if rec.PlayerBlack != "" {
+		writeProperty(&b, "PB", rec.PlayerBlack)
+	}
+	if rec.PlayerWhite != "" {
+		writeProperty(&b, "PW", rec.PlayerWhite)
+	}
+	if rec.TimeLimit > 0 {
+		writeProperty(&b, "TM", strconv.FormatFloat(rec.TimeLimit.Seconds(), 'f', -1, 64))
+	}
+	if rec.Overtime != "" {
+		writeProperty(&b, "OT", rec.Overtime)
+	}
+	if rec.Result != "" {
+		writeProperty(&b, "RE", rec.Result)
+	}
+
+	rec.writeSetup(&b)
+
+	for _, move := range rec.Moves {
+		if move.Kind == igame.ResignMove {
+			continue
+		}
+		b.WriteString(";")
+		writeProperty(&b, colourProperty(move.Colour), moveValue(move))
+	}
+	b.WriteString(")")
+
+	return b.String()
+}
+
+// writeSetup writes AB and AW properties of setup chips
+func (rec *Record) writeSetup(b *strings.Builder) {
+	for _, colour := range []igame.ChipColour{igame.Black, igame.White} {
+		ident := "A" + colourProperty(colour)
+		for _, p := range rec.Setup {
+			if p.Colour != colour {
+				continue
+			}
+			if ident != "" {
+				b.WriteString(ident)
+				ident = ""
+			}
+			fmt.Fprintf(b, "[%s]", point(p.Position))
+		}
+	}
+}
+
+func writeProperty(b *strings.Builder, ident, value string) {
+	fmt.Fprintf(b, "%s[%s]", ident, escape(value))
+}
+
+func escape(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return strings.ReplaceAll(value, "]", `\]`)
+}
+
+func colourProperty(colour igame.ChipColour) string {
+	if colour == igame.White {
+		return "W"
+	}
+	return "B"
+}
+
+func moveValue(move igame.Move) string {
+	if move.Kind == igame.PassMove {
+		return ""
+	}
+	return point(move.Position)
+}
+
+// FormatResult returns result in SGF notation, or empty string if result is nil
+func FormatResult(result *igame.Result) string {
+	if result == nil {
+		return ""
+	}
+	if result.Method == igame.AbortMethod {
+		return "Void"
+	}
+	if result.Winner == igame.NoColour {
+		return "0"
+	}
+	if result.Jigo {
+		// the winner of jigo by a tie-break has no margin.
+		if result.Winner == igame.White {
+			return "W+"
+		}
+		return "B+"
+	}
+
+	winner := "B+"
+	if result.Winner == igame.White {
+		winner = "W+"
+	}
+	switch result.Method {
+	case igame.ResignMethod:
+		return winner + "R"
+	case igame.TimeoutMethod:
+		return winner + "T"
+	case igame.ForfeitMethod:
+		return winner + "F"
+	case igame.CaptureMethod:
+		// SGF has no notation for it, the score is omitted.
+		return winner
+	}
+	return winner + strconv.FormatFloat(result.Margin, 'f', -1, 64)
+}
+
+// point returns SGF notation of the position td
+func point(td igame.TurnData) string {
+	return string([]byte{byte('a' + td.X - 1), byte('a' + td.Y - 1)})
+}
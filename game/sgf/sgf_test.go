@@ -0,0 +1,137 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package sgf_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+	. "github.com/yagoggame/gomaster/game/sgf"
+)
+
+var writeTests = []struct {
+	name   string
+	record *Record
+	want   string
+}{
+	{
+		name:   "empty",
+		record: &Record{Size: 19, Komi: 6.5},
+		want:   "(;FF[4]GM[1]CA[UTF-8]SZ[19]KM[6.5])",
+	},
+	{
+		name: "full",
+		record: &Record{
+			Size:        9,
+			Komi:        0.5,
+			Handicap:    2,
+			PlayerBlack: "Joe",
+			PlayerWhite: "Ni]ck",
+			TimeLimit:   90 * time.Second,
+			Overtime:    "Fischer 10s",
+			Result:      "B+R",
+			Setup: []igame.Placement{
+				{Colour: igame.White, Position: igame.TurnData{X: 5, Y: 5}},
+				{Colour: igame.Black, Position: igame.TurnData{X: 3, Y: 7}},
+				{Colour: igame.Black, Position: igame.TurnData{X: 7, Y: 3}},
+			},
+			Moves: []igame.Move{
+				{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 1, Y: 1}},
+				{Colour: igame.White, Kind: igame.PassMove},
+				{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 9, Y: 3}},
+				{Colour: igame.White, Kind: igame.ResignMove},
+			},
+		},
+		want: `(;FF[4]GM[1]CA[UTF-8]SZ[9]KM[0.5]HA[2]PB[Joe]PW[Ni\]ck]TM[90]OT[Fischer 10s]RE[B+R]AB[cg][gc]AW[ee];B[aa];W[];B[ic])`,
+	},
+}
+
+func TestWrite(t *testing.T) {
+	for _, test := range writeTests {
+		t.Run(test.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := test.record.Write(&b); err != nil {
+				t.Fatalf("Unexpected Write err: %v", err)
+			}
+			if b.String() != test.want {
+				t.Errorf("Unexpected Write result:\nwant: %s,\ngot: %s", test.want, b.String())
+			}
+		})
+	}
+}
+
+var parseTests = []struct {
+	name string
+	data string
+	want *Record
+	err  error
+}{
+	{
+		name: "round trip",
+		data: writeTests[1].want,
+		want: &Record{
+			Size:        9,
+			Komi:        0.5,
+			Handicap:    2,
+			PlayerBlack: "Joe",
+			PlayerWhite: "Ni]ck",
+			TimeLimit:   90 * time.Second,
+			Overtime:    "Fischer 10s",
+			Result:      "B+R",
+			Setup: []igame.Placement{
+				{Colour: igame.Black, Position: igame.TurnData{X: 3, Y: 7}},
+				{Colour: igame.Black, Position: igame.TurnData{X: 7, Y: 3}},
+				{Colour: igame.White, Position: igame.TurnData{X: 5, Y: 5}},
+			},
+			Moves: writeTests[1].record.Moves[:3],
+		},
+	},
+	{
+		name: "variations and unknown properties",
+		data: "(;GM[1]SZ[19]C[comment]\n;B[pd];W[tt]\n(;B[dd])\n(;B[dp]))",
+		want: &Record{
+			Size: 19,
+			Moves: []igame.Move{
+				{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 16, Y: 4}},
+				{Colour: igame.White, Kind: igame.PassMove},
+				{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 4, Y: 4}},
+			},
+		},
+	},
+	{name: "no tree", data: ";B[aa]", err: ErrSyntax},
+	{name: "unterminated", data: "(;B[aa", err: ErrSyntax},
+	{name: "wrong size", data: "(;SZ[big])", err: ErrProperty},
+	{name: "wrong point", data: "(;B[a])", err: ErrProperty},
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range parseTests {
+		t.Run(test.name, func(t *testing.T) {
+			rec, err := Parse(strings.NewReader(test.data))
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Unexpected Parse err:\nwant: %v,\ngot: %v", test.err, err)
+			}
+			if err == nil && !reflect.DeepEqual(rec, test.want) {
+				t.Errorf("Unexpected Parse result:\nwant: %v,\ngot: %v", test.want, rec)
+			}
+		})
+	}
+}
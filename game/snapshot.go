@@ -0,0 +1,297 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/field"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Snapshot holds the state of the Game to persist it and resume by RestoreGame.
+// Settings passed by WithOpeningBook, spectators, subscribers,
+// pending undo requests and rematch offers are not included.
+// Options of the field passed by WithFieldOptions are included, except filters of the state,
+// the game with such filters can't be snapshotted.
+type Snapshot struct {
+	Size            int
+	Ruleset         igame.Ruleset
+	HalfPointKomi   bool             // komi must be a multiple of 0.5
+	CaptureGo       bool             // the first capture wins the game
+	JigoWinner      igame.ChipColour // colour winning on equal scores, NoColour if jigo is a draw
+	Blind           bool             // chips of the opponent are hidden from gamers
+	BlindAll        bool             // all chips are hidden from gamers, if Blind is true
+	Komi            float64          // komi of the game, changes by SetKomi included
+	Handicap        int
+	PieRule         bool
+	PieDecided      bool // the white gamer swapped or set komi
+	MutedSpectators bool
+	MainTime        time.Duration
+	Increment       time.Duration
+	PerMove         time.Duration
+	VacationTime    time.Duration // vacation time of each gamer at the beginning of the game
+	Deadline        time.Duration
+	AutoPass        bool
+	AutoPasses      int // number of missed deadlines of each gamer passed before forfeit
+	Rengo           bool
+	Teaching        bool
+	Players         []SnapshotPlayer // gamers joined the game ordered by id
+	Moves           []HistoryMove    // moves made in the game
+	Turn            int              // number of the current turn
+	Counting        bool             // the game is in counting phase
+	Paused          bool             // the game is paused by agreement of gamers
+	Dead            []igame.TurnData // positions of dead chips marked in counting phase
+	Rejections      int              // number of rejections of the score in counting phase
+	GameOver        bool
+	Begun           time.Time     // time both gamers joined the game, zero if the game is not begun
+	Ended           time.Time     // time the game is over, zero if the game is in progress
+	Result          *igame.Result // result of the game decided outside of the field, nil if the field decided it
+	Chat            []ChatMessage
+}
+
+// SnapshotPlayer describes a gamer of the Snapshot.
+// A vacation in progress is not resumed by RestoreGame.
+type SnapshotPlayer struct {
+	ID        int
+	Name      string
+	Colour    igame.ChipColour
+	Remaining time.Duration // remaining time of the gamer
+	Vacation  time.Duration // remaining vacation time of the gamer
+	Seat      int           // order of the gamer in the team of rengo
+	Timeouts  int           // number of move deadlines missed by the gamer
+}
+
+// RestoreGame creates the Game in the state described by snap.
+// Settings which are not included into snap are passed by opts,
+// settings of snap override them.
+// Gamers of snap are joined to the Game, their clocks are started again.
+// Game mast be finished by calling of End() method.
+func RestoreGame(snap *Snapshot, opts ...Option) (*Game, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	cfg.komi = snap.Komi
+	cfg.handicap = snap.Handicap
+	cfg.pieRule = snap.PieRule
+	cfg.muteSpectators = snap.MutedSpectators
+	cfg.mainTime, cfg.increment, cfg.perMove = snap.MainTime, snap.Increment, snap.PerMove
+	cfg.vacation = snap.VacationTime
+	cfg.deadline, cfg.autoPass, cfg.autoPasses = snap.Deadline, snap.AutoPass, snap.AutoPasses
+	cfg.rengo, cfg.teaching = snap.Rengo, snap.Teaching
+
+	if snap.Blind && !cfg.blind {
+		WithBlindGo(snap.BlindAll)(cfg)
+	}
+
+	fieldOptions := append(cfg.fieldOptions[:len(cfg.fieldOptions):len(cfg.fieldOptions)],
+		field.WithRuleset(snap.Ruleset), field.WithJigoWinner(snap.JigoWinner))
+	if snap.HalfPointKomi {
+		fieldOptions = append(fieldOptions, field.WithHalfPointKomi())
+	}
+	if snap.CaptureGo {
+		fieldOptions = append(fieldOptions, field.WithCaptureGo())
+	}
+	field, err := field.New(snap.Size, snap.Komi, fieldOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if err := setupHandicap(field, snap.Handicap); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshot, err)
+	}
+	if err := replay(field, snap.Moves); err != nil {
+		return nil, err
+	}
+
+	gamerStates, err := restoreGamers(snap.Players, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	gd := newDescriptor(field, cfg)
+	gd.pieDecided = snap.PieDecided
+	gd.currentTurn = snap.Turn
+	gd.counting = snap.Counting
+	gd.paused = snap.Paused
+	gd.dead = append([]igame.TurnData(nil), snap.Dead...)
+	gd.rejections = snap.Rejections
+	gd.gameOver = snap.GameOver
+	gd.begun, gd.ended = snap.Begun, snap.Ended
+	if snap.Result != nil {
+		result := *snap.Result
+		gd.result = &result
+	}
+	gd.history = append([]HistoryMove(nil), snap.Moves...)
+	gd.chat = append([]ChatMessage(nil), snap.Chat...)
+	players := append([]SnapshotPlayer(nil), snap.Players...)
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].Seat < players[j].Seat
+	})
+	for _, p := range players {
+		if gd.players[p.Colour] != "" {
+			gd.players[p.Colour] += " & " + p.Name
+		} else {
+			gd.players[p.Colour] = p.Name
+		}
+	}
+
+	g := newGameHandle()
+	g.run(gamerStates, gd)
+	return g, nil
+}
+
+// replay makes moves on the field
+func replay(field *field.Field, moves []HistoryMove) error {
+	for i, move := range moves {
+		var err error
+		switch move.Kind {
+		case igame.PlaceMove:
+			position := move.Position
+			err = field.Move(move.Colour, &position)
+		case igame.PassMove:
+			err = field.Pass(move.Colour)
+		case igame.ResignMove:
+			err = field.Resign(move.Colour)
+		default:
+			err = fmt.Errorf("unknown kind of move %v", move.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: failed to replay move %d: %s", ErrSnapshot, i, err)
+		}
+	}
+	return nil
+}
+
+// restoreGamers creates states of gamers of the snapshot
+func restoreGamers(players []SnapshotPlayer, cfg *config) (map[int]*GamerState, error) {
+	if len(players) > cfg.seats() {
+		return nil, fmt.Errorf("%w: %d gamers in the game", ErrSnapshot, len(players))
+	}
+
+	gamerStates := make(map[int]*GamerState, len(players))
+	seats := make(map[SnapshotPlayer]bool, len(players))
+	for _, p := range players {
+		if p.Colour != igame.Black && p.Colour != igame.White {
+			return nil, fmt.Errorf("%w: gamer with id %d has colour %v", ErrSnapshot, p.ID, p.Colour)
+		}
+		if p.Seat < 0 || p.Seat >= cfg.teamSize() {
+			return nil, fmt.Errorf("%w: gamer with id %d has seat %d", ErrSnapshot, p.ID, p.Seat)
+		}
+		seat := SnapshotPlayer{Colour: p.Colour, Seat: p.Seat}
+		if _, ok := gamerStates[p.ID]; ok == true || seats[seat] == true {
+			return nil, fmt.Errorf("%w: gamer with id %d or seat %d of colour %v is duplicated", ErrSnapshot, p.ID, p.Seat, p.Colour)
+		}
+		seats[seat] = true
+		gamerStates[p.ID] = &GamerState{
+			Colour:    p.Colour,
+			Name:      p.Name,
+			Remaining: p.Remaining,
+			Vacation:  p.Vacation,
+			Seat:      p.Seat,
+			Timeouts:  p.Timeouts,
+		}
+	}
+	return gamerStates, nil
+}
+
+// snapshot implements concurrently safe processing of querry of
+// Snapshot function
+func snapshot(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	snap, err := makeSnapshot(gamerStates, gd)
+	if err != nil {
+		cmd.rez <- err
+		return
+	}
+	cmd.rez <- snap
+}
+
+// variantReporter is implemented by Masters which tell options of the field
+type variantReporter interface {
+	Variant() field.Variant
+}
+
+// makeSnapshot describes the current state of the game
+func makeSnapshot(gamerStates map[int]*GamerState, gd *gmaeDescriptor) (*Snapshot, error) {
+	snap := &Snapshot{
+		Size:            gd.master.Size(),
+		Komi:            gd.master.State().Komi,
+		Handicap:        gd.cfg.handicap,
+		PieRule:         gd.pieRule,
+		PieDecided:      gd.pieDecided,
+		MutedSpectators: gd.cfg.muteSpectators,
+		MainTime:        gd.cfg.mainTime,
+		Increment:       gd.cfg.increment,
+		PerMove:         gd.cfg.perMove,
+		VacationTime:    gd.cfg.vacation,
+		Deadline:        gd.cfg.deadline,
+		AutoPass:        gd.cfg.autoPass,
+		AutoPasses:      gd.cfg.autoPasses,
+		Rengo:           gd.cfg.rengo,
+		Teaching:        gd.cfg.teaching,
+		Players:         make([]SnapshotPlayer, 0, len(gamerStates)),
+		Moves:           append([]HistoryMove(nil), gd.history...),
+		Turn:            gd.currentTurn,
+		Counting:        gd.counting,
+		Paused:          gd.paused,
+		Dead:            append([]igame.TurnData(nil), gd.dead...),
+		Rejections:      gd.rejections,
+		GameOver:        gd.gameOver,
+		Begun:           gd.begun,
+		Ended:           gd.ended,
+		Chat:            append([]ChatMessage(nil), gd.chat...),
+	}
+	if gd.result != nil {
+		result := *gd.result
+		snap.Result = &result
+	}
+	if reporter, ok := gd.master.(igame.RulesetReporter); ok == true {
+		snap.Ruleset = reporter.Ruleset()
+	}
+	snap.Blind, snap.BlindAll = gd.cfg.blind, gd.cfg.blindAll
+	if reporter, ok := gd.master.(variantReporter); ok == true {
+		variant := reporter.Variant()
+		snap.HalfPointKomi, snap.CaptureGo, snap.JigoWinner = variant.HalfPointKomi, variant.CaptureGo, variant.JigoWinner
+		// the filter of blind go is restored by Blind.
+		if gd.cfg.blind {
+			variant.Filters--
+		}
+		if variant.Filters > 0 {
+			return nil, fmt.Errorf("%w: filters of the state passed by WithFieldOptions can't be persisted", ErrSnapshot)
+		}
+	}
+
+	for id, gs := range gamerStates {
+		snap.Players = append(snap.Players, SnapshotPlayer{
+			ID:        id,
+			Name:      gs.Name,
+			Colour:    gs.Colour,
+			Remaining: gs.Remaining - gd.spent(id),
+			Vacation:  gs.Vacation - vacationSpent(gs, gd.cfg),
+			Seat:      gs.Seat,
+			Timeouts:  gs.Timeouts,
+		})
+	}
+	sort.Slice(snap.Players, func(i, j int) bool {
+		return snap.Players[i].ID < snap.Players[j].ID
+	})
+	return snap, nil
+}
@@ -0,0 +1,101 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestSnapshot checks that the game restored from the snapshot
+// passed through JSON continues from the same position.
+func TestSnapshot(t *testing.T) {
+	game, black, white := pieGame(t, WithFischerTime(time.Minute, 10*time.Second))
+
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	if err := game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	snap, err := game.Snapshot()
+	if err != nil {
+		t.Fatalf("Unexpected Snapshot err: %v", err)
+	}
+	game.End()
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("Unexpected Marshal err: %v", err)
+	}
+	var loaded Snapshot
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unexpected Unmarshal err: %v", err)
+	}
+
+	restored, err := RestoreGame(&loaded)
+	if err != nil {
+		t.Fatalf("Unexpected RestoreGame err: %v", err)
+	}
+	defer restored.End()
+
+	if got := chipsOnBoard(t, restored, black); got != 2 {
+		t.Errorf("Unexpected number of chips on board:\nwant: 2,\ngot: %d", got)
+	}
+	if igt, err := restored.IsMyTurn(white); err != nil || igt != true {
+		t.Errorf("Unexpected IsMyTurn of white: %v, err: %v", igt, err)
+	}
+	moves, err := restored.History(black)
+	if err != nil || len(moves) != 3 {
+		t.Errorf("Unexpected History: %v, err: %v", moves, err)
+	}
+	clocks, err := restored.Clocks(white)
+	if err != nil {
+		t.Fatalf("Unexpected Clocks err: %v", err)
+	}
+	if c := clocks[igame.White]; c.Running != true || c.Remaining > time.Minute+10*time.Second || c.Remaining < time.Minute {
+		t.Errorf("Unexpected clock of white: %+v", c)
+	}
+
+	if err := restored.MakeTurn(white, &igame.TurnData{X: 7, Y: 7}); err != nil {
+		t.Errorf("Unexpected MakeTurn err after restoring: %v", err)
+	}
+}
+
+// TestRestoreInconsistent checks that a snapshot with illegal moves
+// or wrong gamers is not restored.
+func TestRestoreInconsistent(t *testing.T) {
+	move := HistoryMove{Move: igame.Move{Colour: igame.Black, Kind: igame.PlaceMove, Position: igame.TurnData{X: 5, Y: 5}}}
+	snaps := map[string]*Snapshot{
+		"occupied": {Size: usualSize, Komi: usualKomi, Moves: []HistoryMove{move, move}},
+		"colour": {Size: usualSize, Komi: usualKomi, Players: []SnapshotPlayer{
+			{ID: 1, Colour: igame.Black},
+			{ID: 2, Colour: igame.Black},
+		}},
+	}
+	for name, snap := range snaps {
+		t.Run(name, func(t *testing.T) {
+			if _, err := RestoreGame(snap); !errors.Is(err, ErrSnapshot) {
+				t.Errorf("Unexpected RestoreGame err:\nwant: %v,\ngot: %v", ErrSnapshot, err)
+			}
+		})
+	}
+}
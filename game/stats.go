@@ -0,0 +1,97 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Stats holds statistics of the game
+type Stats struct {
+	Moves            int                                // number of moves made, passes included
+	Passes           int                                // number of passes
+	Captures         map[igame.ChipColour]int           // number of chips captured by colour
+	AverageThinkTime map[igame.ChipColour]time.Duration // average time of a move of colour
+	Duration         time.Duration                      // time since the game begun till the end or till now, 0 if it's not begun
+}
+
+// stats implements concurrently safe processing of querry of
+// Stats function
+func stats(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("stats", cmd.id, ErrUnknownID)
+		return
+	}
+
+	state := gd.master.State()
+	rez := &Stats{
+		Captures: map[igame.ChipColour]int{
+			igame.Black: state.ChipsCuptured[igame.White],
+			igame.White: state.ChipsCuptured[igame.Black],
+		},
+		AverageThinkTime: make(map[igame.ChipColour]time.Duration, 2),
+	}
+
+	thinkTime := make(map[igame.ChipColour]time.Duration, 2)
+	moves := make(map[igame.ChipColour]int, 2)
+	previous := gd.begun
+	for _, move := range gd.history {
+		if move.Kind == igame.ResignMove {
+			continue
+		}
+		rez.Moves++
+		if move.Kind == igame.PassMove {
+			rez.Passes++
+		}
+		thinkTime[move.Colour] += move.Time.Sub(previous)
+		moves[move.Colour]++
+		previous = move.Time
+	}
+	for colour, n := range moves {
+		rez.AverageThinkTime[colour] = thinkTime[colour] / time.Duration(n)
+	}
+
+	switch {
+	case gd.begun.IsZero():
+	case gd.gameOver:
+		rez.Duration = gd.ended.Sub(gd.begun)
+	default:
+		rez.Duration = gd.cfg.since(gd.begun)
+	}
+	cmd.rez <- rez
+}
+
+// movesStats returns the number of moves made by the gamer with id, passes included,
+// and the time the gamer spent on them
+func movesStats(gd *gmaeDescriptor, id int) (moves int, used time.Duration) {
+	previous := gd.begun
+	for _, move := range gd.history {
+		if move.Kind == igame.ResignMove {
+			continue
+		}
+		if move.ID == id {
+			moves++
+			used += move.Time.Sub(previous)
+		}
+		previous = move.Time
+	}
+	return moves, used
+}
@@ -0,0 +1,94 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestStats checks statistics of moves and time of the game.
+func TestStats(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, black, white := pieGame(t, WithTimeSource(ft))
+	defer game.End()
+
+	ft.advance(10 * time.Second)
+	if err := game.Pass(white); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	ft.advance(20 * time.Second)
+	if err := game.MakeTurn(black, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+
+	stats, err := game.Stats(white)
+	if err != nil {
+		t.Fatalf("Unexpected Stats err: %v", err)
+	}
+	if stats.Moves != 3 || stats.Passes != 1 || stats.Captures[igame.Black] != 0 || stats.Duration != 30*time.Second {
+		t.Errorf("Unexpected Stats: %+v", stats)
+	}
+	if stats.AverageThinkTime[igame.Black] != 10*time.Second || stats.AverageThinkTime[igame.White] != 10*time.Second {
+		t.Errorf("Unexpected average think time: %v", stats.AverageThinkTime)
+	}
+}
+
+// TestGamerStateCounters checks counters of moves, captures and time of gamers.
+func TestGamerStateCounters(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, black, white := pieGame(t, WithTimeSource(ft))
+	defer game.End()
+
+	moves := []struct {
+		id int
+		td igame.TurnData
+	}{
+		{white, igame.TurnData{X: 1, Y: 1}},
+		{black, igame.TurnData{X: 1, Y: 2}},
+		{white, igame.TurnData{X: 9, Y: 9}},
+		{black, igame.TurnData{X: 2, Y: 1}},
+	}
+	for _, move := range moves {
+		ft.advance(10 * time.Second)
+		if err := game.MakeTurn(move.id, &move.td); err != nil {
+			t.Fatalf("Unexpected MakeTurn err: %v", err)
+		}
+	}
+
+	tests := []struct {
+		id       int
+		moves    int
+		captures int
+		used     time.Duration
+	}{
+		{id: black, moves: 3, captures: 1, used: 20 * time.Second},
+		{id: white, moves: 2, captures: 0, used: 20 * time.Second},
+	}
+	for _, test := range tests {
+		gs, err := game.GamerState(test.id)
+		if err != nil {
+			t.Fatalf("Unexpected GamerState err: %v", err)
+		}
+		if gs.Moves != test.moves || gs.Captures != test.captures || gs.TimeUsed != test.used {
+			t.Errorf("Unexpected counters of gamer with id %d:\nwant: %d moves, %d captures, %v,\ngot: %d moves, %d captures, %v.",
+				test.id, test.moves, test.captures, test.used, gs.Moves, gs.Captures, gs.TimeUsed)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// Status describes the game at a glance
+type Status struct {
+	Turn     int                 // number of the current turn
+	ToMove   igame.ChipColour    // colour of the gamer to move, NoColour if the game is not begun or is over
+	Begun    bool                // both gamers joined the game
+	Paused   bool                // the game is paused by agreement of gamers
+	Counting bool                // the game is in counting phase
+	GameOver bool                // the game is over
+	Gamers   map[int]*GamerState // gamers joined the game by id with their clocks
+	Watchers int                 // number of spectators of the game
+	Watching map[int]string      // names of spectators by id, nil unless WithSpectatorList is set
+}
+
+// status implements concurrently safe processing of querry of
+// Status function
+func status(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("status", cmd.id, ErrUnknownID)
+		return
+	}
+
+	rez := &Status{
+		Turn:     gd.currentTurn,
+		ToMove:   igame.NoColour,
+		Begun:    len(gamerStates) == gd.cfg.seats(),
+		Paused:   gd.paused,
+		Counting: gd.counting,
+		GameOver: gd.gameOver,
+		Gamers:   copyGamerStates(gamerStates, gd),
+		Watchers: len(gd.spectators),
+	}
+	if gd.cfg.listSpectators {
+		rez.Watching = make(map[int]string, len(gd.spectators))
+		for id, spectator := range gd.spectators {
+			rez.Watching[id] = spectator.Name
+		}
+	}
+	if rez.Begun && !gd.gameOver {
+		rez.ToMove = igame.White
+		if isMyTurnCalc(gd.currentTurn, igame.Black) {
+			rez.ToMove = igame.Black
+		}
+	}
+	cmd.rez <- rez
+}
+
+// gamers implements concurrently safe processing of querry of
+// Gamers function
+func gamers(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	defer close(cmd.rez)
+
+	if _, ok := viewerColour(gamerStates, cmd.id, gd); ok == false {
+		cmd.rez <- opError("gamers", cmd.id, ErrUnknownID)
+		return
+	}
+	cmd.rez <- copyGamerStates(gamerStates, gd)
+}
+
+// publishSpectators delivers the event of a change of spectators of kind,
+// the id of the spectator is hidden unless WithSpectatorList is set
+func publishSpectators(gd *gmaeDescriptor, kind EventKind, id int) {
+	if gd.cfg.listSpectators == false {
+		id = 0
+	}
+	publish(gd.subscribers, GameEvent{Kind: kind, ID: id, Watchers: len(gd.spectators)})
+}
+
+// copyGamerStates makes copies of states of all gamers of the game by id
+func copyGamerStates(gamerStates map[int]*GamerState, gd *gmaeDescriptor) map[int]*GamerState {
+	rez := make(map[int]*GamerState, len(gamerStates))
+	for id, gs := range gamerStates {
+		rez[id] = copyGamerState(gs, id, gd)
+	}
+	return rez
+}
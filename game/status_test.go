@@ -0,0 +1,89 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestStatus checks the status of the game in progress and after it's over.
+func TestStatus(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, black, white := pieGame(t, WithTimeSource(ft), WithAbsoluteTime(time.Minute))
+	defer game.End()
+
+	ft.advance(10 * time.Second)
+	status, err := game.Status(black)
+	if err != nil {
+		t.Fatalf("Unexpected Status err: %v", err)
+	}
+	if status.Turn != 1 || status.ToMove != igame.White || !status.Begun || status.Paused || status.Counting || status.GameOver {
+		t.Errorf("Unexpected Status: %+v", status)
+	}
+	if len(status.Gamers) != 2 || status.Gamers[black].Colour != igame.Black || status.Gamers[white].Colour != igame.White {
+		t.Fatalf("Unexpected gamers of Status: %+v", status.Gamers)
+	}
+	if status.Gamers[white].Remaining != 50*time.Second {
+		t.Errorf("Unexpected remaining time of white:\nwant: %v,\ngot: %v.", 50*time.Second, status.Gamers[white].Remaining)
+	}
+
+	if err := game.Resign(white); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	status, err = game.Status(black)
+	if err != nil {
+		t.Fatalf("Unexpected Status err: %v", err)
+	}
+	if status.ToMove != igame.NoColour || !status.GameOver {
+		t.Errorf("Unexpected Status after resign: %+v", status)
+	}
+
+	if _, err := game.Status(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Status err:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
+
+// TestGamers checks that states of all gamers are shown to gamers and spectators.
+func TestGamers(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, black, white := pieGame(t, WithTimeSource(ft), WithAbsoluteTime(time.Minute))
+	defer game.End()
+	watcher := &Gamer{Name: "Watcher", ID: 4}
+	if err := game.Watch(watcher); err != nil {
+		t.Fatalf("Unexpected Watch err: %v", err)
+	}
+
+	ft.advance(10 * time.Second)
+	gamers, err := game.Gamers(watcher.ID)
+	if err != nil {
+		t.Fatalf("Unexpected Gamers err: %v", err)
+	}
+	if len(gamers) != 2 || gamers[black].Colour != igame.Black || gamers[white].Colour != igame.White {
+		t.Fatalf("Unexpected Gamers: %+v", gamers)
+	}
+	if gamers[black].Name == "" || gamers[white].Remaining != 50*time.Second {
+		t.Errorf("Unexpected state of gamers: black %+v, white %+v", gamers[black], gamers[white])
+	}
+
+	if _, err := game.Gamers(invalidGamer.ID); !errors.Is(err, ErrUnknownID) {
+		t.Errorf("Unexpected Gamers err:\nwant: %v,\ngot: %v", ErrUnknownID, err)
+	}
+}
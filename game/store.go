@@ -0,0 +1,93 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+// GameStore persists the game as it's played.
+// The Game appends every move to the store as it's made and saves the snapshot
+// of the game after every change, so the game can be resumed by LoadGame.
+// Methods of the store are called by the goroutine of the Game one by one,
+// failures are delivered to subscribers by StoreFailedEvent.
+type GameStore interface {
+	AppendMove(move HistoryMove) error
+	SaveSnapshot(snap *Snapshot) error
+	LoadSnapshot() (*Snapshot, error)
+}
+
+// persistent is a set of actions which can change the game
+var persistent = map[gameAction]bool{
+	joinCMD:           true,
+	makeTurnCMD:       true,
+	passCMD:           true,
+	resignCMD:         true,
+	leaveCMD:          true,
+	swapCMD:           true,
+	setKomiCMD:        true,
+	markDeadCMD:       true,
+	acceptScoreCMD:    true,
+	resumePlayCMD:     true,
+	answerUndoCMD:     true,
+	sayCMD:            true,
+	timeoutCMD:        true,
+	startVacationCMD:  true,
+	endVacationCMD:    true,
+	vacationOverCMD:   true,
+	disconnectCMD:     true,
+	rejoinCMD:         true,
+	graceOverCMD:      true,
+	pauseCMD:          true,
+	resumeCMD:         true,
+	acceptAbortCMD:    true,
+	answerSettingsCMD: true,
+	rejectScoreCMD:    true,
+	forceEndCMD:       true,
+}
+
+// LoadGame resumes the Game from the snapshot loaded from store
+// and keeps writing it through store. See RestoreGame for the meaning of opts.
+// Game mast be finished by calling of End() method.
+func LoadGame(store GameStore, opts ...Option) (*Game, error) {
+	snap, err := store.LoadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return RestoreGame(snap, append(opts, WithStore(store))...)
+}
+
+// storeMove appends move to the store of the game if any
+func storeMove(gd *gmaeDescriptor, move HistoryMove) {
+	if gd.cfg.store == nil {
+		return
+	}
+	if err := gd.cfg.store.AppendMove(move); err != nil {
+		publish(gd.subscribers, GameEvent{Kind: StoreFailedEvent, ID: move.ID, Err: err})
+	}
+}
+
+// storeSnapshot saves the snapshot of the game to the store of the game if any
+// after processing of cmd which can change the game
+func storeSnapshot(gamerStates map[int]*GamerState, cmd *gameCommand, gd *gmaeDescriptor) {
+	if gd.cfg.store == nil || !persistent[cmd.act] {
+		return
+	}
+	snap, err := makeSnapshot(gamerStates, gd)
+	if err == nil {
+		err = gd.cfg.store.SaveSnapshot(snap)
+	}
+	if err != nil {
+		publish(gd.subscribers, GameEvent{Kind: StoreFailedEvent, Err: err})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// memStore is a GameStore keeping the game in memory
+type memStore struct {
+	mu    sync.Mutex
+	moves []HistoryMove
+	snap  *Snapshot
+	err   error
+}
+
+func (s *memStore) AppendMove(move HistoryMove) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.moves = append(s.moves, move)
+	return s.err
+}
+
+func (s *memStore) SaveSnapshot(snap *Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snap = snap
+	return s.err
+}
+
+func (s *memStore) LoadSnapshot() (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.snap == nil {
+		return nil, errors.New("no snapshot")
+	}
+	return s.snap, nil
+}
+
+// TestStore checks that the game is written through the store
+// and resumed from it.
+func TestStore(t *testing.T) {
+	store := &memStore{}
+	game, black, white := pieGame(t, WithStore(store))
+
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	// the snapshot is saved after the reply, so wait for the next command.
+	if _, err := game.IsMyTurn(black); err != nil {
+		t.Fatalf("Unexpected IsMyTurn err: %v", err)
+	}
+	game.End()
+
+	store.mu.Lock()
+	if len(store.moves) != 2 || store.snap == nil || len(store.snap.Moves) != 2 {
+		t.Errorf("Unexpected content of the store: moves %v, snapshot %+v", store.moves, store.snap)
+	}
+	store.mu.Unlock()
+
+	loaded, err := LoadGame(store)
+	if err != nil {
+		t.Fatalf("Unexpected LoadGame err: %v", err)
+	}
+	defer loaded.End()
+
+	if got := chipsOnBoard(t, loaded, black); got != 2 {
+		t.Errorf("Unexpected number of chips on board:\nwant: 2,\ngot: %d", got)
+	}
+	if err := loaded.Pass(black); err != nil {
+		t.Fatalf("Unexpected Pass err: %v", err)
+	}
+	if _, err := loaded.IsMyTurn(black); err != nil {
+		t.Fatalf("Unexpected IsMyTurn err: %v", err)
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.moves) != 3 || len(store.snap.Moves) != 3 {
+		t.Errorf("Unexpected content of the store after LoadGame: moves %v, snapshot %+v", store.moves, store.snap)
+	}
+}
+
+// TestStoreFailed checks that failures of the store are delivered to subscribers.
+func TestStoreFailed(t *testing.T) {
+	store := &memStore{err: errors.New("disk is full")}
+	game, black, white := pieGame(t, WithStore(store))
+	defer game.End()
+
+	events, cancel := game.Subscribe(black)
+	defer cancel()
+
+	if err := game.MakeTurn(white, &igame.TurnData{X: 3, Y: 3}); err != nil {
+		t.Fatalf("Unexpected MakeTurn err: %v", err)
+	}
+	wants := []GameEvent{
+		{Kind: StoreFailedEvent, ID: white},
+		{Kind: MoveMadeEvent, ID: white},
+		{Kind: StoreFailedEvent},
+	}
+	for _, want := range wants {
+		got := nextEvent(t, events)
+		if got.Kind != want.Kind || got.ID != want.ID {
+			t.Errorf("Unexpected event:\nwant: %+v,\ngot: %+v", want, got)
+		}
+		if got.Kind == StoreFailedEvent && !errors.Is(got.Err, store.err) {
+			t.Errorf("Unexpected err of StoreFailedEvent:\nwant: %v,\ngot: %v", store.err, got.Err)
+		}
+	}
+}
+
+// blockingStore is a memStore blocking AppendMove until release is closed
+type blockingStore struct {
+	memStore
+	release chan struct{}
+}
+
+func (s *blockingStore) AppendMove(move HistoryMove) error {
+	if s.release != nil {
+		<-s.release
+	}
+	return s.memStore.AppendMove(move)
+}
+
+// TestCancelledBusyGame checks that queries cancelled by the context return
+// while the game is busy with the blocked store.
+func TestCancelledBusyGame(t *testing.T) {
+	store := &blockingStore{}
+	game, black, white := pieGame(t, WithStore(store))
+	defer game.End()
+	store.release = make(chan struct{})
+	defer close(store.release)
+
+	for _, test := range []struct {
+		caseName string
+		query    func(ctx context.Context) error
+	}{
+		{caseName: "MakeTurn", query: func(ctx context.Context) error {
+			return game.MakeTurnContext(ctx, white, &igame.TurnData{X: 3, Y: 3})
+		}},
+		{caseName: "WaitTurn", query: func(ctx context.Context) error { return game.WaitTurn(ctx, black) }},
+		{caseName: "WaitState", query: func(ctx context.Context) error {
+			_, err := game.WaitStateContext(ctx, black)
+			return err
+		}},
+		{caseName: "OnMove", query: func(ctx context.Context) error {
+			return game.OnMoveContext(ctx, func(MoveEvent) {})
+		}},
+		{caseName: "OnEnd", query: func(ctx context.Context) error {
+			return game.OnEndContext(ctx, func(igame.Result) {})
+		}},
+	} {
+		t.Run(test.caseName, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), rtDurationThreshold/4)
+			defer cancel()
+			rez := make(chan error, 1)
+			go func() { rez <- test.query(ctx) }()
+			select {
+			case err := <-rez:
+				if !errors.Is(err, ErrCancellation) {
+					t.Errorf("Unexpected err of the cancelled query:\nwant: %v,\ngot: %v", ErrCancellation, err)
+				}
+			case <-time.After(rtDurationThreshold):
+				t.Errorf("The cancelled query is blocked by the busy game")
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "github.com/yagoggame/gomaster/game/igame"
+
+// movingColour returns the colour the gamer with state gs moves by on the current turn.
+// The gamer of the teaching mode moves by the colour of the turn
+func movingColour(gs *GamerState, gd *gmaeDescriptor) igame.ChipColour {
+	if gd.cfg.teaching {
+		if isMyTurnCalc(gd.currentTurn, igame.Black) {
+			return igame.Black
+		}
+		return igame.White
+	}
+	return gs.Colour
+}
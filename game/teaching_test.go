@@ -0,0 +1,69 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestTeaching checks that the only gamer of the teaching mode moves for both colours.
+func TestTeaching(t *testing.T) {
+	game, err := NewGame(usualSize, usualKomi, WithTeaching())
+	if err != nil {
+		t.Fatalf("Unexpected err on NewGame: %v", err)
+	}
+	defer game.End()
+
+	teacher := &Gamer{Name: "Teacher", ID: 1}
+	if err := game.Join(teacher); err != nil {
+		t.Fatalf("Unexpected Join err: %v", err)
+	}
+	if err := game.Join(&Gamer{Name: "Pupil", ID: 2}); !errors.Is(err, ErrNoPlace) {
+		t.Errorf("Unexpected Join err of the second gamer:\nwant: %v,\ngot: %v", ErrNoPlace, err)
+	}
+	if igb, err := game.IsGameBegun(teacher.ID); err != nil || igb != true {
+		t.Errorf("Unexpected IsGameBegun: %v, err: %v", igb, err)
+	}
+
+	events, cancel := game.Subscribe(teacher.ID)
+	defer cancel()
+	for i, colour := range []igame.ChipColour{igame.Black, igame.White, igame.Black} {
+		if igt, err := game.IsMyTurn(teacher.ID); err != nil || igt != true {
+			t.Errorf("Unexpected IsMyTurn on move %d: %v, err: %v", i, igt, err)
+		}
+		if err := game.MakeTurn(teacher.ID, &igame.TurnData{X: i + 1, Y: 1}); err != nil {
+			t.Fatalf("Unexpected MakeTurn err on move %d: %v", i, err)
+		}
+		if ev := nextEvent(t, events); ev.Kind != MoveMadeEvent || ev.Colour != colour {
+			t.Errorf("Unexpected event on move %d: %v of %v, want %v of %v", i, ev.Kind, ev.Colour, MoveMadeEvent, colour)
+		}
+	}
+
+	if err := game.Resign(teacher.ID); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+	res, err := game.Result(teacher.ID)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if res.Winner != igame.Black {
+		t.Errorf("Unexpected winner after resign of white: %v", res.Winner)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import "time"
+
+// TimeSource provides the current time and timers to time controls of the Game,
+// so time can be mocked in tests or taken from another source
+type TimeSource interface {
+	Now() time.Time
+	// AfterFunc waits for the duration d to elapse and then calls f in its own goroutine
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by TimeSource
+type Timer interface {
+	// Stop prevents the Timer from firing,
+	// it returns false if the timer has already fired or been stopped
+	Stop() bool
+}
+
+// systemTime is the TimeSource of the time package
+type systemTime struct{}
+
+func (systemTime) Now() time.Time {
+	return time.Now()
+}
+
+func (systemTime) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// timeSource returns the time source of the game
+func (cfg *config) timeSource() TimeSource {
+	if cfg.time == nil {
+		return systemTime{}
+	}
+	return cfg.time
+}
+
+// since returns the time elapsed since t by the time source of the game
+func (cfg *config) since(t time.Time) time.Duration {
+	return cfg.timeSource().Now().Sub(t)
+}
@@ -0,0 +1,108 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package game
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// fakeTime is a TimeSource moved forward by the test
+type fakeTime struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (ft *fakeTime) Now() time.Time {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return ft.now
+}
+
+func (ft *fakeTime) AfterFunc(d time.Duration, f func()) Timer {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	timer := &fakeTimer{at: ft.now.Add(d), f: f}
+	ft.timers = append(ft.timers, timer)
+	return &fakeTimerStopper{ft: ft, timer: timer}
+}
+
+type fakeTimerStopper struct {
+	ft    *fakeTime
+	timer *fakeTimer
+}
+
+func (s *fakeTimerStopper) Stop() bool {
+	s.ft.mu.Lock()
+	defer s.ft.mu.Unlock()
+	wasActive := !s.timer.stopped
+	s.timer.stopped = true
+	return wasActive
+}
+
+// advance moves the time forward by d and fires expired timers
+func (ft *fakeTime) advance(d time.Duration) {
+	ft.mu.Lock()
+	ft.now = ft.now.Add(d)
+	fired := make([]func(), 0)
+	for _, timer := range ft.timers {
+		if !timer.stopped && !timer.at.After(ft.now) {
+			timer.stopped = true
+			fired = append(fired, timer.f)
+		}
+	}
+	ft.mu.Unlock()
+
+	for _, f := range fired {
+		f()
+	}
+}
+
+// TestTimeSource checks that time controls use the time source of the game.
+func TestTimeSource(t *testing.T) {
+	ft := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	game, black, white := pieGame(t, WithAbsoluteTime(time.Minute), WithTimeSource(ft))
+	defer game.End()
+
+	ft.advance(30 * time.Second)
+	clocks, err := game.Clocks(white)
+	if err != nil {
+		t.Fatalf("Unexpected Clocks err: %v", err)
+	}
+	if c := clocks[igame.White]; c.Remaining != 30*time.Second || c.Running != true {
+		t.Errorf("Unexpected clock of white: %+v", c)
+	}
+
+	ft.advance(30 * time.Second)
+	result, err := game.Result(black)
+	if err != nil {
+		t.Fatalf("Unexpected Result err: %v", err)
+	}
+	if result.Winner != igame.Black || result.Method != igame.TimeoutMethod {
+		t.Errorf("Unexpected Result:\nwant: win of black by timeout,\ngot: %v", result)
+	}
+}
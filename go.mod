@@ -1,5 +1,5 @@
 module github.com/yagoggame/gomaster
 
-go 1.13
+go 1.18
 
 require google.golang.org/genproto v0.0.0-20200311144346-b662892dd51b // indirect
@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"github.com/yagoggame/gomaster/game"
+	"github.com/yagoggame/gomaster/game/igame"
 )
 
 var (
@@ -37,6 +38,9 @@ var (
 	ErrGamerOccupied = errors.New("gamer already joined to another game")
 	// ErrGamerGameStart is an error of game starting
 	ErrGamerGameStart = errors.New("gamer failed to start a new game")
+	// ErrNoGame is an error of operation on the game of the gamer
+	// who doesn't play a game started by the pool
+	ErrNoGame = errors.New("gamer doesn't play a game of the pool")
 )
 
 // GamersPool is a datatype based on chanel,
@@ -116,6 +120,52 @@ func (gp GamersPool) GetGamer(id int) (*game.Gamer, error) {
 	return nil, fmt.Errorf("wrong result type: %v", rez)
 }
 
+// ForceEndGame finishes the game of the gamer with id by the administrator for the reason.
+// The game is void if winner is NoColour, else it's won by winner by forfeit.
+// Gamers can't end games this way, since the administrator of games is kept by the pool.
+func (gp GamersPool) ForceEndGame(id int, reason string, winner igame.ChipColour) error {
+	c := make(chan interface{})
+	gp <- &command{act: forceEndG, id: id, reason: reason, winner: winner, rez: c}
+
+	if err := <-c; err != nil {
+		return err.(error)
+	}
+	return nil
+}
+
+// GetRating gets the Glicko-2 rating of the gamer with id.
+// Ratings are updated when games started by JoinGame finish with a result.
+func (gp GamersPool) GetRating(id int) (Rating, error) {
+	rez, err := gp.rating(id)
+	if err != nil {
+		return Rating{}, err
+	}
+	return rez.rating, nil
+}
+
+// RatingHistory gets changes of the rating of the gamer with id, oldest first.
+func (gp GamersPool) RatingHistory(id int) ([]RatingChange, error) {
+	rez, err := gp.rating(id)
+	if err != nil {
+		return nil, err
+	}
+	return rez.history, nil
+}
+
+// rating gets the rating with the history of the gamer with id
+func (gp GamersPool) rating(id int) (*ratingRez, error) {
+	c := make(chan interface{})
+	gp <- &command{act: getR, id: id, rez: c}
+	rez := <-c
+	switch rez := rez.(type) {
+	case error:
+		return nil, rez
+	case *ratingRez:
+		return rez, nil
+	}
+	return nil, fmt.Errorf("wrong result type: %v", rez)
+}
+
 // Release releases the pool.
 func (gp GamersPool) Release() {
 	c := make(chan interface{})
@@ -58,7 +58,7 @@ func checkFunction(t *testing.T, test testCase, fn func(id int) (*game.Gamer, er
 
 	switch test.want == nil {
 	case true:
-		if returnedGamer == nil || !reflect.DeepEqual(*returnedGamer, *test.gamer) {
+		if returnedGamer == nil || !reflect.DeepEqual(*returnedGamer, rated(test.gamer)) {
 			t.Errorf("Unexpected action gamer:\nwant: %v,\ngot %v", test.gamer, returnedGamer)
 		}
 	case false:
@@ -122,7 +122,7 @@ func join(t *testing.T, pool GamersPool) int {
 }
 
 func checkGamesCount(t *testing.T, pool GamersPool) {
-	games := make(map[game.Game]bool)
+	games := make(map[*game.Game]bool)
 	actualGamers := pool.ListGamers()
 
 	for _, g := range actualGamers {
@@ -133,3 +133,10 @@ func checkGamesCount(t *testing.T, pool GamersPool) {
 		t.Errorf("Unexpected number of games for %d validGamers:\nwant: %d,\ngot %d", len(validGamers), int(math.Ceil(float64(len(validGamers))/2.0)), len(games))
 	}
 }
+
+// rated returns a copy of gamer with the rating filled by the pool for a gamer without rated games
+func rated(gamer *game.Gamer) game.Gamer {
+	gCpy := *gamer
+	gCpy.Rating = DefaultRating.Rating
+	return gCpy
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/yagoggame/gomaster/game"
+	"github.com/yagoggame/gomaster/game/igame"
 )
 
 var errNoVacantGamer = errors.New("failed to find vacant gamer")
@@ -30,25 +31,32 @@ type action int
 
 // set of actions values of GamersPool object.
 const (
-	add      action = iota // add gamer to pool
-	rem                    // remove gamer from pool
-	rel                    // release all data
-	lst                    // get list of gamers in pool
-	joinG                  // join the Game or create a new one
-	releaseG               // release the Game
-	getG                   // get gamer's game
+	add       action = iota // add gamer to pool
+	rem                     // remove gamer from pool
+	rel                     // release all data
+	lst                     // get list of gamers in pool
+	joinG                   // join the Game or create a new one
+	releaseG                // release the Game
+	getG                    // get gamer's game
+	getR                    // get gamer's rating
+	forceEndG               // finish the gamer's game by the administrator
 )
 
 // command is a type to hold a comand to a GamersPool.
 type command struct {
-	act   action
-	komi  float64
-	size  int
-	gamer *game.Gamer
-	id    int
-	rez   chan<- interface{}
+	act    action
+	komi   float64
+	size   int
+	gamer  *game.Gamer
+	id     int
+	reason string
+	winner igame.ChipColour
+	rez    chan<- interface{}
 }
 
+// games holds handles of administrators of games started by the pool
+type games map[*game.Game]*game.Admin
+
 // addGamer implements concurrently safe processing of querry of
 // AddGamer function
 func addGamer(gamers map[int]*game.Gamer, gamer *game.Gamer, rezChan chan<- interface{}) {
@@ -63,32 +71,30 @@ func addGamer(gamers map[int]*game.Gamer, gamer *game.Gamer, rezChan chan<- inte
 
 // rmGamer implements concurrently safe processing of querry of
 // RmGamer function
-func rmGamer(gamers map[int]*game.Gamer, id int, rezChan chan<- interface{}) {
+func rmGamer(gamers map[int]*game.Gamer, rs *ratings, id int, rezChan chan<- interface{}) {
 	defer close(rezChan)
 
 	if gamer, ok := gamers[id]; ok == true {
-		gCpy := *gamer
-		rezChan <- &gCpy
+		rezChan <- rs.rated(gamer)
 	}
 	delete(gamers, id)
 }
 
 // listGamers implements concurrently safe processing of querry of
 // ListGamers function
-func listGamers(gamers map[int]*game.Gamer, rezChan chan<- interface{}) {
+func listGamers(gamers map[int]*game.Gamer, rs *ratings, rezChan chan<- interface{}) {
 	defer close(rezChan)
 
 	rez := make([]*game.Gamer, 0, len(gamers))
 	for k := range gamers {
-		gCpy := *gamers[k]
-		rez = append(rez, &gCpy)
+		rez = append(rez, rs.rated(gamers[k]))
 	}
 	rezChan <- rez
 }
 
 // getGamer implements concurrently safe processing of querry of
 // GetGamer function
-func getGamer(gamers map[int]*game.Gamer, id int, rezChan chan<- interface{}) {
+func getGamer(gamers map[int]*game.Gamer, rs *ratings, id int, rezChan chan<- interface{}) {
 	defer close(rezChan)
 
 	gamer, ok := gamers[id]
@@ -96,12 +102,11 @@ func getGamer(gamers map[int]*game.Gamer, id int, rezChan chan<- interface{}) {
 		rezChan <- fmt.Errorf("failed to get gamer for id %d: %w", id, ErrIDNotFound)
 		return
 	}
-	gCpy := *gamer
-	rezChan <- &gCpy
+	rezChan <- rs.rated(gamer)
 	return
 }
 
-func joinOtherGame(gamers map[int]*game.Gamer, gamer *game.Gamer, cmd *command) error {
+func joinOtherGame(gamers map[int]*game.Gamer, rs *ratings, gamer *game.Gamer, cmd *command) error {
 	for _, g := range gamers {
 		if gamer.ID == g.ID {
 			continue
@@ -116,6 +121,7 @@ func joinOtherGame(gamers map[int]*game.Gamer, gamer *game.Gamer, cmd *command)
 
 			if err := g.GetGame().Join(&gCpy); err == nil {
 				gamer.SetGame(g.GetGame())
+				seat(rs, g.GetGame(), gamer.ID)
 				return nil
 			}
 
@@ -124,11 +130,23 @@ func joinOtherGame(gamers map[int]*game.Gamer, gamer *game.Gamer, cmd *command)
 	return errNoVacantGamer
 }
 
-func startOwnGame(gamer *game.Gamer, cmd *command) error {
-	game, err := game.NewGame(cmd.size, cmd.komi)
+func startOwnGame(gamer *game.Gamer, admins games, rs *ratings, cmd *command) error {
+	admin := &game.Admin{}
+	game, err := game.NewGame(cmd.size, cmd.komi, game.WithAdmin(admin))
 	if err != nil {
 		return fmt.Errorf("failed to create game for gamer with id %d: %w: %s", gamer.ID, ErrGamerGameStart, err)
 	}
+	players := rs.table(game)
+	if err := game.OnDestroy(func() { rs.forget(game) }); err != nil {
+		rs.forget(game)
+		game.End()
+		return fmt.Errorf("failed to rate game for gamer with id %d: %w: %s", gamer.ID, ErrGamerGameStart, err)
+	}
+	// players are passed directly, because the game can be forgotten before the result is rated.
+	if err := game.OnEnd(func(result igame.Result) { rs.rate(players, result) }); err != nil {
+		game.End()
+		return fmt.Errorf("failed to rate game for gamer with id %d: %w: %s", gamer.ID, ErrGamerGameStart, err)
+	}
 
 	//copy the gamer to prevent of changing by the Game
 	gCpy := *gamer
@@ -138,12 +156,21 @@ func startOwnGame(gamer *game.Gamer, cmd *command) error {
 		return fmt.Errorf("failed to join gamer with id %d to a game: %w: %s", gamer.ID, ErrGamerGameStart, err)
 	}
 	gamer.SetGame(game)
+	admins[game] = admin
+	seat(rs, game, gamer.ID)
 	return nil
 }
 
+// seat notes the colour of the gamer with id joined to g for rating of g
+func seat(rs *ratings, g *game.Game, id int) {
+	if gs, err := g.GamerState(id); err == nil {
+		rs.seat(g, gs.Colour, id)
+	}
+}
+
 // joinGame implements concurrently safe processing of querry of
 // JoinGame function
-func joinGame(gamers map[int]*game.Gamer, cmd *command) {
+func joinGame(gamers map[int]*game.Gamer, admins games, rs *ratings, cmd *command) {
 	defer close(cmd.rez)
 
 	gamer, ok := gamers[cmd.id]
@@ -157,9 +184,9 @@ func joinGame(gamers map[int]*game.Gamer, cmd *command) {
 		return
 	}
 
-	err := joinOtherGame(gamers, gamer, cmd)
+	err := joinOtherGame(gamers, rs, gamer, cmd)
 	if errors.Is(err, errNoVacantGamer) {
-		if err := startOwnGame(gamer, cmd); err != nil {
+		if err := startOwnGame(gamer, admins, rs, cmd); err != nil {
 			cmd.rez <- err
 		}
 	}
@@ -167,7 +194,7 @@ func joinGame(gamers map[int]*game.Gamer, cmd *command) {
 
 // releaseGame implements concurrently safe processing of querry of
 // ReleaseGame function
-func releaseGame(gamers map[int]*game.Gamer, id int, rezChan chan<- interface{}) {
+func releaseGame(gamers map[int]*game.Gamer, admins games, id int, rezChan chan<- interface{}) {
 	defer close(rezChan)
 	//  get a gamer by id. If there is no such gamer - it's  bad
 	gamer, ok := gamers[id]
@@ -176,15 +203,67 @@ func releaseGame(gamers map[int]*game.Gamer, id int, rezChan chan<- interface{})
 		return
 	}
 
-	if gamer.GetGame() != nil {
-		_ = gamer.GetGame().Leave(gamer.ID)
+	if g := gamer.GetGame(); g != nil {
+		_ = g.Leave(gamer.ID)
 		gamer.SetGame(nil)
+		forgetGame(gamers, admins, g)
+	}
+}
+
+// forgetGame forgets the administrator of g when no gamers of the pool play it
+func forgetGame(gamers map[int]*game.Gamer, admins games, g *game.Game) {
+	for _, gamer := range gamers {
+		if gamer.GetGame() == g {
+			return
+		}
+	}
+	delete(admins, g)
+}
+
+// forceEndGame implements concurrently safe processing of querry of
+// ForceEndGame function
+func forceEndGame(gamers map[int]*game.Gamer, admins games, cmd *command) {
+	defer close(cmd.rez)
+
+	gamer, ok := gamers[cmd.id]
+	if ok == false {
+		cmd.rez <- fmt.Errorf("failed to end game for id %d: %w", cmd.id, ErrIDNotFound)
+		return
+	}
+	admin, ok := admins[gamer.GetGame()]
+	if ok == false {
+		cmd.rez <- fmt.Errorf("failed to end game for id %d: %w", cmd.id, ErrNoGame)
+		return
+	}
+	if err := admin.ForceEnd(cmd.reason, cmd.winner); err != nil {
+		cmd.rez <- fmt.Errorf("failed to end game for id %d: %w", cmd.id, err)
 	}
 }
 
+// getRating implements concurrently safe processing of querry of
+// GetRating and RatingHistory functions
+func getRating(gamers map[int]*game.Gamer, rs *ratings, id int, rezChan chan<- interface{}) {
+	defer close(rezChan)
+
+	if _, ok := gamers[id]; ok == false {
+		rezChan <- fmt.Errorf("failed to get rating for id %d: %w", id, ErrIDNotFound)
+		return
+	}
+	rating, history := rs.get(id)
+	rezChan <- &ratingRez{rating: rating, history: history}
+}
+
+// ratingRez is a result of getRating
+type ratingRez struct {
+	rating  Rating
+	history []RatingChange
+}
+
 // run processes commads for thread safe operations on pool.
 func (gp GamersPool) run() {
 	gamers := make(map[int]*game.Gamer)
+	admins := make(games)
+	rs := newRatings()
 	go func(gp GamersPool) {
 		for cmd := range gp {
 			switch cmd.act {
@@ -195,15 +274,19 @@ func (gp GamersPool) run() {
 			case add:
 				addGamer(gamers, cmd.gamer, cmd.rez)
 			case lst:
-				listGamers(gamers, cmd.rez)
+				listGamers(gamers, rs, cmd.rez)
 			case rem:
-				rmGamer(gamers, cmd.id, cmd.rez)
+				rmGamer(gamers, rs, cmd.id, cmd.rez)
 			case joinG:
-				joinGame(gamers, cmd)
+				joinGame(gamers, admins, rs, cmd)
 			case releaseG:
-				releaseGame(gamers, cmd.id, cmd.rez)
+				releaseGame(gamers, admins, cmd.id, cmd.rez)
 			case getG:
-				getGamer(gamers, cmd.id, cmd.rez)
+				getGamer(gamers, rs, cmd.id, cmd.rez)
+			case getR:
+				getRating(gamers, rs, cmd.id, cmd.rez)
+			case forceEndG:
+				forceEndGame(gamers, admins, cmd)
 			}
 		}
 	}(gp)
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/yagoggame/gomaster/game"
+	"github.com/yagoggame/gomaster/game/igame"
 )
 
 var fastDurationThreshold = time.Duration(10) * time.Second
@@ -167,7 +168,7 @@ func TestGetGamer(t *testing.T) {
 			removedGamer, _ := pool.RmGamer(test.id)
 			if !(removedGamer == nil && gettedGamer == nil) &&
 				(removedGamer == nil || gettedGamer == nil ||
-					!reflect.DeepEqual(*gettedGamer, *test.gamer)) {
+					!reflect.DeepEqual(*gettedGamer, rated(test.gamer))) {
 				t.Errorf("Unexpected GetGamer and RmGamer results relationship:\nwant: same gamer\ngot: %v, %v",
 					gettedGamer, removedGamer)
 			}
@@ -243,3 +244,46 @@ func TestReleaseGame(t *testing.T) {
 
 	checkReleaseCounter(t, pool, releaseCounter)
 }
+
+// TestForceEndGame tests ForceEndGame function
+func TestForceEndGame(t *testing.T) {
+	pool := NewGamersPool()
+	defer pool.Release()
+	for _, g := range validGamers[:3] {
+		if err := pool.AddGamer(g); err != nil {
+			t.Fatalf("Unexpected fail on AddGamer: %q ", err)
+		}
+	}
+	for _, g := range validGamers[:2] {
+		if err := pool.JoinGame(g.ID, usualSize, usualKomi); err != nil {
+			t.Fatalf("Unexpected fail on JoinGame: %q ", err)
+		}
+	}
+
+	if err := pool.ForceEndGame(0, "maintenance", igame.NoColour); !errors.Is(err, ErrIDNotFound) {
+		t.Errorf("Unexpected ForceEndGame err for unknown id:\nwant: %v,\ngot: %v", ErrIDNotFound, err)
+	}
+	if err := pool.ForceEndGame(validGamers[2].ID, "maintenance", igame.NoColour); !errors.Is(err, ErrNoGame) {
+		t.Errorf("Unexpected ForceEndGame err for gamer without game:\nwant: %v,\ngot: %v", ErrNoGame, err)
+	}
+	if err := pool.ForceEndGame(validGamers[0].ID, "cheating", igame.ChipColour(3)); !errors.Is(err, game.ErrWrongColour) {
+		t.Errorf("Unexpected ForceEndGame err for unknown colour:\nwant: %v,\ngot: %v", game.ErrWrongColour, err)
+	}
+	if err := pool.ForceEndGame(validGamers[0].ID, "cheating", igame.White); err != nil {
+		t.Fatalf("Unexpected ForceEndGame err: %v", err)
+	}
+
+	gamer, err := pool.GetGamer(validGamers[1].ID)
+	if err != nil {
+		t.Fatalf("Unexpected GetGamer err: %v", err)
+	}
+	result, err := gamer.GetGame().Result(gamer.ID)
+	if err != nil || result.Winner != igame.White || result.Method != igame.ForfeitMethod {
+		t.Errorf("Unexpected Result after ForceEndGame:\nwant: win of white by forfeit,\ngot: %v, err: %v", result, err)
+	}
+	for _, g := range validGamers[:2] {
+		if err := pool.ReleaseGame(g.ID); err != nil {
+			t.Errorf("Unexpected fail on ReleaseGame: %q ", err)
+		}
+	}
+}
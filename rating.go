@@ -0,0 +1,223 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package gomaster
+
+import (
+	"math"
+	"sync"
+
+	"github.com/yagoggame/gomaster/game"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// Rating is the Glicko-2 rating of a gamer
+type Rating struct {
+	Rating     float64 // rating on the Glicko scale
+	Deviation  float64 // rating deviation, the less it is the more reliable the rating is
+	Volatility float64 // degree of expected fluctuation of the rating
+}
+
+// DefaultRating is the rating of a gamer who played no rated games in the pool
+var DefaultRating = Rating{Rating: 1500, Deviation: 350, Volatility: 0.06}
+
+// RatingChange describes the change of the rating of a gamer by a game of the pool
+type RatingChange struct {
+	Opponent int     // id of the opponent
+	Score    float64 // 1 for a win, 0.5 for a draw, 0 for a loss
+	Before   Rating
+	After    Rating
+}
+
+// constants of the Glicko-2 rating system
+const (
+	glickoScale   = 173.7178 // factor between the Glicko and the Glicko-2 scales
+	glickoTau     = 0.5      // constraint of the change of volatility over time
+	glickoEpsilon = 0.000001 // tolerance of the calculation of volatility
+)
+
+// outcome is a result of a game against the opponent with rating
+type outcome struct {
+	opponent Rating
+	score    float64
+}
+
+// glicko2 returns r updated by outcomes of games of one rating period
+// by the algorithm of "Example of the Glicko-2 system" by Mark E. Glickman
+func glicko2(r Rating, outcomes []outcome) Rating {
+	mu, phi := (r.Rating-DefaultRating.Rating)/glickoScale, r.Deviation/glickoScale
+	if len(outcomes) == 0 {
+		phi = math.Sqrt(phi*phi + r.Volatility*r.Volatility)
+		return Rating{Rating: r.Rating, Deviation: phi * glickoScale, Volatility: r.Volatility}
+	}
+
+	var invV, sum float64
+	for _, o := range outcomes {
+		muJ, phiJ := (o.opponent.Rating-DefaultRating.Rating)/glickoScale, o.opponent.Deviation/glickoScale
+		g := 1 / math.Sqrt(1+3*phiJ*phiJ/(math.Pi*math.Pi))
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		invV += g * g * e * (1 - e)
+		sum += g * (o.score - e)
+	}
+	v := 1 / invV
+	delta := v * sum
+
+	sigma := volatility(phi, r.Volatility, v, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu += phi * phi * sum
+	return Rating{Rating: mu*glickoScale + DefaultRating.Rating, Deviation: phi * glickoScale, Volatility: sigma}
+}
+
+// volatility calculates the new volatility by the Illinois algorithm
+func volatility(phi, sigma, v, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(glickoTau*glickoTau)
+	}
+
+	A, B := a, 0.0
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*glickoTau) < 0 {
+			k++
+		}
+		B = a - k*glickoTau
+	}
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glickoEpsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
+
+// ratings holds ratings of gamers and gamers of games of the pool.
+// It's shared by the pool and callbacks of its games, so it's guarded by the mutex.
+type ratings struct {
+	mu      sync.Mutex
+	current map[int]Rating                          // ratings of gamers by id, DefaultRating if missing
+	history map[int][]RatingChange                  // changes of ratings of gamers by id, oldest first
+	players map[*game.Game]map[igame.ChipColour]int // ids of gamers of games in progress by colour
+}
+
+func newRatings() *ratings {
+	return &ratings{
+		current: make(map[int]Rating),
+		history: make(map[int][]RatingChange),
+		players: make(map[*game.Game]map[igame.ChipColour]int),
+	}
+}
+
+// rating returns the rating of the gamer with id
+func (rs *ratings) rating(id int) Rating {
+	if r, ok := rs.current[id]; ok == true {
+		return r
+	}
+	return DefaultRating
+}
+
+// table notes g as a game in progress and returns the map of its gamers filled by seat
+func (rs *ratings) table(g *game.Game) map[igame.ChipColour]int {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	players := make(map[igame.ChipColour]int)
+	rs.players[g] = players
+	return players
+}
+
+// seat notes the gamer with id playing by colour in g, if g is still in progress
+func (rs *ratings) seat(g *game.Game, colour igame.ChipColour, id int) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if players, ok := rs.players[g]; ok == true {
+		players[colour] = id
+	}
+}
+
+// rate updates ratings of players of the game finished with result.
+// Games without the winner are rated as draws if scores are equal, otherwise they aren't rated.
+func (rs *ratings) rate(players map[igame.ChipColour]int, result igame.Result) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	black, okB := players[igame.Black]
+	white, okW := players[igame.White]
+	if okB == false || okW == false {
+		return
+	}
+
+	var score float64
+	switch {
+	case result.Winner == igame.Black:
+		score = 1
+	case result.Winner == igame.White:
+		score = 0
+	case result.Jigo:
+		score = 0.5
+	default:
+		return
+	}
+
+	rB, rW := rs.rating(black), rs.rating(white)
+	rs.update(black, white, rB, glicko2(rB, []outcome{{opponent: rW, score: score}}), score)
+	rs.update(white, black, rW, glicko2(rW, []outcome{{opponent: rB, score: 1 - score}}), 1-score)
+}
+
+// forget drops g destroyed with or without the result.
+// The game can't be seated after this, but it still can be rated by the map returned by table.
+func (rs *ratings) forget(g *game.Game) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	delete(rs.players, g)
+}
+
+// rated returns a copy of gamer with the current rating of it
+func (rs *ratings) rated(gamer *game.Gamer) *game.Gamer {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	gCpy := *gamer
+	gCpy.Rating = rs.rating(gamer.ID).Rating
+	return &gCpy
+}
+
+// update sets the rating of the gamer with id changed by the game against opponent
+func (rs *ratings) update(id, opponent int, before, after Rating, score float64) {
+	rs.current[id] = after
+	rs.history[id] = append(rs.history[id], RatingChange{Opponent: opponent, Score: score, Before: before, After: after})
+}
+
+// get returns the rating and copy of the history of changes of it of the gamer with id
+func (rs *ratings) get(id int) (Rating, []RatingChange) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return rs.rating(id), append([]RatingChange(nil), rs.history[id]...)
+}
@@ -0,0 +1,123 @@
+// Copyright ©2020 BlinnikovAA. All rights reserved.
+// This file is part of yagogame.
+//
+// yagogame is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// yagogame is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with yagogame.  If not, see <https://www.gnu.org/licenses/>.
+
+package gomaster
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/yagoggame/gomaster/game"
+	"github.com/yagoggame/gomaster/game/igame"
+)
+
+// TestGlicko2 checks the calculation by the example of the Glicko-2 system by Mark E. Glickman.
+func TestGlicko2(t *testing.T) {
+	r := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	outcomes := []outcome{
+		{opponent: Rating{Rating: 1400, Deviation: 30}, score: 1},
+		{opponent: Rating{Rating: 1550, Deviation: 100}, score: 0},
+		{opponent: Rating{Rating: 1700, Deviation: 300}, score: 0},
+	}
+	got := glicko2(r, outcomes)
+	want := Rating{Rating: 1464.06, Deviation: 151.52, Volatility: 0.05999}
+	if math.Abs(got.Rating-want.Rating) > 0.01 || math.Abs(got.Deviation-want.Deviation) > 0.01 ||
+		math.Abs(got.Volatility-want.Volatility) > 0.00001 {
+		t.Errorf("Unexpected rating:\nwant: %+v,\ngot: %+v", want, got)
+	}
+}
+
+// TestRating checks update of ratings of gamers by the game of the pool.
+func TestRating(t *testing.T) {
+	pool := NewGamersPool()
+	defer pool.Release()
+	winner, loser := validGamers[0], validGamers[1]
+	for _, g := range []*game.Gamer{winner, loser} {
+		if err := pool.AddGamer(g); err != nil {
+			t.Fatalf("Unexpected fail on AddGamer: %q ", err)
+		}
+		if err := pool.JoinGame(g.ID, usualSize, usualKomi); err != nil {
+			t.Fatalf("Unexpected fail on JoinGame: %q ", err)
+		}
+	}
+	if _, err := pool.GetRating(0); !errors.Is(err, ErrIDNotFound) {
+		t.Errorf("Unexpected GetRating err for unknown id:\nwant: %v,\ngot: %v", ErrIDNotFound, err)
+	}
+	if r, err := pool.GetRating(winner.ID); err != nil || r != DefaultRating {
+		t.Errorf("Unexpected initial rating:\nwant: %+v,\ngot: %+v, err: %v", DefaultRating, r, err)
+	}
+
+	gamer, err := pool.GetGamer(loser.ID)
+	if err != nil {
+		t.Fatalf("Unexpected GetGamer err: %v", err)
+	}
+	defer gamer.GetGame().End()
+	if err := gamer.GetGame().Resign(loser.ID); err != nil {
+		t.Fatalf("Unexpected Resign err: %v", err)
+	}
+
+	// ratings are updated asynchronously after the end of the game.
+	deadline := time.Now().Add(fastDurationThreshold)
+	var history []RatingChange
+	for len(history) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		if history, err = pool.RatingHistory(loser.ID); err != nil {
+			t.Fatalf("Unexpected RatingHistory err: %v", err)
+		}
+	}
+	if len(history) != 1 || history[0].Opponent != winner.ID || history[0].Score != 0 || history[0].Before != DefaultRating {
+		t.Fatalf("Unexpected rating history of the loser: %+v", history)
+	}
+	if r, err := pool.GetRating(loser.ID); err != nil || r != history[0].After || r.Rating >= DefaultRating.Rating {
+		t.Errorf("Unexpected rating of the loser: %+v, err: %v", r, err)
+	}
+	if r, err := pool.GetRating(winner.ID); err != nil || r.Rating <= DefaultRating.Rating {
+		t.Errorf("Unexpected rating of the winner: %+v, err: %v", r, err)
+	}
+	if g, err := pool.GetGamer(winner.ID); err != nil || g.Rating <= DefaultRating.Rating {
+		t.Errorf("Unexpected rating of the winner by GetGamer: %v, err: %v", g, err)
+	}
+}
+
+// TestRatingsForget checks that destroyed games are forgotten, but still can be rated.
+func TestRatingsForget(t *testing.T) {
+	rs := newRatings()
+	g, err := game.NewGame(usualSize, usualKomi)
+	if err != nil {
+		t.Fatalf("Unexpected NewGame err: %v", err)
+	}
+	defer g.End()
+
+	players := rs.table(g)
+	rs.seat(g, igame.Black, validGamers[0].ID)
+	rs.forget(g)
+	if len(rs.players) != 0 {
+		t.Errorf("Unexpected games in progress after forget: %v", rs.players)
+	}
+	// the game is destroyed, so the gamer joined later isn't seated.
+	rs.seat(g, igame.White, validGamers[1].ID)
+	if len(rs.players) != 0 || len(players) != 1 {
+		t.Errorf("Unexpected seat of the forgotten game: %v, %v", rs.players, players)
+	}
+
+	players[igame.White] = validGamers[1].ID
+	rs.rate(players, igame.Result{Winner: igame.Black})
+	if r, _ := rs.get(validGamers[0].ID); r.Rating <= DefaultRating.Rating {
+		t.Errorf("Unexpected rating of the winner of the forgotten game: %+v", r)
+	}
+}